@@ -15,9 +15,11 @@
 package v1beta2
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
@@ -32,6 +34,17 @@ type CryostatSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=2
 	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+	// Label selector for namespaces whose workloads Cryostat should be
+	// permitted to access and profile. Namespaces matching this selector
+	// are added to those listed in Target Namespaces, and the set is updated
+	// as namespaces are created, deleted or relabelled.
+	// Warning: All Cryostat users will be able to create and manage
+	// recordings for workloads in the matching namespaces.
+	// Using a selector requires permission to create Cryostat CRs in all namespaces.
+	// More details: https://github.com/cryostatio/cryostat-operator/blob/v4.0.0/docs/config.md#target-namespace-selector
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target Namespace Selector"
+	TargetNamespaceSelector *metav1.LabelSelector `json:"targetNamespaceSelector,omitempty"`
 	// List of TLS certificates to trust when connecting to targets.
 	// Each entry may reference either a Secret or a ConfigMap in the local namespace.
 	// +optional
@@ -58,6 +71,10 @@ type CryostatSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=3,displayName="Enable cert-manager Integration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	EnableCertManager *bool `json:"enableCertManager"`
+	// Options to customize how TLS certificates are issued for Cryostat components.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Options"
+	TLSOptions *TLSOptions `json:"tlsOptions,omitempty"`
 	// Options to customize the storage provisioned for the database and object storage.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -191,6 +208,118 @@ type ResourceConfigList struct {
 	AgentProxyResources corev1.ResourceRequirements `json:"agentProxyResources,omitempty"`
 }
 
+// TLSOptions provides customization for the TLS certificates issued for Cryostat components.
+type TLSOptions struct {
+	// Component that issues the TLS certificates for Cryostat components. "CertManager" uses
+	// cert-manager when "enableCertManager" is true, and disables TLS otherwise. "Operator" has the
+	// operator generate and renew a CA and certificates itself, without requiring cert-manager.
+	// "enableCertManager" and "issuerRef" are ignored when using "Operator". Defaults to "CertManager".
+	// +optional
+	// +kubebuilder:validation:Enum=CertManager;Operator
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Provider",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:CertManager","urn:alm:descriptor:com.tectonic.ui:select:Operator"}
+	CertificateProvider *string `json:"certificateProvider,omitempty"`
+	// Reference to an existing cert-manager Issuer or ClusterIssuer that should issue the
+	// certificates for Cryostat components, in place of the self-signed CA created by the operator.
+	// An Issuer must be in the same namespace as Cryostat. The issued certificates must include
+	// the issuing CA in their "ca.crt" key, which is distributed to target namespaces.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Issuer Reference"
+	IssuerRef *IssuerReference `json:"issuerRef,omitempty"`
+	// Options for the CA certificate created for Cryostat. Not used with "issuerRef",
+	// since the CA belongs to the user-provided issuer.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Certificate Options"
+	CACertificate *CertificateOptions `json:"caCertificate,omitempty"`
+	// Options for the certificates issued for Cryostat components and agents.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Options"
+	Certificates *CertificateOptions `json:"certificates,omitempty"`
+	// How long the previous CA certificate remains trusted after the CA certificate changes.
+	// During this time, Cryostat components and agents trust both CA certificates, so that
+	// certificates signed by either are accepted while they are reissued and workloads restart.
+	// Defaults to 24h.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Rotation Grace Period",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	CARotationGracePeriod *metav1.Duration `json:"caRotationGracePeriod,omitempty"`
+	// How long a cert-manager certificate may remain not ready before the operator emits a
+	// Warning Event describing it. Defaults to 10m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Certificate Ready Timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	CertificateReadyTimeout *metav1.Duration `json:"certificateReadyTimeout,omitempty"`
+	// How the trusted CA certificates are distributed to target namespaces. "Secret" copies them
+	// into a Secret in each target namespace. "TrustManager" creates a trust-manager Bundle that
+	// synchronizes them into a ConfigMap in each target namespace, which injected agents then trust.
+	// Falls back to "Secret" if trust-manager is not installed. Defaults to "Secret".
+	// +optional
+	// +kubebuilder:validation:Enum=Secret;TrustManager
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Distribution",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Secret","urn:alm:descriptor:com.tectonic.ui:select:TrustManager"}
+	CADistribution *string `json:"caDistribution,omitempty"`
+	// TLS security profile that determines the TLS versions and ciphers accepted by Cryostat
+	// components and agents. Uses the same format as the OpenShift API server's "tlsSecurityProfile".
+	// On OpenShift, defaults to the API server's profile, otherwise defaults to "Intermediate".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TLS Security Profile"
+	SecurityProfile *configv1.TLSSecurityProfile `json:"securityProfile,omitempty"`
+	// Use TLS for connections to the Grafana and JFR datasource containers, which otherwise only
+	// accept HTTP connections from within the Cryostat pod. Certificates for these containers are
+	// issued from the Cryostat CA, which the containers connecting to them trust. Has no effect if
+	// TLS is disabled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sidecar TLS",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	SidecarTLS bool `json:"sidecarTLS,omitempty"`
+}
+
+// CertificateOptions customizes the lifetime and private key of certificates.
+// +kubebuilder:validation:XValidation:rule="!has(self.duration) || duration(self.duration) >= duration('1h')",message="duration must be at least 1h"
+// +kubebuilder:validation:XValidation:rule="!has(self.duration) || !has(self.renewBefore) || duration(self.renewBefore) < duration(self.duration)",message="renewBefore must be less than duration"
+type CertificateOptions struct {
+	// How long the certificates are valid for. Defaults to 90 days (2160h).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// How long before expiry the certificates are renewed. Defaults to one third of the duration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// Options for the private keys of the certificates.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PrivateKey *PrivateKeyOptions `json:"privateKey,omitempty"`
+}
+
+// PrivateKeyOptions customizes the private key of certificates.
+// +kubebuilder:validation:XValidation:rule="!has(self.size) || (has(self.algorithm) && self.algorithm == 'ECDSA' ? self.size in [256, 384, 521] : self.size in [2048, 3072, 4096])",message="size must be one of 2048, 3072 or 4096 for RSA, and one of 256, 384 or 521 for ECDSA"
+type PrivateKeyOptions struct {
+	// Algorithm of the private key, either "RSA" or "ECDSA". Defaults to "RSA".
+	// +optional
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:RSA","urn:alm:descriptor:com.tectonic.ui:select:ECDSA"}
+	Algorithm string `json:"algorithm,omitempty"`
+	// Size of the private key in bits. For RSA, one of 2048, 3072 or 4096, defaulting to 2048.
+	// For ECDSA, the curve size of 256, 384 or 521, defaulting to 256.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Size int `json:"size,omitempty"`
+	// Whether a new private key is generated each time a certificate is renewed ("Always"),
+	// or the existing private key is reused ("Never"). Defaults to "Always".
+	// +optional
+	// +kubebuilder:validation:Enum=Always;Never
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Always","urn:alm:descriptor:com.tectonic.ui:select:Never"}
+	RotationPolicy string `json:"rotationPolicy,omitempty"`
+}
+
+// IssuerReference refers to a cert-manager Issuer or ClusterIssuer.
+type IssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Kind of the issuer, either "Issuer" or "ClusterIssuer". Defaults to "Issuer".
+	// +optional
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Issuer","urn:alm:descriptor:com.tectonic.ui:select:ClusterIssuer"}
+	Kind string `json:"kind,omitempty"`
+}
+
 // CryostatStatus defines the observed state of Cryostat.
 type CryostatStatus struct {
 	// List of namespaces that Cryostat has been configured
@@ -213,6 +342,104 @@ type CryostatStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,order=2,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
 	DatabaseSecret string `json:"databaseSecret,omitempty"`
+	// The most recent generation of this Cryostat that has been observed by the operator.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Observed Generation"
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Summary of Cryostat agent injection for pods labelled to use this Cryostat, by namespace.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Agent Injection"
+	AgentInjection []AgentInjectionStatus `json:"agentInjection,omitempty"`
+	// Persistent volumes used by the database and object storage deployed by the operator.
+	// +optional
+	// +listType=map
+	// +listMapKey=component
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Volumes"
+	Volumes []VolumeStatus `json:"volumes,omitempty"`
+	// Retention policies applied to object storage buckets.
+	// +optional
+	// +listType=map
+	// +listMapKey=bucket
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Storage Retention"
+	StorageRetention []BucketRetentionStatus `json:"storageRetention,omitempty"`
+}
+
+// RetentionEnforcement describes how a retention limit is enforced.
+type RetentionEnforcement string
+
+const (
+	// The limit is enforced by the object storage's bucket lifecycle configuration.
+	RetentionEnforcedByLifecycle RetentionEnforcement = "Lifecycle"
+	// The limit is enforced by a CronJob that periodically prunes the bucket.
+	RetentionEnforcedByPruning RetentionEnforcement = "Pruning"
+)
+
+// BucketRetentionStatus describes the retention policy applied to an object storage bucket.
+type BucketRetentionStatus struct {
+	// Name of the bucket.
+	Bucket string `json:"bucket"`
+	// Objects older than this number of days are deleted.
+	// +optional
+	MaxAgeDays *int32 `json:"maxAgeDays,omitempty"`
+	// How the age limit is enforced, either "Lifecycle" or "Pruning".
+	// +optional
+	MaxAgeEnforcedBy RetentionEnforcement `json:"maxAgeEnforcedBy,omitempty"`
+	// The oldest objects are deleted when the bucket exceeds this total size.
+	// +optional
+	MaxTotalSize *resource.Quantity `json:"maxTotalSize,omitempty"`
+	// How the size limit is enforced. This is always "Pruning".
+	// +optional
+	MaxTotalSizeEnforcedBy RetentionEnforcement `json:"maxTotalSizeEnforcedBy,omitempty"`
+}
+
+// VolumeStatus describes the PersistentVolumeClaim used by a Cryostat component.
+type VolumeStatus struct {
+	// Component using the volume, either "database" or "storage".
+	Component string `json:"component"`
+	// Name of the PersistentVolumeClaim in use. This is empty while the component's data is
+	// copied from an emptyDir volume.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+	// Storage requested for the volume.
+	// +optional
+	Requested *resource.Quantity `json:"requested,omitempty"`
+	// Storage capacity of the bound volume.
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Name of the PersistentVolumeClaim that the component's data is being copied to,
+	// while a migration is in progress.
+	// +optional
+	MigrationTarget string `json:"migrationTarget,omitempty"`
+	// Number of replicas of the component's Deployment before it was stopped to copy its data
+	// to a new volume. The Deployment is scaled back to this number if the copy fails.
+	// +optional
+	StoppedReplicas *int32 `json:"stoppedReplicas,omitempty"`
+}
+
+// AgentInjectionStatus summarizes Cryostat agent injection for pods within a namespace.
+type AgentInjectionStatus struct {
+	// Namespace containing the pods.
+	Namespace string `json:"namespace"`
+	// Number of pods that were injected with the Cryostat agent.
+	InjectedPods int32 `json:"injectedPods"`
+	// Number of pods labelled to use this Cryostat that were not injected with the Cryostat agent.
+	NotInjectedPods int32 `json:"notInjectedPods"`
+	// The most recently created pods that were not injected with the Cryostat agent.
+	// +optional
+	RecentFailures []AgentInjectionFailure `json:"recentFailures,omitempty"`
+}
+
+// AgentInjectionFailure describes a pod that was not injected with the Cryostat agent.
+type AgentInjectionFailure struct {
+	// Name of the pod.
+	PodName string `json:"podName"`
+	// Reason the Cryostat agent was not injected into the pod, in CamelCase.
+	Reason string `json:"reason"`
+	// Human-readable details about the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Creation time of the pod.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
 }
 
 // CryostatConditionType refers to a Condition type that may be used in status.conditions
@@ -245,6 +472,39 @@ const (
 	ConditionTypeReportsDeploymentReplicaFailure CryostatConditionType = "ReportsDeploymentReplicaFailure"
 	// If enabled, whether TLS setup is complete for the Cryostat components.
 	ConditionTypeTLSSetupComplete CryostatConditionType = "TLSSetupComplete"
+	// If TLS is enabled, whether a change of the CA certificate has been completed.
+	// This is false while the previous CA certificate is still trusted alongside the current one.
+	ConditionTypeCARotationComplete CryostatConditionType = "CARotationComplete"
+	// Whether the namespaces matching the target namespace selector have been resolved.
+	ConditionTypeTargetNamespacesReady CryostatConditionType = "TargetNamespacesReady"
+	// Whether this Cryostat holds the lock ConfigMap reserving its name in the installation namespace.
+	ConditionTypeLockReady CryostatConditionType = "LockReady"
+	// Whether the Secrets containing generated credentials for Cryostat components are ready.
+	ConditionTypeSecretsReady CryostatConditionType = "SecretsReady"
+	// Whether the service account, roles and role bindings for Cryostat are ready.
+	ConditionTypeRBACReady CryostatConditionType = "RBACReady"
+	// Whether the services, network policies, and any Routes or Ingresses exposing Cryostat are ready.
+	ConditionTypeNetworkReady CryostatConditionType = "NetworkReady"
+	// Whether the agent gateway and the agent callback services in each target namespace are ready.
+	ConditionTypeAgentGatewayReady CryostatConditionType = "AgentGatewayReady"
+	// If the database uses a PersistentVolumeClaim, whether it is bound with the requested capacity.
+	// This is false while the volume is resized or its data is migrated to a new volume.
+	ConditionTypeDatabaseVolumeReady CryostatConditionType = "DatabaseVolumeReady"
+	// If the object storage uses a PersistentVolumeClaim, whether it is bound with the requested capacity.
+	// This is false while the volume is resized or its data is migrated to a new volume.
+	ConditionTypeStorageVolumeReady CryostatConditionType = "StorageVolumeReady"
+	// If database backups or a restore are configured, whether backups are scheduled and the restore has not failed.
+	ConditionTypeDatabaseBackupReady CryostatConditionType = "DatabaseBackupReady"
+	// If object storage retention is configured, whether the retention policies are enforced.
+	ConditionTypeStorageRetentionReady CryostatConditionType = "StorageRetentionReady"
+	// Whether the persistent storage, database and object storage for Cryostat are ready.
+	ConditionTypeStorageReady CryostatConditionType = "StorageReady"
+	// Whether the reports generator deployment is up to date, or scaled down if not configured.
+	ConditionTypeReportsReady CryostatConditionType = "ReportsReady"
+	// Whether the main Cryostat deployment is up to date.
+	ConditionTypeCoreReady CryostatConditionType = "CoreReady"
+	// Whether all components of this Cryostat are ready. This summarizes the other conditions.
+	ConditionTypeReady CryostatConditionType = "Ready"
 )
 
 // StorageConfigurations provides customization to the storage provisioned for
@@ -503,10 +763,34 @@ type NetworkConfiguration struct {
 	// (if a single external IP is being used) to differentiate between ingresses/services.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	IngressSpec      *netv1.IngressSpec `json:"ingressSpec,omitempty"`
+	IngressSpec *netv1.IngressSpec `json:"ingressSpec,omitempty"`
+	// Certificate presented to clients outside the cluster by the Route or Ingress,
+	// in place of the default certificate of the OpenShift router or Ingress controller.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External TLS"
+	TLS              *ExternalTLSConfig `json:"tls,omitempty"`
 	ResourceMetadata `json:",inline"`
 }
 
+// ExternalTLSConfig references an existing certificate used to serve a Cryostat
+// service outside the cluster.
+type ExternalTLSConfig struct {
+	// Name of a Secret of type "kubernetes.io/tls" in the same namespace as Cryostat. The certificate
+	// in its "tls.crt" key must be valid for the external host. If present, the "ca.crt" key is
+	// included in the Route as the CA certificate that issued the certificate.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	SecretName string `json:"secretName"`
+	// Where TLS is terminated for the external host. "Reencrypt" terminates TLS at the Route or Ingress
+	// and connects to Cryostat using HTTPS, trusting the certificate issued for Cryostat within the cluster.
+	// "Edge" terminates TLS at the Route or Ingress and connects to Cryostat using HTTP, which requires
+	// TLS within the cluster to be disabled. Defaults to "Reencrypt" if TLS within the cluster is enabled,
+	// and "Edge" otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=Edge;Reencrypt
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Edge","urn:alm:descriptor:com.tectonic.ui:select:Reencrypt"}
+	Termination *string `json:"termination,omitempty"`
+}
+
 // NetworkConfigurationList holds NetworkConfiguration objects that specify
 // how to expose the services created by the operator for the main Cryostat
 // deployment.
@@ -583,6 +867,7 @@ type TargetConnectionCacheOptions struct {
 // +kubebuilder:printcolumn:name="Target Namespaces",type=string,JSONPath=`.status.targetNamespaces`
 // +kubebuilder:printcolumn:name="Storage Secret",type=string,JSONPath=`.status.storageSecret`
 // +kubebuilder:printcolumn:name="Database Secret",type=string,JSONPath=`.status.databaseSecret`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 type Cryostat struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -810,6 +1095,7 @@ type TargetDiscoveryOptions struct {
 }
 
 // DatabaseOptions provides configuration options to the Cryostat application's database.
+// +kubebuilder:validation:XValidation:rule="!has(self.restore) || has(self.restore.source) || has(self.backup)",message="restore.source must be specified when backup is not configured"
 type DatabaseOptions struct {
 	// Name of the secret containing database keys. This secret must contain a CONNECTION_KEY secret which is the
 	// database connection password, and an ENCRYPTION_KEY secret which is the key used to encrypt sensitive data
@@ -819,11 +1105,124 @@ type DatabaseOptions struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
 	SecretName *string `json:"secretName,omitempty"`
+	// Configuration for an external PostgreSQL database. If specified, the operator will not deploy
+	// a database for Cryostat, and Cryostat will instead connect to this database.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External Database Options"
+	External *ExternalDatabaseOptions `json:"external,omitempty"`
+	// Configuration for scheduled backups of the database deployed by the operator.
+	// Ignored when using an external database.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Database Backup Options"
+	Backup *DatabaseBackupOptions `json:"backup,omitempty"`
+	// Restore the database deployed by the operator from a backup, before Cryostat starts.
+	// The backup is only restored into an empty database, such as that of a new Cryostat installation.
+	// Ignored when using an external database.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Database Restore Options"
+	Restore *DatabaseRestoreOptions `json:"restore,omitempty"`
+}
+
+// DatabaseBackupOptions configures scheduled backups of the Cryostat database.
+type DatabaseBackupOptions struct {
+	// Schedule for database backups, in Cron format.
+	// More details: https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#schedule-syntax
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedule string `json:"schedule"`
+	// Number of most recent backups to keep. Older backups are deleted after each backup. Defaults to 7.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Retention *int32 `json:"retention,omitempty"`
+	// Where database backups are stored.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Destination DatabaseBackupDestination `json:"destination"`
+}
+
+// DatabaseRestoreOptions selects a database backup to restore.
+type DatabaseRestoreOptions struct {
+	// Name of the backup to restore, such as "cryostat-20240102030405.dump".
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9._-]+$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BackupName string `json:"backupName"`
+	// Where the backup is stored. Defaults to the backup destination in .spec.databaseOptions.backup.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Source *DatabaseBackupDestination `json:"source,omitempty"`
+}
+
+// DatabaseBackupDestination is a location where database backups are stored.
+// +kubebuilder:validation:XValidation:rule="has(self.persistentVolumeClaim) != has(self.objectStorage)",message="exactly one of persistentVolumeClaim or objectStorage must be specified"
+type DatabaseBackupDestination struct {
+	// Store backups in an existing PersistentVolumeClaim. The claim is not managed by the operator,
+	// so that backups are kept if Cryostat is deleted.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PersistentVolumeClaim *DatabaseBackupPVC `json:"persistentVolumeClaim,omitempty"`
+	// Store backups in a bucket of the object storage used by Cryostat.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ObjectStorage *DatabaseBackupObjectStorage `json:"objectStorage,omitempty"`
+}
+
+// DatabaseBackupPVC refers to a PersistentVolumeClaim for database backups.
+type DatabaseBackupPVC struct {
+	// Name of the PersistentVolumeClaim in the installation namespace.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:PersistentVolumeClaim"}
+	ClaimName string `json:"claimName"`
+}
+
+// DatabaseBackupObjectStorage refers to a bucket for database backups.
+type DatabaseBackupObjectStorage struct {
+	// Name of the bucket to store backups in. The bucket is created if it does not exist.
+	// Defaults to "database-backups".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Bucket *string `json:"bucket,omitempty"`
+}
+
+// ExternalDatabaseOptions provides configuration options for connecting to an external PostgreSQL database.
+// +kubebuilder:validation:XValidation:rule="!has(self.sslMode) || !(self.sslMode in ['verify-ca', 'verify-full']) || has(self.caCertificate)",message="caCertificate must be specified when sslMode is verify-ca or verify-full"
+type ExternalDatabaseOptions struct {
+	// Hostname of the PostgreSQL server.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Host string `json:"host"`
+	// Port of the PostgreSQL server. Defaults to 5432.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Port *int32 `json:"port,omitempty"`
+	// Name of the database for Cryostat to use. Defaults to "cryostat".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Database *string `json:"database,omitempty"`
+	// SSL mode to use when connecting to the PostgreSQL server. Defaults to "verify-full" if
+	// a CA certificate is specified, and to the PostgreSQL JDBC driver's default ("prefer") otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:disable","urn:alm:descriptor:com.tectonic.ui:select:allow","urn:alm:descriptor:com.tectonic.ui:select:prefer","urn:alm:descriptor:com.tectonic.ui:select:require","urn:alm:descriptor:com.tectonic.ui:select:verify-ca","urn:alm:descriptor:com.tectonic.ui:select:verify-full"}
+	SSLMode *string `json:"sslMode,omitempty"`
+	// A secret or config map containing the CA certificate used to verify the PostgreSQL server's certificate.
+	// The key defaults to "tls.crt" for secrets, and "service-ca.crt" for config maps.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CA Certificate"
+	CACertificate *CertificateSecret `json:"caCertificate,omitempty"`
+	// Name of the secret containing the credentials Cryostat uses to connect to the database.
+	// This secret must contain "username" and "password" keys, as used by secrets of type kubernetes.io/basic-auth.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	CredentialsSecretName string `json:"credentialsSecretName"`
 }
 
 // ObjectStorageOptions provides configuration options to the Cryostat application's object storage.
 // If used, the .spec.objectStorageOptions section should always contain the .provider subsection, and
 // this subsection must contain the .url and .region properties.
+// +kubebuilder:validation:XValidation:rule="!has(self.credentials) || self.credentials.mode == 'Static' || has(self.provider)",message="credentials.mode must be Static unless an external object storage provider is configured"
 type ObjectStorageOptions struct {
 	// Name of the secret containing the object storage secret access key. This secret must contain a
 	// ACCESS_KEY secret which is the object storage access key ID, and a SECRET_KEY secret which is the object storage secret access key.
@@ -841,6 +1240,110 @@ type ObjectStorageOptions struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Bucket Names"
 	StorageBucketNameOptions *StorageBucketNameOptions `json:"storageBucketNameOptions,omitempty"`
+	// How Cryostat obtains credentials for the external object storage provider.
+	// Defaults to static access keys from the secret named by secretName.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Storage Credentials"
+	Credentials *ObjectStorageCredentials `json:"credentials,omitempty"`
+	// Retention policies for objects stored by Cryostat, by bucket.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Retention"
+	Retention *ObjectStorageRetention `json:"retention,omitempty"`
+}
+
+// ObjectStorageRetention configures how long objects are kept in Cryostat's object storage buckets.
+// Age limits are applied as S3 lifecycle configuration where the object storage supports it.
+// Otherwise, and for size limits, the operator periodically prunes the buckets.
+type ObjectStorageRetention struct {
+	// Retention policy for archived JFR files.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ArchivedRecordings *BucketRetentionRule `json:"archivedRecordings,omitempty"`
+	// Retention policy for the cache of Automated Analysis reports.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ArchivedReports *BucketRetentionRule `json:"archivedReports,omitempty"`
+	// Retention policy for JVM heap dumps.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HeapDumps *BucketRetentionRule `json:"heapDumps,omitempty"`
+	// Retention policy for JVM thread dumps.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ThreadDumps *BucketRetentionRule `json:"threadDumps,omitempty"`
+	// Schedule for pruning the buckets, in Cron format. Defaults to hourly.
+	// More details: https://kubernetes.io/docs/concepts/workloads/controllers/cron-jobs/#schedule-syntax
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PruneSchedule *string `json:"pruneSchedule,omitempty"`
+}
+
+// BucketRetentionRule limits the objects kept in a bucket.
+// +kubebuilder:validation:XValidation:rule="has(self.maxAgeDays) || has(self.maxTotalSize)",message="at least one of maxAgeDays or maxTotalSize must be specified"
+type BucketRetentionRule struct {
+	// Objects older than this number of days are deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	MaxAgeDays *int32 `json:"maxAgeDays,omitempty"`
+	// When the objects in the bucket exceed this total size, the oldest objects are deleted
+	// until they fit.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxTotalSize *resource.Quantity `json:"maxTotalSize,omitempty"`
+}
+
+// ObjectStorageCredentialsMode selects how Cryostat authenticates with object storage.
+type ObjectStorageCredentialsMode string
+
+const (
+	// Static access keys from the object storage secret.
+	ObjectStorageCredentialsStatic ObjectStorageCredentialsMode = "Static"
+	// Temporary credentials for an IAM role, obtained with a projected service account token.
+	ObjectStorageCredentialsWebIdentity ObjectStorageCredentialsMode = "WebIdentity"
+	// Credentials found by the AWS SDK's default credentials provider chain.
+	ObjectStorageCredentialsDefaultChain ObjectStorageCredentialsMode = "DefaultChain"
+)
+
+// ObjectStorageCredentials configures how Cryostat authenticates with an external object storage provider.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'WebIdentity' || has(self.webIdentity)",message="webIdentity must be specified when mode is WebIdentity"
+type ObjectStorageCredentials struct {
+	// The credentials mode. "Static" uses the ACCESS_KEY and SECRET_KEY from the object storage secret.
+	// "WebIdentity" assumes an IAM role using a service account token projected into the Cryostat pod,
+	// such as with IAM Roles for Service Accounts (IRSA) on EKS. "DefaultChain" uses the AWS SDK's
+	// default credentials provider chain, for credentials supplied by the environment.
+	// +kubebuilder:validation:Enum=Static;WebIdentity;DefaultChain
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Static","urn:alm:descriptor:com.tectonic.ui:select:WebIdentity","urn:alm:descriptor:com.tectonic.ui:select:DefaultChain"}
+	Mode ObjectStorageCredentialsMode `json:"mode"`
+	// Options for the WebIdentity credentials mode.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WebIdentity *WebIdentityCredentials `json:"webIdentity,omitempty"`
+	// Annotations to add to the Cryostat service account, as required by some workload identity
+	// integrations. For example, "iam.gke.io/gcp-service-account" for GKE Workload Identity.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+}
+
+// WebIdentityCredentials configures the IAM role assumed with a projected service account token.
+type WebIdentityCredentials struct {
+	// ARN of the IAM role for Cryostat to assume. The role's trust policy must allow the Cryostat
+	// service account through the cluster's OIDC identity provider.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Role ARN"
+	RoleARN string `json:"roleArn"`
+	// Audience of the projected service account token. Defaults to "sts.amazonaws.com".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Audience *string `json:"audience,omitempty"`
+	// Requested lifetime of the projected service account token, in seconds. The token is
+	// refreshed automatically before it expires. Defaults to 86400.
+	// +optional
+	// +kubebuilder:validation:Minimum=600
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
 }
 
 // ObjectStorageProviderOptions provides configuration options to the Cryostat application's external object storage.
@@ -926,6 +1429,31 @@ type AgentOptions struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Determines what happens to a pod when the Cryostat agent cannot be injected into it,
+	// when using the operator's agent auto-configuration feature.
+	// With "Ignore", the pod is created without the agent and a warning is returned.
+	// With "Fail", the pod is rejected.
+	// In both cases, a warning Event is recorded on the pod's owner.
+	// Defaults to "Ignore".
+	// +optional
+	// +kubebuilder:validation:Enum=Ignore;Fail
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Injection Failure Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:Ignore","urn:alm:descriptor:com.tectonic.ui:select:Fail"}
+	InjectionFailurePolicy *string `json:"injectionFailurePolicy,omitempty"`
+	// Default configuration properties for Cryostat agents injected by the operator's agent auto-configuration feature.
+	// Keys are agent property names, such as "cryostat.agent.webclient.connect.timeout-ms",
+	// or their environment variable equivalents, such as "CRYOSTAT_AGENT_WEBCLIENT_CONNECT_TIMEOUT_MS".
+	// Properties from a ConfigMap named by a pod's "cryostat.io/agent-config" label or annotation take precedence
+	// over these, and properties configured by the operator or in the container take precedence over both.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Agent Properties"
+	DefaultProperties map[string]string `json:"defaultProperties,omitempty"`
+	// Restart workloads injected with the Cryostat agent when the agent certificate for their namespace is reissued,
+	// so that agents load the new certificate. Deployments, StatefulSets and DaemonSets are restarted by
+	// annotating their pod templates, which rolls out new pods according to their update strategy.
+	// Pods owned by other kinds of workloads must be restarted manually.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Restart On Certificate Rotation",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	RestartOnCertificateRotation bool `json:"restartOnCertificateRotation,omitempty"`
 }
 
 // LoggingOptions provides configuration for logging levels of Cryostat components.
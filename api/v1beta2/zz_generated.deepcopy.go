@@ -19,6 +19,7 @@
 package v1beta2
 
 import (
+	configv1 "github.com/openshift/api/config/v1"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -93,10 +94,60 @@ func (in *AgentGatewayServiceConfig) DeepCopy() *AgentGatewayServiceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentInjectionFailure) DeepCopyInto(out *AgentInjectionFailure) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentInjectionFailure.
+func (in *AgentInjectionFailure) DeepCopy() *AgentInjectionFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentInjectionFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentInjectionStatus) DeepCopyInto(out *AgentInjectionStatus) {
+	*out = *in
+	if in.RecentFailures != nil {
+		in, out := &in.RecentFailures, &out.RecentFailures
+		*out = make([]AgentInjectionFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentInjectionStatus.
+func (in *AgentInjectionStatus) DeepCopy() *AgentInjectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentInjectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentOptions) DeepCopyInto(out *AgentOptions) {
 	*out = *in
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.InjectionFailurePolicy != nil {
+		in, out := &in.InjectionFailurePolicy, &out.InjectionFailurePolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultProperties != nil {
+		in, out := &in.DefaultProperties, &out.DefaultProperties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentOptions.
@@ -164,6 +215,86 @@ func (in *AutomatedRuleConfigMap) DeepCopy() *AutomatedRuleConfigMap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketRetentionRule) DeepCopyInto(out *BucketRetentionRule) {
+	*out = *in
+	if in.MaxAgeDays != nil {
+		in, out := &in.MaxAgeDays, &out.MaxAgeDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalSize != nil {
+		in, out := &in.MaxTotalSize, &out.MaxTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketRetentionRule.
+func (in *BucketRetentionRule) DeepCopy() *BucketRetentionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketRetentionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketRetentionStatus) DeepCopyInto(out *BucketRetentionStatus) {
+	*out = *in
+	if in.MaxAgeDays != nil {
+		in, out := &in.MaxAgeDays, &out.MaxAgeDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalSize != nil {
+		in, out := &in.MaxTotalSize, &out.MaxTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketRetentionStatus.
+func (in *BucketRetentionStatus) DeepCopy() *BucketRetentionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketRetentionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateOptions) DeepCopyInto(out *CertificateOptions) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(PrivateKeyOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateOptions.
+func (in *CertificateOptions) DeepCopy() *CertificateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSecret) DeepCopyInto(out *CertificateSecret) {
 	*out = *in
@@ -272,6 +403,11 @@ func (in *CryostatSpec) DeepCopyInto(out *CryostatSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TargetNamespaceSelector != nil {
+		in, out := &in.TargetNamespaceSelector, &out.TargetNamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TrustedCertSecrets != nil {
 		in, out := &in.TrustedCertSecrets, &out.TrustedCertSecrets
 		*out = make([]CertificateSecret, len(*in))
@@ -304,6 +440,11 @@ func (in *CryostatSpec) DeepCopyInto(out *CryostatSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TLSOptions != nil {
+		in, out := &in.TLSOptions, &out.TLSOptions
+		*out = new(TLSOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.StorageOptions != nil {
 		in, out := &in.StorageOptions, &out.StorageOptions
 		*out = new(StorageConfigurations)
@@ -416,6 +557,27 @@ func (in *CryostatStatus) DeepCopyInto(out *CryostatStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AgentInjection != nil {
+		in, out := &in.AgentInjection, &out.AgentInjection
+		*out = make([]AgentInjectionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]VolumeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageRetention != nil {
+		in, out := &in.StorageRetention, &out.StorageRetention
+		*out = make([]BucketRetentionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryostatStatus.
@@ -428,6 +590,87 @@ func (in *CryostatStatus) DeepCopy() *CryostatStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupDestination) DeepCopyInto(out *DatabaseBackupDestination) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(DatabaseBackupPVC)
+		**out = **in
+	}
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(DatabaseBackupObjectStorage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupDestination.
+func (in *DatabaseBackupDestination) DeepCopy() *DatabaseBackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupObjectStorage) DeepCopyInto(out *DatabaseBackupObjectStorage) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupObjectStorage.
+func (in *DatabaseBackupObjectStorage) DeepCopy() *DatabaseBackupObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupOptions) DeepCopyInto(out *DatabaseBackupOptions) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	in.Destination.DeepCopyInto(&out.Destination)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupOptions.
+func (in *DatabaseBackupOptions) DeepCopy() *DatabaseBackupOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseBackupPVC) DeepCopyInto(out *DatabaseBackupPVC) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseBackupPVC.
+func (in *DatabaseBackupPVC) DeepCopy() *DatabaseBackupPVC {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseBackupPVC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseOptions) DeepCopyInto(out *DatabaseOptions) {
 	*out = *in
@@ -436,6 +679,21 @@ func (in *DatabaseOptions) DeepCopyInto(out *DatabaseOptions) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalDatabaseOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(DatabaseBackupOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(DatabaseRestoreOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseOptions.
@@ -448,6 +706,26 @@ func (in *DatabaseOptions) DeepCopy() *DatabaseOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRestoreOptions) DeepCopyInto(out *DatabaseRestoreOptions) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(DatabaseBackupDestination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRestoreOptions.
+func (in *DatabaseRestoreOptions) DeepCopy() *DatabaseRestoreOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRestoreOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatabaseServiceConfig) DeepCopyInto(out *DatabaseServiceConfig) {
 	*out = *in
@@ -499,6 +777,76 @@ func (in *EmptyDirConfig) DeepCopy() *EmptyDirConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDatabaseOptions) DeepCopyInto(out *ExternalDatabaseOptions) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSLMode != nil {
+		in, out := &in.SSLMode, &out.SSLMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = new(CertificateSecret)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDatabaseOptions.
+func (in *ExternalDatabaseOptions) DeepCopy() *ExternalDatabaseOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDatabaseOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTLSConfig) DeepCopyInto(out *ExternalTLSConfig) {
+	*out = *in
+	if in.Termination != nil {
+		in, out := &in.Termination, &out.Termination
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTLSConfig.
+func (in *ExternalTLSConfig) DeepCopy() *ExternalTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuerReference) DeepCopyInto(out *IssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuerReference.
+func (in *IssuerReference) DeepCopy() *IssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LegacyStorageConfiguration) DeepCopyInto(out *LegacyStorageConfiguration) {
 	*out = *in
@@ -557,6 +905,11 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 		*out = new(networkingv1.IngressSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ExternalTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	in.ResourceMetadata.DeepCopyInto(&out.ResourceMetadata)
 }
 
@@ -655,6 +1008,33 @@ func (in *NetworkPolicyConfig) DeepCopy() *NetworkPolicyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageCredentials) DeepCopyInto(out *ObjectStorageCredentials) {
+	*out = *in
+	if in.WebIdentity != nil {
+		in, out := &in.WebIdentity, &out.WebIdentity
+		*out = new(WebIdentityCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageCredentials.
+func (in *ObjectStorageCredentials) DeepCopy() *ObjectStorageCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectStorageOptions) DeepCopyInto(out *ObjectStorageOptions) {
 	*out = *in
@@ -673,6 +1053,16 @@ func (in *ObjectStorageOptions) DeepCopyInto(out *ObjectStorageOptions) {
 		*out = new(StorageBucketNameOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(ObjectStorageCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ObjectStorageRetention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageOptions.
@@ -735,6 +1125,46 @@ func (in *ObjectStorageProviderOptions) DeepCopy() *ObjectStorageProviderOptions
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStorageRetention) DeepCopyInto(out *ObjectStorageRetention) {
+	*out = *in
+	if in.ArchivedRecordings != nil {
+		in, out := &in.ArchivedRecordings, &out.ArchivedRecordings
+		*out = new(BucketRetentionRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArchivedReports != nil {
+		in, out := &in.ArchivedReports, &out.ArchivedReports
+		*out = new(BucketRetentionRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeapDumps != nil {
+		in, out := &in.HeapDumps, &out.HeapDumps
+		*out = new(BucketRetentionRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ThreadDumps != nil {
+		in, out := &in.ThreadDumps, &out.ThreadDumps
+		*out = new(BucketRetentionRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PruneSchedule != nil {
+		in, out := &in.PruneSchedule, &out.PruneSchedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStorageRetention.
+func (in *ObjectStorageRetention) DeepCopy() *ObjectStorageRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStorageRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenShiftSSOConfig) DeepCopyInto(out *OpenShiftSSOConfig) {
 	*out = *in
@@ -806,6 +1236,21 @@ func (in *PersistentVolumeClaimConfig) DeepCopy() *PersistentVolumeClaimConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateKeyOptions) DeepCopyInto(out *PrivateKeyOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateKeyOptions.
+func (in *PrivateKeyOptions) DeepCopy() *PrivateKeyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateKeyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeTemplateConfigMap) DeepCopyInto(out *ProbeTemplateConfigMap) {
 	*out = *in
@@ -1246,6 +1691,61 @@ func (in *StorageServiceConfig) DeepCopy() *StorageServiceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSOptions) DeepCopyInto(out *TLSOptions) {
+	*out = *in
+	if in.CertificateProvider != nil {
+		in, out := &in.CertificateProvider, &out.CertificateProvider
+		*out = new(string)
+		**out = **in
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(IssuerReference)
+		**out = **in
+	}
+	if in.CACertificate != nil {
+		in, out := &in.CACertificate, &out.CACertificate
+		*out = new(CertificateOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificateOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CARotationGracePeriod != nil {
+		in, out := &in.CARotationGracePeriod, &out.CARotationGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CertificateReadyTimeout != nil {
+		in, out := &in.CertificateReadyTimeout, &out.CertificateReadyTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CADistribution != nil {
+		in, out := &in.CADistribution, &out.CADistribution
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(configv1.TLSSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSOptions.
+func (in *TLSOptions) DeepCopy() *TLSOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetConnectionCacheOptions) DeepCopyInto(out *TargetConnectionCacheOptions) {
 	*out = *in
@@ -1300,3 +1800,58 @@ func (in *TemplateConfigMap) DeepCopy() *TemplateConfigMap {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeStatus) DeepCopyInto(out *VolumeStatus) {
+	*out = *in
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StoppedReplicas != nil {
+		in, out := &in.StoppedReplicas, &out.StoppedReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeStatus.
+func (in *VolumeStatus) DeepCopy() *VolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebIdentityCredentials) DeepCopyInto(out *WebIdentityCredentials) {
+	*out = *in
+	if in.Audience != nil {
+		in, out := &in.Audience, &out.Audience
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebIdentityCredentials.
+func (in *WebIdentityCredentials) DeepCopy() *WebIdentityCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(WebIdentityCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -34,11 +35,16 @@ import (
 	openshiftoperatorv1 "github.com/openshift/api/operator/v1"
 	routev1 "github.com/openshift/api/route/v1"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -52,6 +58,7 @@ import (
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/fips"
+	"github.com/cryostatio/cryostat-operator/internal/tlsprofile"
 	"github.com/cryostatio/cryostat-operator/internal/webhook/agent"
 	webhook "github.com/cryostatio/cryostat-operator/internal/webhook/v1beta2"
 	// +kubebuilder:scaffold:imports
@@ -136,6 +143,11 @@ func main() {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	// Restrict the webhook and metrics servers to the cluster's TLS security profile.
+	// The profile is looked up once the manager is created, and updated when it changes.
+	serverTLSProfile := tlsprofile.NewDynamic(tlsprofile.Default())
+	tlsOpts = append(tlsOpts, serverTLSProfile.ApplyToTLSConfig)
+
 	// Create watchers for metrics and webhooks certificates
 	var metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher
 
@@ -272,6 +284,24 @@ func main() {
 		}
 	}
 
+	// If this is an OpenShift cluster, use the API server's TLS security profile
+	if openShift {
+		profile, err := tlsprofile.ForCluster(context.Background(), mgr.GetAPIReader())
+		if err != nil {
+			setupLog.Error(err, "could not determine the cluster's TLS security profile, using default")
+		} else {
+			serverTLSProfile.Set(profile)
+		}
+		err = serverTLSProfile.WatchCluster(context.Background(), mgr.GetCache(), func(profile *tlsprofile.Profile) {
+			setupLog.Info("TLS security profile changed", "minTLSVersion", profile.MinTLSVersion)
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to watch the cluster's TLS security profile")
+			os.Exit(1)
+		}
+	}
+	setupLog.Info("using TLS security profile", "minTLSVersion", serverTLSProfile.Get().MinTLSVersion)
+
 	// Optionally install OpenShift Console Plugin
 	if consolePlugin {
 		// Look up operator namespace
@@ -313,8 +343,20 @@ func main() {
 		}
 	}
 
+	// Cache pods that are labelled to use a Cryostat agent separately, rather than
+	// limiting the manager's cache of pods to them
+	agentPodCache, err := newAgentPodCache(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create agent pod cache")
+		os.Exit(1)
+	}
+	if err = mgr.Add(agentPodCache); err != nil {
+		setupLog.Error(err, "unable to add agent pod cache to manager")
+		os.Exit(1)
+	}
+
 	config := newReconcilerConfig(mgr, "Cryostat", "cryostat-controller", openShift, certManager,
-		insightsURL)
+		insightsURL, agentPodCache)
 	cryostatController, err := controller.NewCryostatReconciler(config)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cryostat")
@@ -324,6 +366,11 @@ func main() {
 		setupLog.Error(err, "unable to add controller to manager", "controller", "Cryostat")
 		os.Exit(1)
 	}
+	agentInjectionController := controller.NewAgentInjectionReconciler(config)
+	if err = agentInjectionController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to add controller to manager", "controller", "AgentInjection")
+		os.Exit(1)
+	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		if err = webhook.SetupWebhookWithManager(mgr, &operatorv1beta2.Cryostat{}); err != nil {
@@ -332,6 +379,7 @@ func main() {
 		}
 		agentWebhook := agent.NewAgentWebhook(&agent.AgentWebhookConfig{
 			FIPSEnabled: fipsEnabled,
+			IsOpenShift: openShift,
 		})
 		if err = agentWebhook.SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Pod")
@@ -372,6 +420,32 @@ func main() {
 	}
 }
 
+func newAgentPodCache(mgr ctrl.Manager) (cache.Cache, error) {
+	selector, err := newAgentPodSelector()
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(mgr.GetConfig(), cache.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Pod{}: {Label: selector},
+		},
+	})
+}
+
+func newAgentPodSelector() (labels.Selector, error) {
+	nameReq, err := labels.NewRequirement(constants.AgentLabelCryostatName, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	namespaceReq, err := labels.NewRequirement(constants.AgentLabelCryostatNamespace, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*nameReq, *namespaceReq), nil
+}
+
 func isOpenShift(client discovery.DiscoveryInterface, forceOpenShift bool) (bool, error) {
 	if forceOpenShift {
 		return true, nil
@@ -384,7 +458,7 @@ func isCertManagerInstalled(client discovery.DiscoveryInterface) (bool, error) {
 }
 
 func newReconcilerConfig(mgr ctrl.Manager, logName string, eventRecorderName string, openShift bool,
-	certManager bool, insightsURL *url.URL) *controller.ReconcilerConfig {
+	certManager bool, insightsURL *url.URL, agentPodCache cache.Cache) *controller.ReconcilerConfig {
 	return &controller.ReconcilerConfig{
 		Client:                 mgr.GetClient(),
 		Log:                    ctrl.Log.WithName("controller").WithName(logName),
@@ -395,6 +469,7 @@ func newReconcilerConfig(mgr ctrl.Manager, logName string, eventRecorderName str
 		RESTMapper:             mgr.GetRESTMapper(),
 		InsightsProxy:          insightsURL,
 		NewControllerBuilder:   common.NewControllerBuilder,
+		AgentPodCache:          agentPodCache,
 		ReconcilerTLS: common.NewReconcilerTLS(&common.ReconcilerTLSConfig{
 			Client: mgr.GetClient(),
 		}),
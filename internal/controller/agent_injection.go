@@ -0,0 +1,242 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/agentconfig"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Maximum number of failures reported for each namespace in status.agentInjection
+const maxRecentAgentInjectionFailures = 5
+
+// Reasons reported for pods that were not injected with the agent,
+// in addition to those determined by validating the pod's agent labels
+const (
+	reasonAgentNamespaceNotTargeted = "NamespaceNotTargeted"
+	reasonAgentNotInjected          = "NotInjected"
+)
+
+// AgentInjectionReconciler keeps the agent injection status of each Cryostat up to date.
+// This is separate from the CryostatReconciler, so that pods being created and deleted
+// do not cause the whole Cryostat installation to be reconciled.
+type AgentInjectionReconciler struct {
+	*ReconcilerConfig
+}
+
+func NewAgentInjectionReconciler(config *ReconcilerConfig) *AgentInjectionReconciler {
+	return &AgentInjectionReconciler{
+		ReconcilerConfig: config,
+	}
+}
+
+// Reconcile updates status.agentInjection of a Cryostat from the pods labelled to use it
+func (r *AgentInjectionReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	cr := &operatorv1beta2.Cryostat{}
+	err := r.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if !cr.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	instance := model.FromCryostat(cr)
+	original := cr.DeepCopy()
+	err = r.updateAgentInjectionStatus(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if equality.Semantic.DeepEqual(original.Status.AgentInjection, cr.Status.AgentInjection) {
+		return reconcile.Result{}, nil
+	}
+	// Only patch the agent injection status, which the CryostatReconciler leaves as is
+	return reconcile.Result{}, r.Status().Patch(ctx, cr, client.MergeFrom(original))
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentInjectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := r.NewControllerBuilder(mgr).Named("agentinjection").For(&operatorv1beta2.Cryostat{})
+
+	// Watch pods labelled to use a Cryostat, using the cache dedicated to them
+	pred, err := agentPodPredicate()
+	if err != nil {
+		return err
+	}
+	c = c.WatchesRawSource(source.Kind(r.AgentPodCache, client.Object(&corev1.Pod{}),
+		c.EnqueueRequestsFromMapFunc(mapFromAgentPod), pred))
+	return c.Complete(r)
+}
+
+// updateAgentInjectionStatus summarizes which pods labelled to use this Cryostat were
+// injected with the agent, for each namespace
+func (r *AgentInjectionReconciler) updateAgentInjectionStatus(ctx context.Context, cr *model.CryostatInstance) error {
+	pods := &corev1.PodList{}
+	err := r.agentPodReader().List(ctx, pods, client.MatchingLabels{
+		constants.AgentLabelCryostatName:      cr.Name,
+		constants.AgentLabelCryostatNamespace: cr.InstallNamespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Report every target namespace, even if no pods use the agent there yet. The status includes
+	// the namespaces matching the target namespace selector, which the webhook also injects.
+	statuses := map[string]*operatorv1beta2.AgentInjectionStatus{}
+	for _, namespace := range *cr.TargetNamespaceStatus {
+		statuses[namespace] = &operatorv1beta2.AgentInjectionStatus{Namespace: namespace}
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		status, ok := statuses[pod.Namespace]
+		if !ok {
+			status = &operatorv1beta2.AgentInjectionStatus{Namespace: pod.Namespace}
+			statuses[pod.Namespace] = status
+		}
+		if isAgentInjected(pod) {
+			status.InjectedPods++
+			continue
+		}
+		status.NotInjectedPods++
+		status.RecentFailures = append(status.RecentFailures, newAgentInjectionFailure(cr, pod))
+	}
+
+	result := make([]operatorv1beta2.AgentInjectionStatus, 0, len(statuses))
+	for _, status := range statuses {
+		// Keep the most recently created pods
+		slices.SortFunc(status.RecentFailures, func(a, b operatorv1beta2.AgentInjectionFailure) int {
+			if cmp := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); cmp != 0 {
+				return cmp
+			}
+			return strings.Compare(a.PodName, b.PodName)
+		})
+		if len(status.RecentFailures) > maxRecentAgentInjectionFailures {
+			status.RecentFailures = status.RecentFailures[:maxRecentAgentInjectionFailures]
+		}
+		result = append(result, *status)
+	}
+	slices.SortFunc(result, func(a, b operatorv1beta2.AgentInjectionStatus) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	cr.Status.AgentInjection = result
+
+	injected := map[string]int{}
+	for _, status := range result {
+		injected[status.Namespace] = int(status.InjectedPods)
+	}
+	metrics.SetAgentInjectedPods(cr.InstallNamespace, cr.Name, injected)
+	return nil
+}
+
+// agentPodReader returns a reader for the pods labelled to use a Cryostat
+func (c *ReconcilerConfig) agentPodReader() client.Reader {
+	if c.AgentPodCache == nil {
+		return c.Client
+	}
+	return c.AgentPodCache
+}
+
+func isAgentInjected(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Spec.InitContainers, func(container corev1.Container) bool {
+		return container.Name == constants.AgentInitContainerName
+	})
+}
+
+func newAgentInjectionFailure(cr *model.CryostatInstance, pod *corev1.Pod) operatorv1beta2.AgentInjectionFailure {
+	failure := operatorv1beta2.AgentInjectionFailure{
+		PodName:           pod.Name,
+		CreationTimestamp: pod.CreationTimestamp,
+	}
+	if !slices.Contains(*cr.TargetNamespaceStatus, pod.Namespace) {
+		failure.Reason = reasonAgentNamespaceNotTargeted
+		failure.Message = fmt.Sprintf("Namespace \"%s\" is not a target namespace of this Cryostat.", pod.Namespace)
+	} else if reason, err := agentconfig.Validate(pod); err != nil {
+		failure.Reason = reason
+		failure.Message = err.Error()
+	} else {
+		failure.Reason = reasonAgentNotInjected
+		failure.Message = "The pod was not injected with the Cryostat agent. Check the Events of the pod's workload for details."
+	}
+	return failure
+}
+
+// agentPodPredicate accepts pods labelled to use a Cryostat when they are created or deleted.
+// Whether a pod is injected with the agent is decided when it is created, so updates are ignored.
+func agentPodPredicate() (predicate.Predicate, error) {
+	selector := metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      constants.AgentLabelCryostatName,
+				Operator: metav1.LabelSelectorOpExists,
+			},
+			{
+				Key:      constants.AgentLabelCryostatNamespace,
+				Operator: metav1.LabelSelectorOpExists,
+			},
+		},
+	}
+	labelPred, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return nil, err
+	}
+	eventPred := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+	return predicate.And(labelPred, eventPred), nil
+}
+
+// mapFromAgentPod enqueues the Cryostat that the pod's labels refer to
+func mapFromAgentPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, ok := labels[constants.AgentLabelCryostatName]
+	if !ok {
+		return nil
+	}
+	namespace, ok := labels[constants.AgentLabelCryostatNamespace]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
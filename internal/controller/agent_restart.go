@@ -0,0 +1,171 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/cryostatio/cryostat-operator/internal/controller/common/pki"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+)
+
+const (
+	eventAgentWorkloadRestartedType = "AgentCertificateRotated"
+	eventAgentWorkloadRestartedMsg  = "Restarting to load the reissued Cryostat agent certificate"
+)
+
+// restartAgentWorkloads restarts the workloads of pods injected with the agent that were
+// created before the agent certificate for their namespace was issued. Agents only load
+// their certificate on startup, so they would otherwise keep using the previous one.
+func (r *Reconciler) restartAgentWorkloads(ctx context.Context, cr *model.CryostatInstance) error {
+	if cr.Spec.AgentOptions == nil || !cr.Spec.AgentOptions.RestartOnCertificateRotation || !r.IsTLSEnabled(cr) {
+		return nil
+	}
+	for _, ns := range cr.TargetNamespaces {
+		err := r.restartAgentWorkloadsInNamespace(ctx, cr, ns)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) restartAgentWorkloadsInNamespace(ctx context.Context, cr *model.CryostatInstance, namespace string) error {
+	agentCert := resources.NewAgentCert(cr, namespace, r.gvk)
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: agentCert.Spec.SecretName, Namespace: namespace}, secret)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			// The certificate has not been copied to this namespace yet
+			return nil
+		}
+		return err
+	}
+	cert, err := pki.ParseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return fmt.Errorf("failed to parse certificate in secret %s/%s: %w", namespace, secret.Name, err)
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(cert.Raw))
+
+	pods := &corev1.PodList{}
+	err = r.agentPodReader().List(ctx, pods, client.InNamespace(namespace), client.MatchingLabels{
+		constants.AgentLabelCryostatName:      cr.Name,
+		constants.AgentLabelCryostatNamespace: cr.InstallNamespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	workloads := map[string]client.Object{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || !isAgentInjected(pod) || !pod.CreationTimestamp.Time.Before(cert.NotBefore) {
+			continue
+		}
+		workload := getAgentWorkload(pod)
+		if workload == nil {
+			r.Log.Info("Pod injected with the agent must be restarted manually to load the reissued agent certificate",
+				"name", pod.Name, "namespace", pod.Namespace)
+			continue
+		}
+		kind := workload.GetObjectKind().GroupVersionKind().Kind
+		workloads[kind+"/"+workload.GetName()] = workload
+	}
+
+	for _, workload := range workloads {
+		err := r.restartAgentWorkload(ctx, workload, hash)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getAgentWorkload returns the Deployment, StatefulSet or DaemonSet that controls the pod,
+// if any. The returned object only contains its name and namespace.
+func getAgentWorkload(pod *corev1.Pod) client.Object {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil
+	}
+	meta := metav1.ObjectMeta{Name: owner.Name, Namespace: pod.Namespace}
+	switch owner.Kind {
+	case "ReplicaSet":
+		// Deployments name their ReplicaSets using the pod template hash,
+		// which avoids caching every ReplicaSet in the cluster
+		templateHash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if !ok || !strings.HasSuffix(owner.Name, "-"+templateHash) {
+			return nil
+		}
+		meta.Name = strings.TrimSuffix(owner.Name, "-"+templateHash)
+		return &appsv1.Deployment{TypeMeta: metav1.TypeMeta{Kind: "Deployment"}, ObjectMeta: meta}
+	case "StatefulSet":
+		return &appsv1.StatefulSet{TypeMeta: metav1.TypeMeta{Kind: "StatefulSet"}, ObjectMeta: meta}
+	case "DaemonSet":
+		return &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{Kind: "DaemonSet"}, ObjectMeta: meta}
+	default:
+		return nil
+	}
+}
+
+// restartAgentWorkload annotates the workload's pod template with the agent certificate hash,
+// unless a restart was already triggered for this certificate
+func (r *Reconciler) restartAgentWorkload(ctx context.Context, workload client.Object, hash string) error {
+	err := r.Get(ctx, client.ObjectKeyFromObject(workload), workload)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	var template *corev1.PodTemplateSpec
+	switch obj := workload.(type) {
+	case *appsv1.Deployment:
+		template = &obj.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &obj.Spec.Template
+	case *appsv1.DaemonSet:
+		template = &obj.Spec.Template
+	}
+	if template.Annotations[constants.AgentCertificateHashAnnotation] == hash {
+		return nil
+	}
+
+	patch := client.MergeFrom(workload.DeepCopyObject().(client.Object))
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[constants.AgentCertificateHashAnnotation] = hash
+	err = r.Patch(ctx, workload, patch)
+	if err != nil {
+		return err
+	}
+	r.Log.Info("Restarting workload to load the reissued agent certificate", "name", workload.GetName(),
+		"namespace", workload.GetNamespace())
+	r.EventRecorder.Event(workload, corev1.EventTypeNormal, eventAgentWorkloadRestartedType, eventAgentWorkloadRestartedMsg)
+	return nil
+}
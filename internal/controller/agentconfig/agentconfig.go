@@ -0,0 +1,446 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agentconfig
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reasons that the agent configuration of a pod is invalid
+const (
+	ReasonContainerNotFound    = "ContainerNotFound"
+	ReasonInvalidConfiguration = "InvalidConfiguration"
+	ReasonCallbackPortConflict = "CallbackPortConflict"
+)
+
+const (
+	defaultLogLevel             = "off"
+	defaultJavaOptsVar          = "JAVA_TOOL_OPTIONS"
+	defaultHarvesterExitMaxAge  = int32(30000)
+	kib                         = int32(1024)
+	mib                         = 1024 * kib
+	defaultHarvesterExitMaxSize = 20 * mib
+)
+
+var systemPropertyKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Error is an invalid agent configuration, along with the reason the agent cannot be injected
+type Error struct {
+	Reason string
+	Err    error
+}
+
+func newError(reason string, err error) *Error {
+	return &Error{
+		Reason: reason,
+		Err:    err,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ParseCallbackPorts parses the list of agent callback ports, or returns the default port if none are given
+func ParseCallbackPorts(config map[string]string) ([]int32, error) {
+	value, pres := config[constants.AgentLabelCallbackPort]
+	if !pres {
+		return []int32{constants.AgentCallbackContainerPort}, nil
+	}
+	result := []int32{}
+	for _, port := range strings.Split(value, ",") {
+		// Parse each port into an int32 and return an error if invalid
+		parsed, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelCallbackPort, err.Error())
+		}
+		result = append(result, int32(parsed))
+	}
+	return result, nil
+}
+
+// getCallbackPorts returns a callback port for each of count containers. Either one port
+// is given for each container, or a single port is given and each following container uses the next port.
+func getCallbackPorts(config map[string]string, count int) ([]int32, error) {
+	ports, err := ParseCallbackPorts(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(ports) == count {
+		return ports, nil
+	}
+	if len(ports) != 1 {
+		return nil, fmt.Errorf("invalid value for \"%s\": expected 1 or %d ports, but found %d",
+			constants.AgentLabelCallbackPort, count, len(ports))
+	}
+	for i := 1; i < count; i++ {
+		ports = append(ports, ports[0]+int32(i))
+	}
+	return ports, nil
+}
+
+// checkCallbackPorts ensures that the callback ports are distinct from each other,
+// and from the ports already declared by containers in the pod. This is only checked when
+// injecting multiple containers, whose callback ports may be assigned automatically.
+func checkCallbackPorts(pod *corev1.Pod, ports []int32) error {
+	used := map[int32]string{}
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			used[port.ContainerPort] = fmt.Sprintf("container \"%s\"", container.Name)
+		}
+	}
+	for _, port := range ports {
+		if owner, pres := used[port]; pres {
+			return fmt.Errorf("agent callback port %d is already used by %s", port, owner)
+		}
+		used[port] = "another agent"
+	}
+	return nil
+}
+
+// HasWriteAccess returns whether the agent should allow Cryostat to modify the target, such as by starting recordings
+func HasWriteAccess(config map[string]string) (*bool, error) {
+	// Default to true
+	result := true
+	value, pres := config[constants.AgentLabelReadOnly]
+	if pres {
+		// Parse the label value into a bool and return an error if invalid
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelReadOnly, err.Error())
+		}
+		result = !parsed
+	}
+	return &result, nil
+}
+
+// LogLevel returns the log level of the agent
+func LogLevel(config map[string]string) string {
+	result := defaultLogLevel
+	value, pres := config[constants.AgentLabelLogLevel]
+	if pres {
+		result = value
+	}
+	return result
+}
+
+// JavaOptionsVar returns the name of the environment variable the JVM reads additional options from
+func JavaOptionsVar(config map[string]string) string {
+	result := defaultJavaOptsVar
+	value, pres := config[constants.AgentLabelJavaOptionsVar]
+	if pres {
+		result = value
+	}
+	return result
+}
+
+// HarvesterTemplate returns the event template the agent's harvester records with. The agent
+// only supports a single harvester recording, so a list of templates is rejected rather than
+// having all but one silently ignored.
+func HarvesterTemplate(config map[string]string) (string, error) {
+	value := strings.TrimSpace(config[constants.AgentLabelHarvesterTemplate])
+	if strings.Contains(value, ",") {
+		return "", fmt.Errorf("invalid value for \"%s\": the agent only supports a single harvester template",
+			constants.AgentLabelHarvesterTemplate)
+	}
+	return value, nil
+}
+
+// SmartTriggersConfigMapNames returns the names of the ConfigMaps containing smart trigger definitions
+func SmartTriggersConfigMapNames(config map[string]string) []string {
+	result := []string{}
+	value, pres := config[constants.AgentLabelSmartTriggersConfigMaps]
+	if pres {
+		for _, name := range strings.Split(value, ",") {
+			// Annotations may contain whitespace around each name
+			name = strings.TrimSpace(name)
+			if len(name) > 0 {
+				result = append(result, name)
+			}
+		}
+	}
+	return result
+}
+
+// Get returns the agent auto-configuration for an object, read from its labels and
+// annotations with the "cryostat.io/" prefix. Annotations take precedence over labels.
+func Get(meta *metav1.ObjectMeta) map[string]string {
+	result := map[string]string{}
+	for key, value := range meta.Labels {
+		if strings.HasPrefix(key, constants.AgentLabelPrefix) {
+			result[key] = value
+		}
+	}
+	for key, value := range meta.Annotations {
+		// The Cryostat instance is only selected by labels, since the webhooks filter pods using them
+		if key == constants.AgentLabelCryostatName || key == constants.AgentLabelCryostatNamespace {
+			continue
+		}
+		if strings.HasPrefix(key, constants.AgentLabelPrefix) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// IsInjectionDisabled returns whether the pod has opted out of agent injection
+func IsInjectionDisabled(pod *corev1.Pod) bool {
+	value, pres := Get(&pod.ObjectMeta)[constants.AgentLabelInject]
+	return pres && strings.EqualFold(value, "false")
+}
+
+// ParseInjectSelection splits a namespace's agent injection label into the namespace
+// and name of a Cryostat. Since label values may not contain '/', these are separated by
+// the first '.', which cannot appear in a namespace name.
+func ParseInjectSelection(value string) (namespace string, name string, err error) {
+	namespace, name, found := strings.Cut(value, ".")
+	if !found || len(namespace) == 0 || len(name) == 0 {
+		return "", "", fmt.Errorf("invalid value for %s label \"%s\", expected \"<namespace>.<name>\"",
+			constants.AgentLabelInject, value)
+	}
+	return namespace, name, nil
+}
+
+// SystemProperties parses additional Java system properties for the agent, given one
+// per line as "key=value". Blank lines and lines starting with '#' are ignored.
+func SystemProperties(config map[string]string) ([]string, error) {
+	value, pres := config[constants.AgentAnnotationSystemProperties]
+	if !pres {
+		return nil, nil
+	}
+
+	result := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		val = strings.TrimSpace(val)
+		if !found || !systemPropertyKeyRegexp.MatchString(key) {
+			return nil, fmt.Errorf("invalid value for \"%s\": \"%s\" is not a valid property",
+				constants.AgentAnnotationSystemProperties, line)
+		}
+		// The Java options variable is split on whitespace by the JVM
+		if strings.ContainsAny(val, " \t") {
+			return nil, fmt.Errorf("invalid value for \"%s\": value of property \"%s\" must not contain whitespace",
+				constants.AgentAnnotationSystemProperties, key)
+		}
+		result = append(result, fmt.Sprintf("-D%s=%s", key, val))
+	}
+	return result, nil
+}
+
+// HarvesterExitMaxAge returns the maximum age in milliseconds of the data the harvester uploads when the JVM exits
+func HarvesterExitMaxAge(config map[string]string) (*int32, error) {
+	value := defaultHarvesterExitMaxAge
+	age, pres := config[constants.AgentLabelHarvesterExitMaxAge]
+	if pres {
+		// Parse the label value into an int32 and return an error if invalid
+		parsed, err := time.ParseDuration(age)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelHarvesterExitMaxAge, err.Error())
+		}
+		value = int32(parsed.Milliseconds())
+	}
+	return &value, nil
+}
+
+// HarvesterExitMaxSize returns the maximum size in bytes of the data the harvester uploads when the JVM exits
+func HarvesterExitMaxSize(config map[string]string) (*int32, error) {
+	value := defaultHarvesterExitMaxSize
+	size, pres := config[constants.AgentLabelHarvesterExitMaxSize]
+	if pres {
+		parsed, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelHarvesterExitMaxSize, err.Error())
+		}
+		value = int32(parsed.Value())
+	}
+	return &value, nil
+}
+
+func getHarvesterPeriod(config map[string]string) (*int32, error) {
+	period, pres := config[constants.AgentLabelHarvesterPeriod]
+	if !pres {
+		return nil, nil
+	}
+
+	parsed, err := time.ParseDuration(period)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelHarvesterPeriod, err.Error())
+	}
+	value := int32(parsed.Milliseconds())
+	return &value, nil
+}
+
+func getHarvesterMaxFiles(config map[string]string) (*int32, error) {
+	maxFiles, pres := config[constants.AgentLabelHarvesterMaxFiles]
+	if !pres {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseInt(maxFiles, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for \"%s\": %s", constants.AgentLabelHarvesterMaxFiles, err.Error())
+	}
+	if parsed <= 0 {
+		return nil, fmt.Errorf("invalid value for \"%s\": must be positive", constants.AgentLabelHarvesterMaxFiles)
+	}
+	value := int32(parsed)
+	return &value, nil
+}
+
+func getTargetContainers(pod *corev1.Pod, config map[string]string) ([]*corev1.Container, error) {
+	if len(pod.Spec.Containers) == 0 {
+		// Should never happen, Kubernetes doesn't allow this
+		return nil, errors.New("pod has no containers")
+	}
+	value, pres := config[constants.AgentLabelContainer]
+	if !pres {
+		// Use the first container by default
+		return []*corev1.Container{&pod.Spec.Containers[0]}, nil
+	}
+	// Find the containers matching the comma-separated label or annotation
+	result := []*corev1.Container{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		container, err := findNamedContainer(pod.Spec.Containers, name)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(result, container) {
+			return nil, fmt.Errorf("container \"%s\" is listed more than once", name)
+		}
+		result = append(result, container)
+	}
+	return result, nil
+}
+
+func findNamedContainer(containers []corev1.Container, name string) (*corev1.Container, error) {
+	for i, container := range containers {
+		if container.Name == name {
+			return &containers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no container found with name \"%s\"", name)
+}
+
+// Options contains the agent configuration parsed from a pod's labels and annotations
+type Options struct {
+	Containers           []*corev1.Container
+	Ports                []int32
+	Write                bool
+	HarvesterTemplate    string
+	HarvesterPeriod      *int32
+	HarvesterMaxFiles    *int32
+	HarvesterExitMaxAge  int32
+	HarvesterExitMaxSize int32
+	SystemProperties     []string
+}
+
+// Parse reads the agent configuration of a pod from config, which holds its agent labels and annotations.
+// If the configuration is invalid, the returned error gives the reason the agent cannot be injected.
+func Parse(pod *corev1.Pod, config map[string]string) (*Options, *Error) {
+	// Select target containers
+	containers, err := getTargetContainers(pod, config)
+	if err != nil {
+		return nil, newError(ReasonContainerNotFound, err)
+	}
+
+	// Determine the callback port numbers, one for each container
+	ports, err := getCallbackPorts(config, len(containers))
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	// Pods injected into a single container keep the previous behaviour of not checking
+	// the callback port against ports the pod already declares
+	if len(ports) > 1 {
+		err = checkCallbackPorts(pod, ports)
+		if err != nil {
+			return nil, newError(ReasonCallbackPortConflict, err)
+		}
+	}
+
+	// Check whether write access has been disabled
+	write, err := HasWriteAccess(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+
+	harvesterTemplate, err := HarvesterTemplate(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	harvesterPeriod, err := getHarvesterPeriod(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	harvesterMaxFiles, err := getHarvesterMaxFiles(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	harvesterExitMaxAge, err := HarvesterExitMaxAge(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	harvesterExitMaxSize, err := HarvesterExitMaxSize(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+	systemProperties, err := SystemProperties(config)
+	if err != nil {
+		return nil, newError(ReasonInvalidConfiguration, err)
+	}
+
+	return &Options{
+		Containers:           containers,
+		Ports:                ports,
+		Write:                *write,
+		HarvesterTemplate:    harvesterTemplate,
+		HarvesterPeriod:      harvesterPeriod,
+		HarvesterMaxFiles:    harvesterMaxFiles,
+		HarvesterExitMaxAge:  *harvesterExitMaxAge,
+		HarvesterExitMaxSize: *harvesterExitMaxSize,
+		SystemProperties:     systemProperties,
+	}, nil
+}
+
+// Validate checks the agent configuration in the labels and annotations of a pod
+// that has not been injected, as the webhook would. If the configuration is invalid, the reason
+// the webhook would fail the injection is returned with the error.
+func Validate(pod *corev1.Pod) (string, error) {
+	_, parseErr := Parse(pod, Get(&pod.ObjectMeta))
+	if parseErr != nil {
+		return parseErr.Reason, parseErr.Err
+	}
+	return "", nil
+}
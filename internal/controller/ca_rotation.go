@@ -0,0 +1,219 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+
+	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common/pki"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultCARotationGracePeriod = 24 * time.Hour
+
+// Annotation on the CA bundle secret recording when the current CA certificate replaced the previous one
+const annotationCARotatedAt = "operator.cryostat.io/ca-rotated-at"
+
+const (
+	reasonCACertificateCurrent = "CACertificateCurrent"
+	reasonReissuingCerts       = "ReissuingCertificates"
+	reasonPreviousCATrusted    = "PreviousCATrusted"
+)
+
+// caBundle contains the CA certificates trusted by Cryostat components and agents
+type caBundle struct {
+	// The current CA certificate, followed by any previous CA certificates that are still trusted
+	certificates []*x509.Certificate
+	// When the previous CA certificates stop being trusted, if there are any
+	previousTrustedUntil time.Time
+}
+
+// PEM returns the PEM encoding of the trusted CA certificates
+func (b *caBundle) PEM() []byte {
+	return pki.EncodeCertificates(b.certificates...)
+}
+
+func (b *caBundle) current() *x509.Certificate {
+	return b.certificates[0]
+}
+
+func (b *caBundle) rotating() bool {
+	return len(b.certificates) > 1
+}
+
+func newCABundleSecret(cr *model.CryostatInstance) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-ca-bundle",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+}
+
+// reconcileCABundle stores the bundle of trusted CA certificates in the install namespace.
+// When the CA certificate changes, the previous CA certificate stays in the bundle for the
+// grace period, so that certificates signed by either CA are trusted while they are reissued
+// and workloads restart. The bundle should be published before certificates are issued by the new CA.
+// The returned bundle records when the previous CA stops being trusted, when the caller should reconcile again.
+func (r *Reconciler) reconcileCABundle(ctx context.Context, cr *model.CryostatInstance, caBytes []byte) (*caBundle, error) {
+	current, err := pki.ParseCertificate(caBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	bundle := &caBundle{}
+	secret := newCABundleSecret(cr)
+	err = r.createOrUpdateSecret(ctx, secret, cr.Object, func() error {
+		trusted, err := pki.ParseCertificates(secret.Data[constants.CAKey])
+		if err != nil {
+			r.Log.Error(err, "Discarding invalid CA bundle", "name", secret.Name, "namespace", secret.Namespace)
+			trusted = nil
+		}
+		rotatedAt, _ := time.Parse(time.RFC3339, secret.Annotations[annotationCARotatedAt])
+		now := time.Now()
+		if len(trusted) > 0 && !trusted[0].Equal(current) {
+			r.Log.Info("CA certificate changed, trusting the previous CA certificate during the grace period",
+				"name", cr.Name, "namespace", cr.InstallNamespace)
+			rotatedAt = now
+		}
+
+		bundle.certificates = []*x509.Certificate{current}
+		trustedUntil := rotatedAt.Add(getCARotationGracePeriod(cr))
+		if now.Before(trustedUntil) {
+			for _, cert := range trusted {
+				if !cert.Equal(current) && now.Before(cert.NotAfter) {
+					bundle.certificates = append(bundle.certificates, cert)
+				}
+			}
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		if bundle.rotating() {
+			bundle.previousTrustedUntil = trustedUntil
+			secret.Annotations[annotationCARotatedAt] = rotatedAt.UTC().Format(time.RFC3339)
+		} else {
+			delete(secret.Annotations, annotationCARotatedAt)
+		}
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeOpaque
+		}
+		secret.Data = map[string][]byte{
+			constants.CAKey: bundle.PEM(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func getCARotationGracePeriod(cr *model.CryostatInstance) time.Duration {
+	if cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.CARotationGracePeriod != nil {
+		return cr.Spec.TLSOptions.CARotationGracePeriod.Duration
+	}
+	return defaultCARotationGracePeriod
+}
+
+// reissueCertificatesFromPreviousCA has cert-manager reissue the certificates that were signed by a
+// CA certificate other than the current one, which switches them to the current CA. Returns the names
+// of the certificates that are being reissued.
+func (r *Reconciler) reissueCertificatesFromPreviousCA(ctx context.Context, bundle *caBundle,
+	certs []*certv1.Certificate) ([]string, error) {
+	reissuing := []string{}
+	for _, cert := range certs {
+		if cert.Spec.IsCA {
+			continue
+		}
+		secret, err := r.GetCertificateSecret(ctx, cert)
+		if err != nil {
+			return nil, err
+		}
+		// Issuers don't always include the CA certificate, in which case we can't tell which CA signed it
+		caBytes := secret.Data[constants.CAKey]
+		if len(caBytes) == 0 {
+			continue
+		}
+		ca, err := pki.ParseCertificate(caBytes)
+		if err != nil || ca.Equal(bundle.current()) {
+			continue
+		}
+
+		err = r.renewCertificate(ctx, cert)
+		if err != nil {
+			return nil, err
+		}
+		reissuing = append(reissuing, cert.Name)
+	}
+	return reissuing, nil
+}
+
+// renewCertificate triggers cert-manager to reissue a certificate in the same way as "cmctl renew"
+func (r *Reconciler) renewCertificate(ctx context.Context, cert *certv1.Certificate) error {
+	current := &certv1.Certificate{}
+	err := r.Get(ctx, types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, current)
+	if err != nil {
+		return err
+	}
+	for _, condition := range current.Status.Conditions {
+		if condition.Type == certv1.CertificateConditionIssuing && condition.Status == certMeta.ConditionTrue {
+			// Already being reissued
+			return nil
+		}
+	}
+
+	now := metav1.Now()
+	current.Status.Conditions = append(current.Status.Conditions, certv1.CertificateCondition{
+		Type:               certv1.CertificateConditionIssuing,
+		Status:             certMeta.ConditionTrue,
+		Reason:             "ManuallyTriggered",
+		Message:            "Certificate reissuance was triggered by the Cryostat operator after the CA certificate changed",
+		LastTransitionTime: &now,
+	})
+	err = r.Status().Update(ctx, current)
+	if err != nil {
+		return err
+	}
+	r.Log.Info("Reissuing certificate signed by the previous CA certificate", "name", cert.Name, "namespace", cert.Namespace)
+	return nil
+}
+
+// setCARotationCondition reports the progress of a change of the CA certificate
+func setCARotationCondition(cr *model.CryostatInstance, bundle *caBundle, reissuing []string) {
+	if len(reissuing) > 0 {
+		setCondition(cr, operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionFalse, reasonReissuingCerts,
+			fmt.Sprintf("Reissuing certificates signed by the previous CA certificate: %s.", strings.Join(reissuing, ", ")))
+	} else if bundle.rotating() {
+		setCondition(cr, operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionFalse, reasonPreviousCATrusted,
+			fmt.Sprintf("Certificates are issued by the current CA certificate. The previous CA certificate is trusted until %s.",
+				bundle.previousTrustedUntil.UTC().Format(time.RFC3339)))
+	} else {
+		setCondition(cr, operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionTrue, reasonCACertificateCurrent,
+			"Only the current CA certificate is trusted.")
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultCertificateReadyTimeout = 10 * time.Minute
+
+// Bounds for the delay before checking certificates that are not ready again. Changes to
+// the certificates also trigger a reconcile, so this only limits how long we may miss one.
+const (
+	minCertNotReadyRequeue = 5 * time.Second
+	maxCertNotReadyRequeue = 5 * time.Minute
+)
+
+const eventCertificateNotReadyType = "CertificateNotReady"
+
+// pendingCertificate describes a certificate that cert-manager has not yet marked as ready
+type pendingCertificate struct {
+	name string
+	// How long the certificate's Ready condition has not been true
+	pendingFor time.Duration
+	// Reason and message of the certificate's Ready condition, if present
+	reason  string
+	message string
+}
+
+func (c *pendingCertificate) String() string {
+	if len(c.reason) == 0 {
+		return fmt.Sprintf("%s (not yet processed by cert-manager)", c.name)
+	}
+	return fmt.Sprintf("%s (pending for %s, %s: %s)", c.name, c.pendingFor.Round(time.Second), c.reason, c.message)
+}
+
+// certsNotReadyError is returned when cert-manager has not yet issued all certificates
+// for a CR. It wraps common.ErrCertNotReady.
+type certsNotReadyError struct {
+	// How long to wait before checking the certificates again
+	retryAfter time.Duration
+}
+
+func (e *certsNotReadyError) Error() string {
+	return common.ErrCertNotReady.Error()
+}
+
+func (e *certsNotReadyError) Unwrap() error {
+	return common.ErrCertNotReady
+}
+
+// getPendingCertificates returns the certificates owned by this CR that are not ready,
+// sorted by name
+func (r *Reconciler) getPendingCertificates(ctx context.Context, cr *model.CryostatInstance) ([]*pendingCertificate, error) {
+	certs := &certv1.CertificateList{}
+	err := r.List(ctx, certs, ctrlclient.InNamespace(cr.InstallNamespace))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pending := []*pendingCertificate{}
+	for i := range certs.Items {
+		cert := &certs.Items[i]
+		if !metav1.IsControlledBy(cert, cr.Object) {
+			continue
+		}
+		ready := getCertificateReadyCondition(cert)
+		if ready != nil && ready.Status == certMeta.ConditionTrue {
+			continue
+		}
+
+		// Without a Ready condition, cert-manager has not processed the certificate yet
+		result := &pendingCertificate{name: cert.Name}
+		if ready != nil {
+			result.reason = ready.Reason
+			result.message = ready.Message
+			if ready.LastTransitionTime != nil && now.After(ready.LastTransitionTime.Time) {
+				result.pendingFor = now.Sub(ready.LastTransitionTime.Time)
+			}
+		}
+		pending = append(pending, result)
+	}
+	slices.SortFunc(pending, func(a, b *pendingCertificate) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return pending, nil
+}
+
+func getCertificateReadyCondition(cert *certv1.Certificate) *certv1.CertificateCondition {
+	for i, condition := range cert.Status.Conditions {
+		if condition.Type == certv1.CertificateConditionReady {
+			return &cert.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// reportPendingCertificates updates the TLSSetupComplete condition to describe the certificates
+// that are not ready. When any of them exceeded the timeout, the condition uses a distinct reason,
+// and a Warning Event listing those certificates is emitted once when the condition changes to that
+// reason. It returns an error wrapping common.ErrCertNotReady, with a delay that grows the longer
+// certificates have been pending.
+func (r *Reconciler) reportPendingCertificates(ctx context.Context, cr *model.CryostatInstance) error {
+	pending, err := r.getPendingCertificates(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	reason := reasonWaitingForCert
+	message := "Waiting for certificates to become ready."
+	retryAfter := minCertNotReadyRequeue
+	if len(pending) > 0 {
+		descriptions := make([]string, 0, len(pending))
+		timedOut := []string{}
+		longest := time.Duration(0)
+		timeout := getCertificateReadyTimeout(cr)
+		for _, cert := range pending {
+			descriptions = append(descriptions, cert.String())
+			longest = max(longest, cert.pendingFor)
+			if cert.pendingFor >= timeout {
+				timedOut = append(timedOut, cert.String())
+			}
+		}
+		message = "Waiting for certificates to become ready: " + strings.Join(descriptions, ", ")
+		r.Log.Info("Certificates not yet ready", "name", cr.Name, "namespace", cr.InstallNamespace,
+			"not ready", strings.Join(descriptions, ", "))
+
+		if len(timedOut) > 0 {
+			reason = reasonCertificateTimeout
+			previous := meta.FindStatusCondition(cr.Status.Conditions, string(operatorv1beta2.ConditionTypeTLSSetupComplete))
+			if previous == nil || previous.Reason != reasonCertificateTimeout {
+				r.EventRecorder.Eventf(cr.Object, corev1.EventTypeWarning, eventCertificateNotReadyType,
+					"Certificates exceeded the timeout of %s while waiting to become ready: %s", timeout,
+					strings.Join(timedOut, ", "))
+			}
+		}
+
+		// Back off exponentially, by waiting about as long as the certificates have been pending so far
+		retryAfter = min(max(longest, minCertNotReadyRequeue), maxCertNotReadyRequeue)
+	}
+
+	err = r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
+		reason, message)
+	if err != nil {
+		return err
+	}
+	return &certsNotReadyError{retryAfter: retryAfter}
+}
+
+func getCertificateReadyTimeout(cr *model.CryostatInstance) time.Duration {
+	if cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.CertificateReadyTimeout != nil {
+		return cr.Spec.TLSOptions.CertificateReadyTimeout.Duration
+	}
+	return defaultCertificateReadyTimeout
+}
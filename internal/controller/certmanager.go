@@ -18,12 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strings"
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -53,23 +56,38 @@ func (r *Reconciler) setupTLS(ctx context.Context, cr *model.CryostatInstance) (
 		return nil, errCertManagerMissing
 	}
 
-	// Create self-signed issuer used to bootstrap CA
-	err = r.createOrUpdateIssuer(ctx, resources.NewSelfSignedIssuer(cr), cr.Object)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create CA certificate for Cryostat using the self-signed issuer
 	caCert := resources.NewCryostatCACert(r.gvk, cr)
-	err = r.createOrUpdateCertificate(ctx, caCert, cr.Object)
-	if err != nil {
-		return nil, err
-	}
+	customIssuer := resources.HasCustomIssuer(cr)
+	if customIssuer {
+		// Certificates are issued by the user's issuer, so remove any CA issuers we created previously
+		err = r.deleteCAIssuers(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Remove any CA certificate previously copied from a user-provided issuer
+		err = r.deleteIssuerCASecret(ctx, cr, caCert)
+		if err != nil {
+			return nil, err
+		}
 
-	// Create CA issuer using the CA cert just created
-	err = r.createOrUpdateIssuer(ctx, resources.NewCryostatCAIssuer(r.gvk, cr), cr.Object)
-	if err != nil {
-		return nil, err
+		// Create self-signed issuer used to bootstrap CA
+		err = r.createOrUpdateIssuer(ctx, resources.NewSelfSignedIssuer(cr), cr.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		// Create CA certificate for Cryostat using the self-signed issuer
+		err = r.createOrUpdateCertificate(ctx, caCert, cr.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		// Create CA issuer using the CA cert just created
+		err = r.createOrUpdateIssuer(ctx, resources.NewCryostatCAIssuer(r.gvk, cr), cr.Object)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Create secret to hold keystore password
@@ -93,9 +111,14 @@ func (r *Reconciler) setupTLS(ctx context.Context, cr *model.CryostatInstance) (
 		return nil, err
 	}
 
-	// Create a certificate for the Cryostat database signed by the Cryostat CA
+	// Create a certificate for the Cryostat database signed by the Cryostat CA, unless using an external database
 	databaseCert := resources.NewDatabaseCert(cr)
-	err = r.createOrUpdateCertificate(ctx, databaseCert, cr.Object)
+	if resources.DeployManagedDatabase(cr) {
+		err = r.createOrUpdateCertificate(ctx, databaseCert, cr.Object)
+	} else {
+		err = r.deleteCertWithSecret(ctx, databaseCert)
+		metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, databaseCert.Name)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -115,45 +138,88 @@ func (r *Reconciler) setupTLS(ctx context.Context, cr *model.CryostatInstance) (
 	}
 
 	// List of certificates whose secrets should be owned by this CR
-	certificates := []*certv1.Certificate{caCert, cryostatCert, reportsCert, databaseCert, storageCert, agentProxyCert}
+	certificates := []*certv1.Certificate{cryostatCert, reportsCert, storageCert, agentProxyCert}
+	if resources.DeployManagedDatabase(cr) {
+		certificates = append(certificates, databaseCert)
+	}
 
-	// Get the Cryostat CA certificate bytes from certificate secret
-	caBytes, err := r.getCertficateBytes(ctx, caCert)
+	// Create certificates for the Grafana and JFR datasource containers, if requested
+	sidecarCerts := resources.NewSidecarCerts(cr)
+	for _, cert := range sidecarCerts {
+		if common.IsSidecarTLSEnabled(cr) {
+			err = r.createOrUpdateCertificate(ctx, cert, cr.Object)
+		} else {
+			err = r.deleteCertWithSecret(ctx, cert)
+			metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, cert.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if common.IsSidecarTLSEnabled(cr) {
+		certificates = append(certificates, sidecarCerts...)
+	}
+
+	var caBytes []byte
+	if customIssuer {
+		// Get the CA certificate bytes of the user's issuer from the Cryostat certificate secret,
+		// and store them where the Cryostat CA certificate would otherwise be
+		caBytes, err = r.getIssuerCABytes(ctx, cryostatCert)
+		if err != nil {
+			return nil, err
+		}
+		err = r.reconcileIssuerCASecret(ctx, cr, caCert, caBytes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// Get the Cryostat CA certificate bytes from certificate secret
+		caBytes, err = r.getCertficateBytes(ctx, caCert)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append([]*certv1.Certificate{caCert}, certificates...)
+	}
+
+	// Publish the trusted CA certificates. Unlike with certificates issued by the operator, cert-manager
+	// issues certificates from a new CA as soon as it is available, so certificates may be issued by
+	// the new CA before this reconcile publishes it. Those certificates are trusted once it completes.
+	bundle, err := r.reconcileCABundle(ctx, cr, caBytes)
 	if err != nil {
 		return nil, err
 	}
+	caBundleSecret := newCABundleSecret(cr)
 
 	tlsConfig := &resources.TLSConfig{
 		CryostatSecret:     cryostatCert.Spec.SecretName,
-		DatabaseSecret:     databaseCert.Spec.SecretName,
 		StorageSecret:      storageCert.Spec.SecretName,
 		ReportsSecret:      reportsCert.Spec.SecretName,
 		AgentProxySecret:   agentProxyCert.Spec.SecretName,
 		KeystorePassSecret: cryostatCert.Spec.Keystores.PKCS12.PasswordSecretRef.Name,
-		CACert:             caBytes,
+		CABundleSecret:     caBundleSecret.Name,
+		CABundle:           bundle.PEM(),
+		// Reconcile again to stop trusting the previous CA once the grace period ends
+		RequeueAt: bundle.previousTrustedUntil,
+	}
+	if resources.DeployManagedDatabase(cr) {
+		tlsConfig.DatabaseSecret = databaseCert.Spec.SecretName
+	}
+	if common.IsSidecarTLSEnabled(cr) {
+		tlsConfig.GrafanaSecret = resources.NewGrafanaCert(cr).Spec.SecretName
+		tlsConfig.DatasourceSecret = resources.NewDatasourceCert(cr).Spec.SecretName
+	}
+
+	// Make the Cryostat CA certificate available in each target namespace
+	err = r.distributeCACertificate(ctx, cr, caBytes, tlsConfig.CABundle)
+	if err != nil {
+		return nil, err
 	}
 
 	agentCertsNotReady := []string{}
 	for _, ns := range cr.TargetNamespaces {
-		// Copy Cryostat CA secret in each target namespace
-		if ns != cr.InstallNamespace {
-			namespaceSecret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      caCert.Spec.SecretName,
-					Namespace: ns,
-				},
-				Type: corev1.SecretTypeOpaque,
-			}
-			err = r.createOrUpdateCertSecret(ctx, namespaceSecret, caBytes,
-				common.LabelsForTargetNamespaceObject(cr))
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		// Create a certificate for Cryostat agents in each target namespace
 		agentCert := resources.NewAgentCert(cr, ns, r.gvk)
-		err := r.reconcileAgentCertificate(ctx, agentCert, cr, ns)
+		err := r.reconcileAgentCertificate(ctx, agentCert, cr, ns, tlsConfig.CABundle)
 		if err != nil {
 			if err == common.ErrCertNotReady {
 				// Continue with other namespaces if the cert isn't ready
@@ -177,80 +243,189 @@ func (r *Reconciler) setupTLS(ctx context.Context, cr *model.CryostatInstance) (
 		return nil, err
 	}
 
+	// Now that the new CA is trusted everywhere, switch any certificates signed by the previous CA
+	reissuing, err := r.reissueCertificatesFromPreviousCA(ctx, bundle, certificates)
+	if err != nil {
+		return nil, err
+	}
+	setCARotationCondition(cr, bundle, reissuing)
+
+	// Report when each certificate expires
+	for _, cert := range certificates {
+		if cert.Status.NotAfter != nil {
+			metrics.SetCertificateExpiry(cr.InstallNamespace, cr.Name, cert.Name, cert.Status.NotAfter.Time)
+		}
+	}
+
 	// Clean up resources from target namespaces that are no longer requested
 	for _, ns := range toDelete(cr) {
-		// Delete any Cryostat CA secret copies in removed namespaces
+		// Delete any Cryostat CA and agent certificate secret copies in removed namespaces
 		if ns != cr.InstallNamespace {
-			namespaceSecret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      caCert.Spec.SecretName,
-					Namespace: ns,
-				},
-			}
-			err = r.deleteSecret(ctx, namespaceSecret)
+			err = r.deleteTargetNamespaceSecrets(ctx, cr, ns)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		// Delete any agent certificates removed target namespaces
+		// Delete any agent certificates removed target namespaces,
+		// along with the original secret
 		agentCert := resources.NewAgentCert(cr, ns, r.gvk)
-
-		// Delete namespace copy
-		if ns != cr.InstallNamespace {
-			namespaceAgentSecret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      agentCert.Spec.SecretName,
-					Namespace: ns,
-				},
-			}
-			err = r.deleteSecret(ctx, namespaceAgentSecret)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		// Delete certificate with original secret
 		err := r.deleteCertWithSecret(ctx, agentCert)
 		if err != nil {
 			return nil, err
 		}
+		metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, agentCert.Name)
 	}
 
 	return tlsConfig, nil
 }
 
+func (r *Reconciler) deleteCAIssuers(ctx context.Context, cr *model.CryostatInstance) error {
+	issuers := []*certv1.Issuer{resources.NewCryostatCAIssuer(r.gvk, cr), resources.NewSelfSignedIssuer(cr)}
+	for _, issuer := range issuers {
+		err := r.Delete(ctx, issuer)
+		if err != nil && !kerrors.IsNotFound(err) {
+			r.Log.Error(err, "Could not delete issuer", "name", issuer.Name, "namespace", issuer.Namespace)
+			return err
+		}
+		if err == nil {
+			r.Log.Info("deleted Issuer", "name", issuer.Name, "namespace", issuer.Namespace)
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) getIssuerCABytes(ctx context.Context, cert *certv1.Certificate) ([]byte, error) {
+	secret, err := r.GetCertificateSecret(ctx, cert)
+	if err != nil {
+		return nil, err
+	}
+	caBytes := secret.Data[constants.CAKey]
+	if len(caBytes) == 0 {
+		return nil, fmt.Errorf("issuer \"%s\" did not provide a CA certificate in the \"%s\" key of secret \"%s\"",
+			cert.Spec.IssuerRef.Name, constants.CAKey, secret.Name)
+	}
+	return caBytes, nil
+}
+
+// reconcileIssuerCASecret stores the CA certificate of a user-provided issuer in the install namespace,
+// replacing the Cryostat CA certificate and its private key, if one was previously created.
+func (r *Reconciler) reconcileIssuerCASecret(ctx context.Context, cr *model.CryostatInstance,
+	caCert *certv1.Certificate, caBytes []byte) error {
+	// Remove the Cryostat CA certificate along with its secret, so that cert-manager does not reissue it
+	err := r.Get(ctx, types.NamespacedName{Name: caCert.Name, Namespace: caCert.Namespace}, &certv1.Certificate{})
+	if err == nil {
+		err = r.deleteCertWithSecret(ctx, caCert)
+		if err != nil {
+			return err
+		}
+		metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, caCert.Name)
+	} else if !kerrors.IsNotFound(err) {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caCert.Spec.SecretName,
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	return r.createOrUpdateSecret(ctx, secret, cr.Object, func() error {
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeOpaque
+		}
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey: caBytes,
+		}
+		return nil
+	})
+}
+
+func (r *Reconciler) deleteIssuerCASecret(ctx context.Context, cr *model.CryostatInstance, caCert *certv1.Certificate) error {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: caCert.Spec.SecretName, Namespace: cr.InstallNamespace}, secret)
+	if err != nil {
+		return ctrlclient.IgnoreNotFound(err)
+	}
+	// Secrets created by cert-manager have the TLS type
+	if secret.Type != corev1.SecretTypeTLS && metav1.IsControlledBy(secret, cr.Object) {
+		return r.deleteSecret(ctx, secret)
+	}
+	return nil
+}
+
 func (r *Reconciler) finalizeTLS(ctx context.Context, cr *model.CryostatInstance) error {
-	caCert := resources.NewCryostatCACert(r.gvk, cr)
 	for _, ns := range cr.TargetNamespaces {
 		if ns != cr.InstallNamespace {
-			namespaceSecret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      caCert.Spec.SecretName,
-					Namespace: ns,
-				},
-			}
-			err := r.deleteSecret(ctx, namespaceSecret)
-			if err != nil {
-				return err
-			}
-
-			// Delete any agent certificate secrets in target namespaces
-			agentCert := resources.NewAgentCert(cr, ns, r.gvk)
-			namespaceAgentSecret := &corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      agentCert.Spec.SecretName,
-					Namespace: ns,
-				},
-			}
-			err = r.deleteSecret(ctx, namespaceAgentSecret)
+			err := r.deleteTargetNamespaceSecrets(ctx, cr, ns)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
-	return nil
+	// The Bundle is cluster-scoped, so it is not garbage collected with the CR
+	return r.deleteTrustManagerBundle(ctx, cr)
+}
+
+// copyCASecret stores a copy of the Cryostat CA certificate in a target namespace,
+// along with the bundle of trusted CA certificates
+func (r *Reconciler) copyCASecret(ctx context.Context, cr *model.CryostatInstance, caCert *certv1.Certificate,
+	namespace string, caBytes []byte, caBundle []byte) error {
+	namespaceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caCert.Spec.SecretName,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	return r.createOrUpdateCertSecret(ctx, namespaceSecret, caBytes, caBundle,
+		common.LabelsForTargetNamespaceObject(cr))
+}
+
+// copyAgentCertSecret stores a copy of an agent certificate secret in its target namespace,
+// where agents trust the bundle of trusted CA certificates in place of the issuing CA
+func (r *Reconciler) copyAgentCertSecret(ctx context.Context, cr *model.CryostatInstance, secret *corev1.Secret,
+	namespace string, caBundle []byte) error {
+	targetSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name,
+			Namespace: namespace,
+		},
+	}
+	return r.createOrUpdateSecret(ctx, targetSecret, nil, func() error {
+		common.MergeLabelsAndAnnotations(&targetSecret.ObjectMeta,
+			common.LabelsForTargetNamespaceObject(cr), map[string]string{})
+		targetSecret.Data = map[string][]byte{}
+		maps.Copy(targetSecret.Data, secret.Data)
+		targetSecret.Data[constants.CAKey] = caBundle
+		return nil
+	})
+}
+
+// deleteTargetNamespaceSecrets deletes the copies of the Cryostat CA and agent
+// certificate secrets from a target namespace
+func (r *Reconciler) deleteTargetNamespaceSecrets(ctx context.Context, cr *model.CryostatInstance, namespace string) error {
+	caCert := resources.NewCryostatCACert(r.gvk, cr)
+	namespaceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      caCert.Spec.SecretName,
+			Namespace: namespace,
+		},
+	}
+	err := r.deleteSecret(ctx, namespaceSecret)
+	if err != nil {
+		return err
+	}
+
+	agentCert := resources.NewAgentCert(cr, namespace, r.gvk)
+	namespaceAgentSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      agentCert.Spec.SecretName,
+			Namespace: namespace,
+		},
+	}
+	return r.deleteSecret(ctx, namespaceAgentSecret)
 }
 
 func (r *Reconciler) setCertSecretOwner(ctx context.Context, cr *model.CryostatInstance, certs ...*certv1.Certificate) error {
@@ -406,7 +581,8 @@ func (r *Reconciler) deleteCertWithSecret(ctx context.Context, cert *certv1.Cert
 	return nil
 }
 
-func (r *Reconciler) reconcileAgentCertificate(ctx context.Context, cert *certv1.Certificate, cr *model.CryostatInstance, namespace string) error {
+func (r *Reconciler) reconcileAgentCertificate(ctx context.Context, cert *certv1.Certificate, cr *model.CryostatInstance,
+	namespace string, caBundle []byte) error {
 	// Create the Agent certificate in the install namespace
 	err := r.createOrUpdateCertificate(ctx, cert, cr.Object)
 	if err != nil {
@@ -419,19 +595,7 @@ func (r *Reconciler) reconcileAgentCertificate(ctx context.Context, cert *certv1
 		if err != nil {
 			return err
 		}
-
-		targetSecret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      secret.Name,
-				Namespace: namespace,
-			},
-		}
-		err = r.createOrUpdateSecret(ctx, targetSecret, nil, func() error {
-			common.MergeLabelsAndAnnotations(&targetSecret.ObjectMeta,
-				common.LabelsForTargetNamespaceObject(cr), map[string]string{})
-			targetSecret.Data = secret.Data
-			return nil
-		})
+		err = r.copyAgentCertSecret(ctx, cr, secret, namespace, caBundle)
 		if err != nil {
 			return err
 		}
@@ -547,7 +711,7 @@ func (r *Reconciler) createOrUpdateKeystoreSecret(ctx context.Context, secret *c
 	return nil
 }
 
-func (r *Reconciler) createOrUpdateCertSecret(ctx context.Context, secret *corev1.Secret, cert []byte,
+func (r *Reconciler) createOrUpdateCertSecret(ctx context.Context, secret *corev1.Secret, cert []byte, caBundle []byte,
 	labels map[string]string) error {
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
 		common.MergeLabelsAndAnnotations(&secret.ObjectMeta, labels, map[string]string{})
@@ -555,6 +719,7 @@ func (r *Reconciler) createOrUpdateCertSecret(ctx context.Context, secret *corev
 			secret.Data = map[string][]byte{}
 		}
 		secret.Data[corev1.TLSCertKey] = cert
+		secret.Data[constants.CAKey] = caBundle
 		return nil
 	})
 	if err != nil {
@@ -21,14 +21,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // ControllerBuilder wraps controller-runtime's builder.Builder
 // as an interface to aid testing.
 type ControllerBuilder interface {
 	For(object client.Object, opts ...builder.ForOption) ControllerBuilder
+	Named(name string) ControllerBuilder
 	Owns(object client.Object, opts ...builder.OwnsOption) ControllerBuilder
 	Watches(object client.Object, eventHandler handler.EventHandler, opts ...builder.WatchesOption) ControllerBuilder
+	WatchesRawSource(src source.Source) ControllerBuilder
 	Complete(r reconcile.Reconciler) error
 	EnqueueRequestsFromMapFunc(fn handler.MapFunc) handler.EventHandler
 	WithPredicates(predicates ...predicate.Predicate) builder.Predicates
@@ -53,6 +56,12 @@ func (b *ctrlBuilder) For(object client.Object, opts ...builder.ForOption) Contr
 	return b
 }
 
+// Named wraps the [builder.Builder.Named] method
+func (b *ctrlBuilder) Named(name string) ControllerBuilder {
+	b.impl = b.impl.Named(name)
+	return b
+}
+
 // Owns wraps the [builder.Builder.Owns] method
 func (b *ctrlBuilder) Owns(object client.Object, opts ...builder.OwnsOption) ControllerBuilder {
 	b.impl = b.impl.Owns(object, opts...)
@@ -65,6 +74,12 @@ func (b *ctrlBuilder) Watches(object client.Object, eventHandler handler.EventHa
 	return b
 }
 
+// WatchesRawSource wraps the [builder.Builder.WatchesRawSource] method
+func (b *ctrlBuilder) WatchesRawSource(src source.Source) ControllerBuilder {
+	b.impl = b.impl.WatchesRawSource(src)
+	return b
+}
+
 // Complete wraps the [builder.Builder.Complete] method
 func (b *ctrlBuilder) Complete(r reconcile.Reconciler) error {
 	return b.impl.Complete(r)
@@ -15,6 +15,7 @@
 package common
 
 import (
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -30,3 +31,15 @@ func AgentGatewayServiceName(cr *model.CryostatInstance) string {
 func AgentCertificateName(gvk *schema.GroupVersionKind, cr *model.CryostatInstance, targetNamespace string) string {
 	return ClusterUniqueNameWithPrefixTargetNS(gvk, "agent", cr.Name, cr.InstallNamespace, targetNamespace)
 }
+
+// CABundleName returns the name of the trust-manager Bundle for the CR, which is
+// also the name of the ConfigMap it synchronizes into each target namespace
+func CABundleName(gvk *schema.GroupVersionKind, cr *model.CryostatInstance) string {
+	return ClusterUniqueNameWithPrefix(gvk, "ca", cr.Name, cr.InstallNamespace)
+}
+
+// CABundleNamespaceLabel returns the key of the label selecting the target namespaces of the CR
+// for its trust-manager Bundle
+func CABundleNamespaceLabel(gvk *schema.GroupVersionKind, cr *model.CryostatInstance) string {
+	return constants.CABundleNamespaceLabelPrefix + ClusterUniqueShortNameWithPrefix(gvk, "ca", cr.Name, cr.InstallNamespace)
+}
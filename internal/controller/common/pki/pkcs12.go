@@ -0,0 +1,29 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"crypto/x509"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// EncodePKCS12 encodes this key pair as a password-protected PKCS#12 keystore, using the
+// same modern profile as cert-manager. Certificates and the private key are encrypted using
+// PBES2 with PBKDF2-HMAC-SHA256 and AES-256-CBC, and the keystore is protected by an HMAC-SHA256 MAC.
+// Any CA certificates are included in the keystore to complete the certificate chain.
+func (kp *KeyPair) EncodePKCS12(password string, caCerts ...*x509.Certificate) ([]byte, error) {
+	return pkcs12.Modern2023.Encode(kp.PrivateKey, kp.Certificate, caCerts, password)
+}
@@ -0,0 +1,327 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"slices"
+	"time"
+)
+
+// KeyAlgorithm is the algorithm of a certificate's private key
+type KeyAlgorithm string
+
+const (
+	RSAKeyAlgorithm   KeyAlgorithm = "RSA"
+	ECDSAKeyAlgorithm KeyAlgorithm = "ECDSA"
+)
+
+// Default key sizes, which match cert-manager's
+const (
+	defaultRSAKeySize   = 2048
+	defaultECDSAKeySize = 256
+)
+
+// KeyPair is an X.509 certificate along with its private key
+type KeyPair struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+}
+
+// CertificateRequest describes a certificate to be issued
+type CertificateRequest struct {
+	// Common name of the certificate's subject
+	CommonName string
+	// DNS names included as subject alternative names
+	DNSNames []string
+	// IP addresses included as subject alternative names
+	IPAddresses []net.IP
+	// Whether the certificate can be used to sign other certificates
+	IsCA bool
+	// Extended key usages of the certificate
+	ExtKeyUsages []x509.ExtKeyUsage
+	// How long the certificate is valid for
+	Duration time.Duration
+	// Algorithm of the private key. Defaults to RSA.
+	KeyAlgorithm KeyAlgorithm
+	// Size of the private key in bits, or the curve size for ECDSA. Uses a default size if zero.
+	KeySize int
+	// Existing private key to reuse, instead of generating a new one. It is only used
+	// if it matches the key algorithm and size.
+	PrivateKey crypto.Signer
+}
+
+// NewSelfSignedCA generates a new self-signed CA certificate
+func NewSelfSignedCA(req *CertificateRequest) (*KeyPair, error) {
+	return issue(req, nil)
+}
+
+// Issue generates a new certificate signed by this CA
+func (ca *KeyPair) Issue(req *CertificateRequest) (*KeyPair, error) {
+	if !ca.Certificate.IsCA {
+		return nil, errors.New("certificate is not a CA")
+	}
+	return issue(req, ca)
+}
+
+func issue(req *CertificateRequest, parent *KeyPair) (*KeyPair, error) {
+	key := req.PrivateKey
+	if key == nil || !req.matchesKey(key) {
+		var err error
+		key, err = req.generateKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: req.CommonName,
+		},
+		DNSNames:              req.DNSNames,
+		IPAddresses:           req.IPAddresses,
+		NotBefore:             now,
+		NotAfter:              now.Add(req.Duration),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           req.ExtKeyUsages,
+		BasicConstraintsValid: true,
+		IsCA:                  req.IsCA,
+	}
+	if req.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	// Only RSA keys are used for key encipherment
+	if _, ok := key.(*rsa.PrivateKey); !ok {
+		template.KeyUsage &^= x509.KeyUsageKeyEncipherment
+	}
+
+	// Self-sign the certificate if there is no parent CA
+	signer := &KeyPair{
+		Certificate: template,
+		PrivateKey:  key,
+	}
+	if parent != nil {
+		signer = parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.Certificate, key.Public(), signer.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		Certificate: cert,
+		PrivateKey:  key,
+	}, nil
+}
+
+func (req *CertificateRequest) generateKey() (crypto.Signer, error) {
+	switch req.KeyAlgorithm {
+	case ECDSAKeyAlgorithm:
+		curve, err := curveForSize(req.KeySize)
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	case RSAKeyAlgorithm, "":
+		size := req.KeySize
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		return rsa.GenerateKey(rand.Reader, size)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm \"%s\"", req.KeyAlgorithm)
+	}
+}
+
+// matchesKey returns whether the private key has the requested algorithm and size
+func (req *CertificateRequest) matchesKey(key crypto.Signer) bool {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		curve, err := curveForSize(req.KeySize)
+		return req.KeyAlgorithm == ECDSAKeyAlgorithm && err == nil && k.Curve == curve
+	case *rsa.PrivateKey:
+		size := req.KeySize
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		return (req.KeyAlgorithm == RSAKeyAlgorithm || req.KeyAlgorithm == "") && k.N.BitLen() == size
+	default:
+		return false
+	}
+}
+
+func curveForSize(size int) (elliptic.Curve, error) {
+	switch size {
+	case 256, 0:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported ECDSA key size %d", size)
+	}
+}
+
+// ParseCertificate decodes the first certificate in PEM-encoded data
+func ParseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM-encoded certificate found")
+	}
+	return x509.ParseCertificate(certBlock.Bytes)
+}
+
+// ParseCertificates decodes all certificates in PEM-encoded data, such as a CA bundle
+func ParseCertificates(certsPEM []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, certsPEM = pem.Decode(certsPEM)
+		if block == nil {
+			return certs, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+}
+
+// EncodeCertificates returns the PEM encoding of the certificates, in order
+func EncodeCertificates(certs ...*x509.Certificate) []byte {
+	buf := []byte{}
+	for _, cert := range certs {
+		buf = append(buf, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: cert.Raw,
+		})...)
+	}
+	return buf
+}
+
+// ParseKeyPair decodes a PEM-encoded certificate and private key
+func ParseKeyPair(certPEM []byte, keyPEM []byte) (*KeyPair, error) {
+	cert, err := ParseCertificate(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+	var key crypto.Signer
+	switch keyBlock.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(keyBlock.Bytes)
+	case "PRIVATE KEY":
+		var parsed any
+		parsed, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+		if err == nil {
+			signer, ok := parsed.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("unsupported private key type %T", parsed)
+			}
+			key = signer
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type \"%s\" for private key", keyBlock.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{
+		Certificate: cert,
+		PrivateKey:  key,
+	}, nil
+}
+
+// CertificatePEM returns the PEM encoding of the certificate
+func (kp *KeyPair) CertificatePEM() []byte {
+	return EncodeCertificates(kp.Certificate)
+}
+
+// PrivateKeyPEM returns the PEM encoding of the private key
+func (kp *KeyPair) PrivateKeyPEM() ([]byte, error) {
+	block := &pem.Block{}
+	switch key := kp.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		block.Type = "RSA PRIVATE KEY"
+		block.Bytes = x509.MarshalPKCS1PrivateKey(key)
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		block.Type = "EC PRIVATE KEY"
+		block.Bytes = der
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		block.Type = "PRIVATE KEY"
+		block.Bytes = der
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// NeedsReissue returns whether this certificate no longer satisfies the request,
+// was not signed by the provided CA, or is due to be renewed
+func (kp *KeyPair) NeedsReissue(req *CertificateRequest, ca *KeyPair, renewBefore time.Duration) bool {
+	cert := kp.Certificate
+	if cert.Subject.CommonName != req.CommonName || !slices.Equal(cert.DNSNames, req.DNSNames) ||
+		!slices.EqualFunc(cert.IPAddresses, req.IPAddresses, net.IP.Equal) || cert.IsCA != req.IsCA || !slices.Equal(cert.ExtKeyUsage, req.ExtKeyUsages) || !req.matchesKey(kp.PrivateKey) {
+		return true
+	}
+	if ca != nil && cert.CheckSignatureFrom(ca.Certificate) != nil {
+		return true
+	}
+	return !time.Now().Before(kp.RenewalTime(renewBefore))
+}
+
+// RenewalTime returns when this key pair's certificate should be renewed, given how long before
+// it expires it should be renewed
+func (kp *KeyPair) RenewalTime(renewBefore time.Duration) time.Time {
+	return kp.Certificate.NotAfter.Add(-renewBefore)
+}
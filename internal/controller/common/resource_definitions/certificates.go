@@ -19,6 +19,7 @@ import (
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
@@ -57,7 +58,7 @@ func NewCryostatCAIssuer(gvk *schema.GroupVersionKind, cr *model.CryostatInstanc
 }
 
 func NewCryostatCACert(gvk *schema.GroupVersionKind, cr *model.CryostatInstance) *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name + "-ca",
 			Namespace: cr.InstallNamespace,
@@ -70,11 +71,78 @@ func NewCryostatCACert(gvk *schema.GroupVersionKind, cr *model.CryostatInstance)
 			},
 			IsCA: true,
 		},
+	}, caCertificateOptions(cr))
+}
+
+func caCertificateOptions(cr *model.CryostatInstance) *operatorv1beta2.CertificateOptions {
+	if cr.Spec.TLSOptions == nil {
+		return nil
+	}
+	return cr.Spec.TLSOptions.CACertificate
+}
+
+func leafCertificateOptions(cr *model.CryostatInstance) *operatorv1beta2.CertificateOptions {
+	if cr.Spec.TLSOptions == nil {
+		return nil
+	}
+	return cr.Spec.TLSOptions.Certificates
+}
+
+// withCertificateOptions applies the user-provided lifetime and private key options to the certificate.
+// Unset options are left for cert-manager, or the operator, to default.
+func withCertificateOptions(cert *certv1.Certificate, options *operatorv1beta2.CertificateOptions) *certv1.Certificate {
+	if options == nil {
+		return cert
+	}
+	cert.Spec.Duration = options.Duration
+	cert.Spec.RenewBefore = options.RenewBefore
+	if options.PrivateKey != nil {
+		cert.Spec.PrivateKey = &certv1.CertificatePrivateKey{
+			Algorithm:      certv1.PrivateKeyAlgorithm(options.PrivateKey.Algorithm),
+			Size:           options.PrivateKey.Size,
+			RotationPolicy: certv1.PrivateKeyRotationPolicy(options.PrivateKey.RotationPolicy),
+		}
+	}
+	return cert
+}
+
+// GetCertificateKeyAlgorithm returns the private key algorithm of the certificates
+// issued for Cryostat components and agents
+func GetCertificateKeyAlgorithm(cr *model.CryostatInstance) string {
+	options := leafCertificateOptions(cr)
+	if options == nil || options.PrivateKey == nil || len(options.PrivateKey.Algorithm) == 0 {
+		return string(certv1.RSAKeyAlgorithm)
+	}
+	return options.PrivateKey.Algorithm
+}
+
+// HasCustomIssuer returns whether certificates for the CR are issued by a user-provided
+// issuer, rather than the CA created by the operator
+func HasCustomIssuer(cr *model.CryostatInstance) bool {
+	return cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.IssuerRef != nil
+}
+
+// newIssuerRef refers to the issuer of the certificates for Cryostat components
+func newIssuerRef(cr *model.CryostatInstance) certMeta.ObjectReference {
+	if !HasCustomIssuer(cr) {
+		return certMeta.ObjectReference{
+			Name: cr.Name + "-ca",
+		}
+	}
+	issuerRef := cr.Spec.TLSOptions.IssuerRef
+	kind := issuerRef.Kind
+	if len(kind) == 0 {
+		kind = certv1.IssuerKind
+	}
+	return certMeta.ObjectReference{
+		Name:  issuerRef.Name,
+		Kind:  kind,
+		Group: certv1.SchemeGroupVersion.Group,
 	}
 }
 
 func NewCryostatCert(cr *model.CryostatInstance, keystoreSecretName string) *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
 			Namespace: cr.InstallNamespace,
@@ -99,19 +167,17 @@ func NewCryostatCert(cr *model.CryostatInstance, keystoreSecretName string) *cer
 					Profile: certv1.Modern2023PKCS12Profile,
 				},
 			},
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef: newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 				certv1.UsageClientAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
 }
 
 func NewReportsCert(cr *model.CryostatInstance) *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name + "-reports",
 			Namespace: cr.InstallNamespace,
@@ -124,18 +190,16 @@ func NewReportsCert(cr *model.CryostatInstance) *certv1.Certificate {
 				fmt.Sprintf("%s-reports.%s.svc.cluster.local", cr.Name, cr.InstallNamespace),
 			},
 			SecretName: cr.Name + "-reports-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef:  newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
 }
 
 func NewDatabaseCert(cr *model.CryostatInstance) *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name + "-database",
 			Namespace: cr.InstallNamespace,
@@ -148,18 +212,16 @@ func NewDatabaseCert(cr *model.CryostatInstance) *certv1.Certificate {
 				fmt.Sprintf("%s-database.%s.svc.cluster.local", cr.Name, cr.InstallNamespace),
 			},
 			SecretName: cr.Name + "-database-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef:  newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
 }
 
 func NewStorageCert(cr *model.CryostatInstance) *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name + "-storage",
 			Namespace: cr.InstallNamespace,
@@ -172,21 +234,19 @@ func NewStorageCert(cr *model.CryostatInstance) *certv1.Certificate {
 				fmt.Sprintf("%s-storage.%s.svc.cluster.local", cr.Name, cr.InstallNamespace),
 			},
 			SecretName: cr.Name + "-storage-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef:  newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 				certv1.UsageClientAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
 }
 
 func NewAgentCert(cr *model.CryostatInstance, namespace string, gvk *schema.GroupVersionKind) *certv1.Certificate {
 	svcName := common.AgentCallbackServiceName(gvk, cr)
 	name := common.AgentCertificateName(gvk, cr, namespace)
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: cr.InstallNamespace,
@@ -197,20 +257,18 @@ func NewAgentCert(cr *model.CryostatInstance, namespace string, gvk *schema.Grou
 				fmt.Sprintf("*.%s.%s.svc", svcName, namespace),
 			},
 			SecretName: name,
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef:  newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 				certv1.UsageClientAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
 }
 
 func NewAgentProxyCert(cr *model.CryostatInstance) *certv1.Certificate {
 	svcName := common.AgentGatewayServiceName(cr)
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name + "-agent-proxy",
 			Namespace: cr.InstallNamespace,
@@ -223,12 +281,57 @@ func NewAgentProxyCert(cr *model.CryostatInstance) *certv1.Certificate {
 				fmt.Sprintf("%s.%s.svc.cluster.local", svcName, cr.InstallNamespace),
 			},
 			SecretName: cr.Name + "-agent-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: cr.Name + "-ca",
-			},
+			IssuerRef:  newIssuerRef(cr),
 			Usages: append(certv1.DefaultKeyUsages(),
 				certv1.UsageServerAuth,
 			),
 		},
-	}
+	}, leafCertificateOptions(cr))
+}
+
+// NewGrafanaCert creates a certificate for the Grafana container, which is only reachable
+// from other containers in the Cryostat pod
+func NewGrafanaCert(cr *model.CryostatInstance) *certv1.Certificate {
+	return withCertificateOptions(&certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-grafana",
+			Namespace: cr.InstallNamespace,
+		},
+		Spec: certv1.CertificateSpec{
+			CommonName:  constants.GrafanaTLSCommonName,
+			DNSNames:    []string{"localhost"},
+			IPAddresses: []string{constants.LoopbackAddress},
+			SecretName:  cr.Name + "-grafana-tls",
+			IssuerRef:   newIssuerRef(cr),
+			Usages: append(certv1.DefaultKeyUsages(),
+				certv1.UsageServerAuth,
+			),
+		},
+	}, leafCertificateOptions(cr))
+}
+
+// NewDatasourceCert creates a certificate for the JFR datasource container, which is only
+// reachable from other containers in the Cryostat pod
+func NewDatasourceCert(cr *model.CryostatInstance) *certv1.Certificate {
+	return withCertificateOptions(&certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-jfr-datasource",
+			Namespace: cr.InstallNamespace,
+		},
+		Spec: certv1.CertificateSpec{
+			CommonName:  constants.DatasourceTLSCommonName,
+			DNSNames:    []string{"localhost"},
+			IPAddresses: []string{constants.LoopbackAddress},
+			SecretName:  cr.Name + "-jfr-datasource-tls",
+			IssuerRef:   newIssuerRef(cr),
+			Usages: append(certv1.DefaultKeyUsages(),
+				certv1.UsageServerAuth,
+			),
+		},
+	}, leafCertificateOptions(cr))
+}
+
+// NewSidecarCerts returns the certificates for the Grafana and JFR datasource containers
+func NewSidecarCerts(cr *model.CryostatInstance) []*certv1.Certificate {
+	return []*certv1.Certificate{NewGrafanaCert(cr), NewDatasourceCert(cr)}
 }
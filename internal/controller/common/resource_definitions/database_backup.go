@@ -0,0 +1,567 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_definitions
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	common "github.com/cryostatio/cryostat-operator/internal/controller/common"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DatabaseRestoreBackupAnnotation records the backup that a restore Job restores
+	DatabaseRestoreBackupAnnotation = "operator.cryostat.io/database-backup"
+	defaultDatabaseBackupRetention  = int32(7)
+	defaultDatabaseBackupBucket     = "database-backups"
+	databaseBackupMountPath         = "/var/lib/cryostat/backups"
+	// Exit code of scripts when retrying cannot succeed, which fails the Job immediately
+	nonRetryableExitCode = int32(3)
+)
+
+// Backs up the database using pg_dump's custom format, then deletes all but the most recent backups
+const databaseBackupScript = `set -euo pipefail
+name="cryostat-$(date -u +%Y%m%d%H%M%S).dump"
+pg_dump --format=custom --file="${BACKUP_DIR}/${name}.partial"
+if [ -n "${BUCKET_URL:-}" ]; then
+  s3 --request PUT "${BUCKET_URL}" >/dev/null 2>&1 || true
+  s3 --upload-file "${BACKUP_DIR}/${name}.partial" "${BUCKET_URL}/${name}"
+  rm -f "${BACKUP_DIR}/${name}.partial"
+  { s3 "${BUCKET_URL}?list-type=2&prefix=cryostat-" | grep -oE '<Key>cryostat-[0-9]{14}\.dump</Key>' || true; } |
+    sed -E 's#</?Key>##g' | sort -r | tail -n "+$((RETENTION + 1))" | while read -r old; do
+      s3 --request DELETE "${BUCKET_URL}/${old}"
+      echo "Deleted backup ${old}"
+    done
+else
+  mv "${BACKUP_DIR}/${name}.partial" "${BACKUP_DIR}/${name}"
+  { ls -1 "${BACKUP_DIR}" | grep -E '^cryostat-[0-9]{14}\.dump$' || true; } |
+    sort -r | tail -n "+$((RETENTION + 1))" | while read -r old; do
+      rm -f "${BACKUP_DIR}/${old}"
+      echo "Deleted backup ${old}"
+    done
+fi
+echo "Created backup ${name}"
+`
+
+// Restores a backup into the database. Fails without retrying if the database already contains tables.
+const databaseRestoreScript = `set -euo pipefail
+until pg_isready --timeout=5; do
+  echo "Waiting for the database to accept connections"
+  sleep 5
+done
+tables="$(psql --no-align --tuples-only --command="SELECT count(*) FROM pg_tables WHERE schemaname = 'public'")"
+if [ "${tables}" != "0" ]; then
+  echo "The database already contains ${tables} tables, refusing to restore backup ${BACKUP_NAME}" >&2
+  exit 3
+fi
+if [ -n "${BUCKET_URL:-}" ]; then
+  s3 --output "${BACKUP_DIR}/${BACKUP_NAME}" "${BUCKET_URL}/${BACKUP_NAME}"
+fi
+pg_restore --no-owner --no-privileges --no-comments --single-transaction --exit-on-error \
+  --dbname="${PGDATABASE}" "${BACKUP_DIR}/${BACKUP_NAME}"
+echo "Restored backup ${BACKUP_NAME}"
+`
+
+// Defines s3_request, which sends an S3 request signed with the object storage credentials, and s3,
+// which also fails on HTTP errors. Signing requires curl 7.75 or later, so the script fails without
+// retrying if the curl in the image does not support it.
+const objectStorageS3Function = `if ! curl --help all 2>/dev/null | grep -q -e '--aws-sigv4'; then
+  echo "curl does not support --aws-sigv4, which requires curl 7.75 or later: $(curl --version | head -n 1)" >&2
+  exit 3
+fi
+s3_request() {
+  curl --silent --show-error ${S3_CURL_OPTS:-} --aws-sigv4 "aws:amz:${S3_REGION}:s3" \
+    --user "${AWS_ACCESS_KEY_ID}:${AWS_SECRET_ACCESS_KEY}" "$@"
+}
+s3() {
+  s3_request --fail "$@"
+}
+`
+
+// DatabaseBackupLabels returns the labels of pods that back up and restore the database
+func DatabaseBackupLabels(cr *model.CryostatInstance) map[string]string {
+	return map[string]string{
+		"app":       cr.Name,
+		"kind":      "cryostat",
+		"component": "database-backup",
+	}
+}
+
+// DatabaseBackupEnabled returns whether the database deployed by the operator should be backed up
+func DatabaseBackupEnabled(cr *model.CryostatInstance) bool {
+	return DeployManagedDatabase(cr) && cr.Spec.DatabaseOptions != nil && cr.Spec.DatabaseOptions.Backup != nil
+}
+
+// DatabaseRestoreEnabled returns whether the database deployed by the operator should be restored from a backup
+func DatabaseRestoreEnabled(cr *model.CryostatInstance) bool {
+	return DeployManagedDatabase(cr) && cr.Spec.DatabaseOptions != nil && cr.Spec.DatabaseOptions.Restore != nil
+}
+
+// DatabaseBackupUsesObjectStorage returns whether backups are stored in, or restored from, object storage
+func DatabaseBackupUsesObjectStorage(cr *model.CryostatInstance) bool {
+	if DatabaseBackupEnabled(cr) && cr.Spec.DatabaseOptions.Backup.Destination.ObjectStorage != nil {
+		return true
+	}
+	if DatabaseRestoreEnabled(cr) {
+		source := getDatabaseRestoreSource(cr)
+		return source != nil && source.ObjectStorage != nil
+	}
+	return false
+}
+
+func NewCronJobForDatabaseBackup(cr *model.CryostatInstance, imageTags *ImageTags, tls *TLSConfig,
+	specs *ServiceSpecs, openshift bool, fsGroup int64) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-database-backup",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	if !DatabaseBackupEnabled(cr) {
+		return cronJob, nil
+	}
+	backup := cr.Spec.DatabaseOptions.Backup
+
+	retention := defaultDatabaseBackupRetention
+	if backup.Retention != nil {
+		retention = *backup.Retention
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "RETENTION",
+			Value: strconv.Itoa(int(retention)),
+		},
+	}
+	podSpec, err := newPodForDatabaseClient(cr, imageTags, tls, specs, openshift, fsGroup, &backup.Destination,
+		databaseBackupScript, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := DatabaseBackupLabels(cr)
+	backoffLimit := int32(2)
+	cronJob.Labels = labels
+	cronJob.Spec = batchv1.CronJobSpec{
+		Schedule:          backup.Schedule,
+		ConcurrencyPolicy: batchv1.ForbidConcurrent,
+		JobTemplate: batchv1.JobTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: batchv1.JobSpec{
+				BackoffLimit:     &backoffLimit,
+				PodFailurePolicy: newNonRetryablePodFailurePolicy(podSpec.Containers[0].Name),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: labels,
+					},
+					Spec: *podSpec,
+				},
+			},
+		},
+	}
+	return cronJob, nil
+}
+
+func NewJobForDatabaseRestore(cr *model.CryostatInstance, imageTags *ImageTags, tls *TLSConfig,
+	specs *ServiceSpecs, openshift bool, fsGroup int64) (*batchv1.Job, error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-database-restore",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	if !DatabaseRestoreEnabled(cr) {
+		return job, nil
+	}
+	restore := cr.Spec.DatabaseOptions.Restore
+
+	source := getDatabaseRestoreSource(cr)
+	if source == nil {
+		return nil, fmt.Errorf("no source specified to restore database backup %s from", restore.BackupName)
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "BACKUP_NAME",
+			Value: restore.BackupName,
+		},
+	}
+	podSpec, err := newPodForDatabaseClient(cr, imageTags, tls, specs, openshift, fsGroup, source,
+		databaseRestoreScript, envs)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := DatabaseBackupLabels(cr)
+	backoffLimit := int32(3)
+	job.Labels = labels
+	job.Annotations = map[string]string{
+		DatabaseRestoreBackupAnnotation: restore.BackupName,
+	}
+	job.Spec = batchv1.JobSpec{
+		BackoffLimit:     &backoffLimit,
+		PodFailurePolicy: newNonRetryablePodFailurePolicy(podSpec.Containers[0].Name),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: *podSpec,
+		},
+	}
+	return job, nil
+}
+
+// newNonRetryablePodFailurePolicy fails a Job without further retries once its script exits with
+// nonRetryableExitCode
+func newNonRetryablePodFailurePolicy(containerName string) *batchv1.PodFailurePolicy {
+	return &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					ContainerName: &containerName,
+					Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+					Values:        []int32{nonRetryableExitCode},
+				},
+			},
+		},
+	}
+}
+
+func getDatabaseRestoreSource(cr *model.CryostatInstance) *operatorv1beta2.DatabaseBackupDestination {
+	if cr.Spec.DatabaseOptions.Restore.Source != nil {
+		return cr.Spec.DatabaseOptions.Restore.Source
+	}
+	if cr.Spec.DatabaseOptions.Backup != nil {
+		return &cr.Spec.DatabaseOptions.Backup.Destination
+	}
+	return nil
+}
+
+// newPodForDatabaseClient returns a pod that runs a script using the PostgreSQL client tools
+// from the database image, connected to the database deployed by the operator
+func newPodForDatabaseClient(cr *model.CryostatInstance, imageTags *ImageTags, tls *TLSConfig, specs *ServiceSpecs,
+	openshift bool, fsGroup int64, dest *operatorv1beta2.DatabaseBackupDestination, script string,
+	extraEnvs []corev1.EnvVar) (*corev1.PodSpec, error) {
+	optional := false
+	envs := []corev1.EnvVar{
+		{
+			Name:  "PGHOST",
+			Value: fmt.Sprintf("%s-database.%s.svc", cr.Name, cr.InstallNamespace),
+		},
+		{
+			Name:  "PGPORT",
+			Value: strconv.Itoa(int(constants.DatabasePort)),
+		},
+		{
+			Name:  "PGUSER",
+			Value: "cryostat",
+		},
+		{
+			Name:  "PGDATABASE",
+			Value: DatabaseName,
+		},
+		{
+			Name: "PGPASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getDatabaseSecret(cr),
+					},
+					Key:      constants.DatabaseSecretConnectionKey,
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name:  "BACKUP_DIR",
+			Value: databaseBackupMountPath,
+		},
+	}
+	envs = append(envs, extraEnvs...)
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      "backups",
+			MountPath: databaseBackupMountPath,
+		},
+	}
+	volumes := []corev1.Volume{}
+
+	readOnlyMode := int32(0440)
+	if tls != nil {
+		tlsPath := path.Join(SecretMountPrefix, tls.DatabaseSecret)
+		envs = append(envs,
+			corev1.EnvVar{
+				Name:  "PGSSLMODE",
+				Value: databaseSSLModeVerifyFull,
+			},
+			corev1.EnvVar{
+				Name:  "PGSSLROOTCERT",
+				Value: path.Join(tlsPath, constants.CAKey),
+			},
+		)
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "database-tls-secret",
+			MountPath: tlsPath,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "database-tls-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: tls.DatabaseSecret,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  constants.CAKey,
+							Path: constants.CAKey,
+							Mode: &readOnlyMode,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	if dest.PersistentVolumeClaim != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: "backups",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: dest.PersistentVolumeClaim.ClaimName,
+				},
+			},
+		})
+	} else {
+		// Stage backups in a temporary directory before uploading them
+		volumes = append(volumes, corev1.Volume{
+			Name: "backups",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		s3Envs, s3Mounts, s3Volumes, err := newObjectStorageEnvForDatabaseBackup(cr, tls, specs, dest.ObjectStorage)
+		if err != nil {
+			return nil, err
+		}
+		envs = append(envs, s3Envs...)
+		mounts = append(mounts, s3Mounts...)
+		volumes = append(volumes, s3Volumes...)
+		script = objectStorageS3Function + script
+	}
+
+	var containerSc *corev1.SecurityContext
+	if cr.Spec.SecurityOptions != nil && cr.Spec.SecurityOptions.DatabaseSecurityContext != nil {
+		containerSc = cr.Spec.SecurityOptions.DatabaseSecurityContext
+	} else {
+		privEscalation := false
+		containerSc = &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &privEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{constants.CapabilityAll},
+			},
+		}
+	}
+
+	// Use the same pod configuration as the database
+	podSpec := NewPodForDatabase(cr, imageTags, tls, openshift, fsGroup)
+	automountSAToken := false
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:            cr.Name + "-db-client",
+				Image:           imageTags.DatabaseImageTag,
+				ImagePullPolicy: common.GetPullPolicy(imageTags.DatabaseImageTag),
+				Command:         []string{"/bin/bash", "-c", script},
+				Env:             envs,
+				VolumeMounts:    mounts,
+				SecurityContext: containerSc,
+				Resources:       *NewDatabaseContainerResource(cr),
+			},
+		},
+		RestartPolicy:                corev1.RestartPolicyNever,
+		AutomountServiceAccountToken: &automountSAToken,
+		NodeSelector:                 podSpec.NodeSelector,
+		Affinity:                     podSpec.Affinity,
+		Tolerations:                  podSpec.Tolerations,
+		SecurityContext:              podSpec.SecurityContext,
+		Volumes:                      volumes,
+	}, nil
+}
+
+// newObjectStorageEnvForDatabaseBackup configures requests to the bucket storing database backups,
+// using the same object storage and static credentials as Cryostat
+func newObjectStorageEnvForDatabaseBackup(cr *model.CryostatInstance, tls *TLSConfig, specs *ServiceSpecs,
+	options *operatorv1beta2.DatabaseBackupObjectStorage) ([]corev1.EnvVar, []corev1.VolumeMount, []corev1.Volume, error) {
+	client, err := newObjectStorageClient(cr, tls, specs, "database backups to object storage")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	bucket := defaultDatabaseBackupBucket
+	if options.Bucket != nil {
+		bucket = *options.Bucket
+	}
+	envs := append([]corev1.EnvVar{
+		{
+			Name:  "BUCKET_URL",
+			Value: client.bucketURL(bucket),
+		},
+	}, client.envs...)
+	return envs, client.mounts, client.volumes, nil
+}
+
+// ObjectStorageCredentialsError indicates that scripts run by the operator cannot authenticate
+// with object storage using the configured credentials
+type ObjectStorageCredentialsError struct {
+	// Feature requiring the credentials, for the error message
+	Purpose string
+	// Configured credentials mode
+	Mode operatorv1beta2.ObjectStorageCredentialsMode
+}
+
+func (e *ObjectStorageCredentialsError) Error() string {
+	return fmt.Sprintf("%s require %s object storage credentials, but %s credentials are configured",
+		e.Purpose, operatorv1beta2.ObjectStorageCredentialsStatic, e.Mode)
+}
+
+// objectStorageClient describes how scripts run by the operator send signed requests
+// to the object storage used by Cryostat
+type objectStorageClient struct {
+	endpoint    *url.URL
+	virtualHost bool
+	envs        []corev1.EnvVar
+	mounts      []corev1.VolumeMount
+	volumes     []corev1.Volume
+}
+
+// newObjectStorageClient configures requests to the same object storage as Cryostat, signed with
+// the static credentials from the storage secret. The purpose describes the feature for error messages.
+func newObjectStorageClient(cr *model.CryostatInstance, tls *TLSConfig, specs *ServiceSpecs,
+	purpose string) (*objectStorageClient, error) {
+	// Scripts sign their requests with the access keys from the storage secret
+	if mode := GetObjectStorageCredentialsMode(cr); mode != operatorv1beta2.ObjectStorageCredentialsStatic {
+		return nil, &ObjectStorageCredentialsError{Purpose: purpose, Mode: mode}
+	}
+
+	client := &objectStorageClient{
+		mounts:  []corev1.VolumeMount{},
+		volumes: []corev1.Volume{},
+	}
+	region := "us-east-1"
+	curlOpts := ""
+	if DeployManagedStorage(cr) {
+		if specs.StorageURL == nil {
+			return nil, fmt.Errorf("the URL of the object storage deployed by the operator is unknown")
+		}
+		client.endpoint = specs.StorageURL
+		if tls != nil {
+			tlsPath := path.Join(SecretMountPrefix, tls.StorageSecret)
+			curlOpts = "--cacert " + path.Join(tlsPath, constants.CAKey)
+			readOnlyMode := int32(0440)
+			client.mounts = append(client.mounts, corev1.VolumeMount{
+				Name:      "storage-tls-secret",
+				MountPath: tlsPath,
+				ReadOnly:  true,
+			})
+			client.volumes = append(client.volumes, corev1.Volume{
+				Name: "storage-tls-secret",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: tls.StorageSecret,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  constants.CAKey,
+								Path: constants.CAKey,
+								Mode: &readOnlyMode,
+							},
+						},
+					},
+				},
+			})
+		}
+	} else {
+		provider := cr.Spec.ObjectStorageOptions.Provider
+		parsed, err := url.Parse(*provider.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object storage URL %q: %w", *provider.URL, err)
+		}
+		client.endpoint = parsed
+		if provider.Region != nil {
+			region = *provider.Region
+		}
+		client.virtualHost = provider.UseVirtualHostAccess != nil && *provider.UseVirtualHostAccess
+		if provider.TLSTrustAll != nil && *provider.TLSTrustAll {
+			curlOpts = "--insecure"
+		}
+	}
+
+	optional := false
+	secretName := getStorageSecret(cr)
+	client.envs = []corev1.EnvVar{
+		{
+			Name:  "S3_REGION",
+			Value: region,
+		},
+		{
+			Name: "AWS_ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      "ACCESS_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name: "AWS_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      "SECRET_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+	}
+	if len(curlOpts) > 0 {
+		client.envs = append(client.envs, corev1.EnvVar{
+			Name:  "S3_CURL_OPTS",
+			Value: curlOpts,
+		})
+	}
+	return client, nil
+}
+
+// bucketURL returns the URL of a bucket, using either virtual host or path style access
+func (c *objectStorageClient) bucketURL(bucket string) string {
+	bucketURL := *c.endpoint
+	if c.virtualHost {
+		bucketURL.Host = bucket + "." + bucketURL.Host
+	} else {
+		bucketURL.Path = path.Join("/", bucketURL.Path, bucket)
+	}
+	return bucketURL.String()
+}
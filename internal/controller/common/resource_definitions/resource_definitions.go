@@ -15,18 +15,22 @@
 package resource_definitions
 
 import (
+	cryptotls "crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
 	"path"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	common "github.com/cryostatio/cryostat-operator/internal/controller/common"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	"github.com/cryostatio/cryostat-operator/internal/tlsprofile"
 	appsv1 "k8s.io/api/apps/v1"
 	authzv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -69,10 +73,23 @@ type TLSConfig struct {
 	StorageSecret string
 	// Name of the TLS secret for the agent proxy
 	AgentProxySecret string
+	// Name of the TLS secret for Grafana, if the sidecar containers use TLS
+	GrafanaSecret string
+	// Name of the TLS secret for the JFR datasource, if the sidecar containers use TLS
+	DatasourceSecret string
 	// Name of the secret containing the password for the keystore in CryostatSecret
 	KeystorePassSecret string
-	// PEM-encoded X.509 certificate for the Cryostat CA
-	CACert []byte
+	// Name of the secret containing the bundle of trusted CA certificates
+	CABundleSecret string
+	// PEM-encoded X.509 certificates trusted by Cryostat components. This includes the Cryostat CA,
+	// and any previous CA that is still trusted while the CA certificate changes.
+	CABundle []byte
+	// TLS versions and ciphers accepted by Cryostat components
+	Profile *tlsprofile.Profile
+	// When TLS should next be reconciled, such as to renew a certificate issued by the operator,
+	// or to stop trusting a previous CA certificate.
+	// Zero if no reconcile is needed.
+	RequeueAt time.Time
 }
 
 const (
@@ -111,9 +128,16 @@ const (
 	OAuth2ConfigFileName              string = "alpha_config.json"
 	OAuth2ConfigFilePath              string = "/etc/oauth2_proxy/alpha_config"
 	DatabaseName                      string = "cryostat"
+	databaseSSLModeVerifyFull         string = "verify-full"
+	externalDatabaseCAPath            string = "/var/run/secrets/operator.cryostat.io/external-database-ca"
+	webIdentityTokenPath              string = "/var/run/secrets/operator.cryostat.io/object-storage-token"
+	webIdentityTokenFile              string = "token"
+	defaultWebIdentityAudience        string = "sts.amazonaws.com"
 	SecretMountPrefix                 string = "/var/run/secrets/operator.cryostat.io"
 )
 
+const defaultWebIdentityExpirationSeconds int64 = 86400
+
 func createMapCopy(in map[string]string) map[string]string {
 	copy := make(map[string]string)
 	for k, v := range in {
@@ -505,10 +529,10 @@ func NewPodForCR(cr *model.CryostatInstance, specs *ServiceSpecs, imageTags *Ima
 
 	if tls != nil {
 		volSources = append(volSources, corev1.VolumeProjection{
-			// Add Cryostat self-signed CA
+			// Add Cryostat self-signed CA, along with any previous CA that is still trusted
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: tls.CryostatSecret,
+					Name: tls.CABundleSecret,
 				},
 				Items: []corev1.KeyToPath{
 					{
@@ -616,7 +640,49 @@ func NewPodForCR(cr *model.CryostatInstance, specs *ServiceSpecs, imageTags *Ima
 				},
 			},
 		}
-		volumes = append(volumes, dbTlsVolume)
+		if DeployManagedDatabase(cr) {
+			volumes = append(volumes, dbTlsVolume)
+		}
+
+		if UseSidecarTLS(tls) {
+			volumes = append(volumes,
+				corev1.Volume{
+					Name: "grafana-tls-secret",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  tls.GrafanaSecret,
+							DefaultMode: &readOnlyMode,
+						},
+					},
+				},
+				corev1.Volume{
+					Name: "jfr-datasource-tls-secret",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  tls.DatasourceSecret,
+							DefaultMode: &readOnlyMode,
+						},
+					},
+				},
+				corev1.Volume{
+					Name: "ca-bundle",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  tls.CABundleSecret,
+							DefaultMode: &readOnlyMode,
+						},
+					},
+				},
+			)
+		}
+	}
+
+	if hasExternalDatabaseCA(cr) {
+		volumes = append(volumes, newExternalDatabaseCAVolume(cr.Spec.DatabaseOptions.External.CACertificate, &readOnlyMode))
+	}
+
+	if usesWebIdentityCredentials(cr) {
+		volumes = append(volumes, newWebIdentityTokenVolume(cr, &readOnlyMode))
 	}
 
 	// Project certificate secrets into deployment
@@ -861,6 +927,12 @@ func NewPodForDatabase(cr *model.CryostatInstance, imageTags *ImageTags, tls *TL
 	}
 }
 
+// DeployManagedDatabase returns whether the operator should deploy a database for Cryostat,
+// rather than using an external database
+func DeployManagedDatabase(cr *model.CryostatInstance) bool {
+	return cr.Spec.DatabaseOptions == nil || cr.Spec.DatabaseOptions.External == nil
+}
+
 func DeployManagedStorage(cr *model.CryostatInstance) bool {
 	return cr.Spec.ObjectStorageOptions == nil ||
 		cr.Spec.ObjectStorageOptions.Provider == nil ||
@@ -1026,6 +1098,11 @@ func NewPodForReports(cr *model.CryostatInstance, imageTags *ImageTags, serviceS
 			tlsConfigName,
 			path.Join(SecretMountPrefix, tls.ReportsSecret, corev1.TLSPrivateKeyKey),
 		)
+		profile := getTLSProfile(tls)
+		javaOpts += fmt.Sprintf(" -Dquarkus.tls.%s.protocols=%s", tlsConfigName, strings.Join(profile.Versions(), ","))
+		if ciphers := profile.IANACiphers(); len(ciphers) > 0 {
+			javaOpts += fmt.Sprintf(" -Dquarkus.tls.%s.cipher-suites=%s", tlsConfigName, strings.Join(ciphers, ","))
+		}
 		tlsSecretMount := corev1.VolumeMount{
 			Name:      "reports-tls-secret",
 			MountPath: path.Join(SecretMountPrefix, tls.ReportsSecret),
@@ -1177,7 +1254,7 @@ func NewOpenShiftAuthProxyContainer(cr *model.CryostatInstance, specs *ServiceSp
 		"--pass-user-bearer-token=false",
 		"--pass-basic-auth=false",
 		fmt.Sprintf("--upstream=http://localhost:%d/", constants.CryostatHTTPContainerPort),
-		fmt.Sprintf("--upstream=http://localhost:%d/grafana/", constants.GrafanaContainerPort),
+		fmt.Sprintf("--upstream=%s/grafana/", getInternalDashboardURL(tls)),
 		fmt.Sprintf("--openshift-service-account=%s", cr.Name),
 		"--proxy-websockets=true",
 		"--proxy-prefix=/oauth2",
@@ -1239,6 +1316,12 @@ func NewOpenShiftAuthProxyContainer(cr *model.CryostatInstance, specs *ServiceSp
 		)
 	}
 
+	if UseSidecarTLS(tls) {
+		// Trust the Cryostat CA when connecting to Grafana
+		args = append(args, fmt.Sprintf("--upstream-ca=%s", path.Join(SecretMountPrefix, tls.CABundleSecret, constants.CAKey)))
+		volumeMounts = append(volumeMounts, newCABundleVolumeMount(tls))
+	}
+
 	cookieOptional := false
 	return &corev1.Container{
 		Name:            cr.Name + "-auth-proxy",
@@ -1346,6 +1429,12 @@ func NewOAuth2ProxyContainer(cr *model.CryostatInstance, specs *ServiceSpecs, im
 		livenessProbeScheme = corev1.URISchemeHTTPS
 	}
 
+	if UseSidecarTLS(tls) {
+		// Trust the Cryostat CA when connecting to Grafana, in addition to the system's CA certificates
+		volumeMounts = append(volumeMounts, newCABundleVolumeMount(tls))
+		envs = append(envs, newCABundleCertDirEnv(tls))
+	}
+
 	if isBasicAuthEnabled(cr) {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      cr.Name + "-auth-proxy-htpasswd",
@@ -1504,7 +1593,7 @@ func NewCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, imageTag
 		mounts = append(mounts, mount)
 	}
 
-	if tls != nil {
+	if tls != nil && DeployManagedDatabase(cr) {
 		tlsPath := path.Join(SecretMountPrefix, tls.DatabaseSecret)
 		tlsSecretMount := corev1.VolumeMount{
 			Name:      "database-tls-secret",
@@ -1514,6 +1603,22 @@ func NewCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, imageTag
 		mounts = append(mounts, tlsSecretMount)
 	}
 
+	if hasExternalDatabaseCA(cr) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "external-database-ca",
+			MountPath: externalDatabaseCAPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if usesWebIdentityCredentials(cr) {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "object-storage-token",
+			MountPath: webIdentityTokenPath,
+			ReadOnly:  true,
+		})
+	}
+
 	probeHandler := corev1.ProbeHandler{
 		Exec: &corev1.ExecAction{
 			Command: []string{
@@ -1610,10 +1715,6 @@ func newEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, tls
 			Name:  "QUARKUS_HIBERNATE_ORM_SQL_LOAD_SCRIPT",
 			Value: "no-file",
 		},
-		{
-			Name:  "QUARKUS_DATASOURCE_USERNAME",
-			Value: "cryostat",
-		},
 		{
 			Name:  "CRYOSTAT_CONFIG_PATH",
 			Value: configPath,
@@ -1634,7 +1735,7 @@ func newEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, tls
 		},
 		{
 			Name:  "QUARKUS_S3_AWS_CREDENTIALS_TYPE",
-			Value: "static",
+			Value: getObjectStorageCredentialsType(cr),
 		},
 	}
 	if cr.Spec.EnableAudit != nil {
@@ -1655,6 +1756,7 @@ func newEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, tls
 				Value: path.Join(SecretMountPrefix, "client-tls", tls.KeystorePassSecret, constants.KeystorePassFile),
 			},
 		)
+		envs = append(envs, newTLSProfileEnvForQuarkus(tls)...)
 	}
 
 	storageEnv, err := newStorageEnvForCoreContainer(cr, specs)
@@ -1669,16 +1771,49 @@ func newEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs, tls
 		newInsightsEnvForCoreContainer(specs),
 		newTargetConnectionCacheEnvForCoreContainer(cr),
 		newK8SDiscoveryEnvForCoreContainer(cr),
-		newGrafanaEnvForCoreContainer(specs),
+		newGrafanaEnvForCoreContainer(specs, tls),
 		newAgentEnvForCoreContainer(cr),
 	), nil
 }
 
+func getTLSProfile(tls *TLSConfig) *tlsprofile.Profile {
+	if tls.Profile == nil {
+		return tlsprofile.Default()
+	}
+	return tls.Profile
+}
+
+// Configures the TLS versions and ciphers of the default Quarkus TLS configuration
+func newTLSProfileEnvForQuarkus(tls *TLSConfig) []corev1.EnvVar {
+	profile := getTLSProfile(tls)
+	envs := []corev1.EnvVar{
+		{
+			Name:  "QUARKUS_TLS_PROTOCOLS",
+			Value: strings.Join(profile.Versions(), ","),
+		},
+	}
+	if ciphers := profile.IANACiphers(); len(ciphers) > 0 {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "QUARKUS_TLS_CIPHER_SUITES",
+			Value: strings.Join(ciphers, ","),
+		})
+	}
+	return envs
+}
+
 func newDatabaseEnvForCoreContainer(cr *model.CryostatInstance, tls *TLSConfig) []corev1.EnvVar {
+	if !DeployManagedDatabase(cr) {
+		return newExternalDatabaseEnvForCoreContainer(cr.Spec.DatabaseOptions.External)
+	}
+
 	optional := false
 	secretName := getDatabaseSecret(cr)
 
 	envs := []corev1.EnvVar{
+		{
+			Name:  "QUARKUS_DATASOURCE_USERNAME",
+			Value: "cryostat",
+		},
 		{
 			Name: "QUARKUS_DATASOURCE_PASSWORD",
 			ValueFrom: &corev1.EnvVarSource{
@@ -1708,43 +1843,122 @@ func newDatabaseEnvForCoreContainer(cr *model.CryostatInstance, tls *TLSConfig)
 	return envs
 }
 
-func newStorageEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs) ([]corev1.EnvVar, error) {
+func newExternalDatabaseEnvForCoreContainer(external *operatorv1beta2.ExternalDatabaseOptions) []corev1.EnvVar {
 	optional := false
-	secretName := getStorageSecret(cr)
-	envs := []corev1.EnvVar{
+	port := constants.DatabasePort
+	if external.Port != nil {
+		port = *external.Port
+	}
+	database := DatabaseName
+	if external.Database != nil {
+		database = *external.Database
+	}
+
+	jdbcURL := &url.URL{
+		Scheme: "postgresql",
+		Host:   net.JoinHostPort(external.Host, strconv.Itoa(int(port))),
+		Path:   database,
+	}
+	query := url.Values{}
+	sslMode := external.SSLMode
+	if sslMode == nil && external.CACertificate != nil {
+		sslMode = &[]string{databaseSSLModeVerifyFull}[0]
+	}
+	if sslMode != nil {
+		query.Set("sslmode", *sslMode)
+	}
+	if external.CACertificate != nil {
+		query.Set("sslrootcert", path.Join(externalDatabaseCAPath, constants.CAKey))
+	}
+	jdbcURL.RawQuery = query.Encode()
+
+	return []corev1.EnvVar{
 		{
-			Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID",
+			Name: "QUARKUS_DATASOURCE_USERNAME",
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: secretName,
+						Name: external.CredentialsSecretName,
 					},
-					Key:      "ACCESS_KEY",
+					Key:      corev1.BasicAuthUsernameKey,
 					Optional: &optional,
 				},
 			},
 		},
 		{
-			Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_SECRET_ACCESS_KEY",
+			Name: "QUARKUS_DATASOURCE_PASSWORD",
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
-						Name: secretName,
+						Name: external.CredentialsSecretName,
 					},
-					Key:      "SECRET_KEY",
+					Key:      corev1.BasicAuthPasswordKey,
 					Optional: &optional,
 				},
 			},
 		},
 		{
-			Name:  "AWS_ACCESS_KEY_ID",
-			Value: "$(QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID)",
+			Name:  "QUARKUS_DATASOURCE_JDBC_URL",
+			Value: "jdbc:" + jdbcURL.String(),
 		},
-		{
-			Name:  "AWS_SECRET_ACCESS_KEY",
-			Value: "$(QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_SECRET_ACCESS_KEY)",
+	}
+}
+
+func hasExternalDatabaseCA(cr *model.CryostatInstance) bool {
+	return !DeployManagedDatabase(cr) && cr.Spec.DatabaseOptions.External.CACertificate != nil
+}
+
+// newExternalDatabaseCAVolume projects the external database's CA certificate into a volume
+// as "ca.crt", regardless of whether it is stored in a secret or a config map
+func newExternalDatabaseCAVolume(cert *operatorv1beta2.CertificateSecret, mode *int32) corev1.Volume {
+	source := corev1.VolumeProjection{}
+	if len(cert.SecretName) > 0 {
+		key := operatorv1beta2.DefaultCertificateKey
+		if cert.CertificateKey != nil {
+			key = *cert.CertificateKey
+		}
+		source.Secret = &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: cert.SecretName,
+			},
+			Items: []corev1.KeyToPath{
+				{
+					Key:  key,
+					Path: constants.CAKey,
+					Mode: mode,
+				},
+			},
+		}
+	} else {
+		key := operatorv1beta2.DefaultConfigMapCertificateKey
+		if cert.CertificateKey != nil {
+			key = *cert.CertificateKey
+		}
+		source.ConfigMap = &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: cert.ConfigMapName,
+			},
+			Items: []corev1.KeyToPath{
+				{
+					Key:  key,
+					Path: constants.CAKey,
+					Mode: mode,
+				},
+			},
+		}
+	}
+	return corev1.Volume{
+		Name: "external-database-ca",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{source},
+			},
 		},
 	}
+}
+
+func newStorageEnvForCoreContainer(cr *model.CryostatInstance, specs *ServiceSpecs) ([]corev1.EnvVar, error) {
+	envs := newObjectStorageCredentialsEnv(cr)
 
 	if DeployManagedStorage(cr) {
 		// default environment variable settings for managed/provisioned cryostat-storage instance
@@ -1990,11 +2204,11 @@ func newK8SDiscoveryEnvForCoreContainer(cr *model.CryostatInstance) []corev1.Env
 	return vars
 }
 
-func newGrafanaEnvForCoreContainer(specs *ServiceSpecs) []corev1.EnvVar {
+func newGrafanaEnvForCoreContainer(specs *ServiceSpecs, tls *TLSConfig) []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{
 			Name:  "GRAFANA_DATASOURCE_URL",
-			Value: datasourceURL,
+			Value: getDatasourceURL(tls),
 		},
 	}
 	if specs.AuthProxyURL != nil {
@@ -2005,7 +2219,7 @@ func newGrafanaEnvForCoreContainer(specs *ServiceSpecs) []corev1.EnvVar {
 			},
 			corev1.EnvVar{
 				Name:  "GRAFANA_DASHBOARD_URL",
-				Value: getInternalDashboardURL(),
+				Value: getInternalDashboardURL(tls),
 			},
 		)
 	}
@@ -2062,7 +2276,7 @@ func NewGrafanaContainer(cr *model.CryostatInstance, imageTag string, tls *TLSCo
 		},
 		{
 			Name:  "JFR_DATASOURCE_URL",
-			Value: datasourceURL,
+			Value: getDatasourceURL(tls),
 		},
 		{
 			Name:  "GF_SERVER_ROOT_URL",
@@ -2070,6 +2284,43 @@ func NewGrafanaContainer(cr *model.CryostatInstance, imageTag string, tls *TLSCo
 		},
 	}
 
+	mounts := []corev1.VolumeMount{}
+	probeScheme := corev1.URISchemeHTTP
+	if UseSidecarTLS(tls) {
+		envs = append(envs,
+			corev1.EnvVar{
+				Name:  "GF_SERVER_PROTOCOL",
+				Value: "https",
+			},
+			corev1.EnvVar{
+				Name:  "GF_SERVER_CERT_FILE",
+				Value: path.Join(SecretMountPrefix, tls.GrafanaSecret, corev1.TLSCertKey),
+			},
+			corev1.EnvVar{
+				Name:  "GF_SERVER_CERT_KEY",
+				Value: path.Join(SecretMountPrefix, tls.GrafanaSecret, corev1.TLSPrivateKeyKey),
+			},
+			// Trust the Cryostat CA when connecting to the JFR datasource
+			newCABundleCertDirEnv(tls),
+		)
+		// Grafana only supports TLS1.2 and TLS1.3 as minimum versions
+		if minVersion := getTLSProfile(tls).GoMinVersion(); minVersion >= cryptotls.VersionTLS12 {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "GF_SERVER_MIN_TLS_VERSION",
+				Value: strings.ReplaceAll(cryptotls.VersionName(minVersion), " ", ""),
+			})
+		}
+		mounts = append(mounts,
+			corev1.VolumeMount{
+				Name:      "grafana-tls-secret",
+				MountPath: path.Join(SecretMountPrefix, tls.GrafanaSecret),
+				ReadOnly:  true,
+			},
+			newCABundleVolumeMount(tls),
+		)
+		probeScheme = corev1.URISchemeHTTPS
+	}
+
 	var containerSc *corev1.SecurityContext
 	if cr.Spec.SecurityOptions != nil && cr.Spec.SecurityOptions.GrafanaSecurityContext != nil {
 		containerSc = cr.Spec.SecurityOptions.GrafanaSecurityContext
@@ -2092,13 +2343,14 @@ func NewGrafanaContainer(cr *model.CryostatInstance, imageTag string, tls *TLSCo
 				ContainerPort: constants.GrafanaContainerPort,
 			},
 		},
-		Env: envs,
+		Env:          envs,
+		VolumeMounts: mounts,
 		StartupProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
 					Port:   intstr.IntOrString{IntVal: 3000},
 					Path:   "/api/health",
-					Scheme: corev1.URISchemeHTTP,
+					Scheme: probeScheme,
 				},
 			},
 			FailureThreshold: 30,
@@ -2108,7 +2360,7 @@ func NewGrafanaContainer(cr *model.CryostatInstance, imageTag string, tls *TLSCo
 				HTTPGet: &corev1.HTTPGetAction{
 					Port:   intstr.IntOrString{IntVal: 3000},
 					Path:   "/api/health",
-					Scheme: corev1.URISchemeHTTP,
+					Scheme: probeScheme,
 				},
 			},
 		},
@@ -2191,6 +2443,19 @@ func NewStorageContainer(cr *model.CryostatInstance, imageTag string, tls *TLSCo
 			fmt.Sprintf("-s3.cert.file=%s", path.Join(SecretMountPrefix, tls.StorageSecret, corev1.TLSCertKey)),
 		)
 
+		// SeaweedFS reads its "tls" security settings from WEED_-prefixed environment variables
+		profile := getTLSProfile(tls)
+		envs = append(envs, corev1.EnvVar{
+			Name:  "WEED_TLS_MIN_VERSION",
+			Value: cryptotls.VersionName(profile.GoMinVersion()),
+		})
+		if ciphers := profile.GoCipherSuiteNames(); len(ciphers) > 0 {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "WEED_TLS_CIPHER_SUITES",
+				Value: strings.Join(ciphers, ","),
+			})
+		}
+
 		tlsSecretMount := corev1.VolumeMount{
 			Name:      "storage-tls-secret",
 			MountPath: path.Join(SecretMountPrefix, tls.StorageSecret),
@@ -2332,6 +2597,11 @@ func NewDatabaseContainer(cr *model.CryostatInstance, imageTag string, tls *TLSC
 			"-c", fmt.Sprintf("ssl_cert_file=%s", path.Join(tlsPath, corev1.TLSCertKey)),
 			"-c", fmt.Sprintf("ssl_key_file=%s", path.Join(tlsPath, corev1.TLSPrivateKeyKey)),
 		)
+		profile := getTLSProfile(tls)
+		args = append(args, "-c", fmt.Sprintf("ssl_min_protocol_version=%s", profile.MinVersion()))
+		if ciphers := profile.OpenSSLCiphers(); len(ciphers) > 0 {
+			args = append(args, "-c", fmt.Sprintf("ssl_ciphers=%s", strings.Join(ciphers, ":")))
+		}
 	}
 
 	return corev1.Container{
@@ -2358,8 +2628,14 @@ func NewDatabaseContainer(cr *model.CryostatInstance, imageTag string, tls *TLSC
 	}
 }
 
-// datasourceURL contains the fixed URL to jfr-datasource's web server
-var datasourceURL = "http://" + constants.LoopbackAddress + ":" + strconv.Itoa(int(constants.DatasourceContainerPort))
+// getDatasourceURL returns the fixed URL to jfr-datasource's web server
+func getDatasourceURL(tls *TLSConfig) string {
+	scheme := "http"
+	if UseSidecarTLS(tls) {
+		scheme = "https"
+	}
+	return scheme + "://" + constants.LoopbackAddress + ":" + strconv.Itoa(int(constants.DatasourceContainerPort))
+}
 
 func NewJfrDatasourceContainerResource(cr *model.CryostatInstance) *corev1.ResourceRequirements {
 	resources := &corev1.ResourceRequirements{}
@@ -2390,13 +2666,60 @@ func NewJfrDatasourceContainer(cr *model.CryostatInstance, imageTag string, serv
 			Name:  "QUARKUS_HTTP_HOST",
 			Value: constants.LoopbackAddress,
 		},
-		{
-			Name:  "QUARKUS_HTTP_PORT",
-			Value: strconv.Itoa(int(constants.DatasourceContainerPort)),
-		},
 	}
 
 	mounts := []corev1.VolumeMount{}
+	livenessCommand := []string{"curl", "--fail"}
+	if UseSidecarTLS(tls) {
+		tlsPath := path.Join(SecretMountPrefix, tls.DatasourceSecret)
+		envs = append(envs,
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_SSL_PORT",
+				Value: strconv.Itoa(int(constants.DatasourceContainerPort)),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_INSECURE_REQUESTS",
+				Value: "disabled",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_RELOAD_PERIOD",
+				Value: "1h",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_KEY_STORE_PEM_0_CERT",
+				Value: path.Join(tlsPath, corev1.TLSCertKey),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_KEY_STORE_PEM_0_KEY",
+				Value: path.Join(tlsPath, corev1.TLSPrivateKeyKey),
+			},
+			// Verify client certificates against the Cryostat CA when they are presented. Client
+			// certificates are not required, since Grafana's datasource does not present one.
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_SSL_CLIENT_AUTH",
+				Value: "request",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_TRUST_STORE_PEM_CERTS",
+				Value: path.Join(SecretMountPrefix, tls.CABundleSecret, constants.CAKey),
+			},
+		)
+		envs = append(envs, newTLSProfileEnvForQuarkus(tls)...)
+		mounts = append(mounts,
+			corev1.VolumeMount{
+				Name:      "jfr-datasource-tls-secret",
+				MountPath: tlsPath,
+				ReadOnly:  true,
+			},
+			newCABundleVolumeMount(tls),
+		)
+		livenessCommand = append(livenessCommand, "--cacert", path.Join(tlsPath, constants.CAKey))
+	} else {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "QUARKUS_HTTP_PORT",
+			Value: strconv.Itoa(int(constants.DatasourceContainerPort)),
+		})
+	}
 	if tls != nil {
 		tlsPath := path.Join(SecretMountPrefix, tls.StorageSecret)
 		tlsSecretMount := corev1.VolumeMount{
@@ -2439,7 +2762,7 @@ func NewJfrDatasourceContainer(cr *model.CryostatInstance, imageTag string, serv
 		LivenessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				Exec: &corev1.ExecAction{
-					Command: []string{"curl", "--fail", datasourceURL},
+					Command: append(livenessCommand, getDatasourceURL(tls)),
 				},
 			},
 		},
@@ -2523,8 +2846,33 @@ func newAgentProxyContainerResource(cr *model.CryostatInstance) *corev1.Resource
 	return resources
 }
 
-func getInternalDashboardURL() string {
-	return fmt.Sprintf("http://localhost:%d", constants.GrafanaContainerPort)
+func getInternalDashboardURL(tls *TLSConfig) string {
+	scheme := "http"
+	if UseSidecarTLS(tls) {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://localhost:%d", scheme, constants.GrafanaContainerPort)
+}
+
+// UseSidecarTLS returns whether the Grafana and JFR datasource containers serve HTTPS
+func UseSidecarTLS(tls *TLSConfig) bool {
+	return tls != nil && len(tls.GrafanaSecret) > 0 && len(tls.DatasourceSecret) > 0
+}
+
+func newCABundleVolumeMount(tls *TLSConfig) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "ca-bundle",
+		MountPath: path.Join(SecretMountPrefix, tls.CABundleSecret),
+		ReadOnly:  true,
+	}
+}
+
+// newCABundleCertDirEnv has Go programs trust the Cryostat CA, along with the system's CA certificates
+func newCABundleCertDirEnv(tls *TLSConfig) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name:  "SSL_CERT_DIR",
+		Value: path.Join(SecretMountPrefix, tls.CABundleSecret),
+	}
 }
 
 func newVolumeForDatabase(cr *model.CryostatInstance) []corev1.Volume {
@@ -2554,7 +2902,7 @@ func newVolumeForDatabase(cr *model.CryostatInstance) []corev1.Volume {
 	} else {
 		volumeSource = corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: cr.Name + "-database",
+				ClaimName: GetVolumeClaimName(cr, VolumeComponentDatabase),
 			},
 		}
 	}
@@ -2594,7 +2942,7 @@ func newVolumeForStorage(cr *model.CryostatInstance) []corev1.Volume {
 	} else {
 		volumeSource = corev1.VolumeSource{
 			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-				ClaimName: cr.Name + "-storage",
+				ClaimName: GetVolumeClaimName(cr, VolumeComponentStorage),
 			},
 		}
 	}
@@ -2618,6 +2966,120 @@ func getDatabaseSecret(cr *model.CryostatInstance) string {
 	return cr.Name + "-db"
 }
 
+// newObjectStorageCredentialsEnv returns the environment variables the S3 client uses to
+// authenticate with object storage, depending on the credentials mode
+func newObjectStorageCredentialsEnv(cr *model.CryostatInstance) []corev1.EnvVar {
+	switch GetObjectStorageCredentialsMode(cr) {
+	case operatorv1beta2.ObjectStorageCredentialsWebIdentity:
+		// Read by the AWS SDK's web identity token provider in the default credentials chain
+		return []corev1.EnvVar{
+			{
+				Name:  "AWS_ROLE_ARN",
+				Value: cr.Spec.ObjectStorageOptions.Credentials.WebIdentity.RoleARN,
+			},
+			{
+				Name:  "AWS_WEB_IDENTITY_TOKEN_FILE",
+				Value: path.Join(webIdentityTokenPath, webIdentityTokenFile),
+			},
+			{
+				Name:  "AWS_ROLE_SESSION_NAME",
+				Value: cr.Name,
+			},
+		}
+	case operatorv1beta2.ObjectStorageCredentialsDefaultChain:
+		return []corev1.EnvVar{}
+	}
+
+	optional := false
+	secretName := getStorageSecret(cr)
+	return []corev1.EnvVar{
+		{
+			Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      "ACCESS_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      "SECRET_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name:  "AWS_ACCESS_KEY_ID",
+			Value: "$(QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID)",
+		},
+		{
+			Name:  "AWS_SECRET_ACCESS_KEY",
+			Value: "$(QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_SECRET_ACCESS_KEY)",
+		},
+	}
+}
+
+// GetObjectStorageCredentialsMode returns how Cryostat authenticates with object storage.
+// The storage deployed by the operator always uses static credentials.
+func GetObjectStorageCredentialsMode(cr *model.CryostatInstance) operatorv1beta2.ObjectStorageCredentialsMode {
+	if DeployManagedStorage(cr) || cr.Spec.ObjectStorageOptions.Credentials == nil {
+		return operatorv1beta2.ObjectStorageCredentialsStatic
+	}
+	return cr.Spec.ObjectStorageOptions.Credentials.Mode
+}
+
+func getObjectStorageCredentialsType(cr *model.CryostatInstance) string {
+	if GetObjectStorageCredentialsMode(cr) == operatorv1beta2.ObjectStorageCredentialsStatic {
+		return "static"
+	}
+	// The default chain includes the web identity token provider
+	return "default"
+}
+
+func usesWebIdentityCredentials(cr *model.CryostatInstance) bool {
+	return GetObjectStorageCredentialsMode(cr) == operatorv1beta2.ObjectStorageCredentialsWebIdentity
+}
+
+// newWebIdentityTokenVolume projects a service account token for the object storage provider's
+// security token service. The kubelet refreshes the token before it expires.
+func newWebIdentityTokenVolume(cr *model.CryostatInstance, mode *int32) corev1.Volume {
+	webIdentity := cr.Spec.ObjectStorageOptions.Credentials.WebIdentity
+	audience := defaultWebIdentityAudience
+	if webIdentity.Audience != nil {
+		audience = *webIdentity.Audience
+	}
+	expirationSeconds := defaultWebIdentityExpirationSeconds
+	if webIdentity.ExpirationSeconds != nil {
+		expirationSeconds = *webIdentity.ExpirationSeconds
+	}
+	return corev1.Volume{
+		Name: "object-storage-token",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: &expirationSeconds,
+							Path:              webIdentityTokenFile,
+						},
+					},
+				},
+				DefaultMode: mode,
+			},
+		},
+	}
+}
+
 func getStorageSecret(cr *model.CryostatInstance) string {
 	if cr.Spec.ObjectStorageOptions != nil && cr.Spec.ObjectStorageOptions.SecretName != nil {
 		return *cr.Spec.ObjectStorageOptions.SecretName
@@ -0,0 +1,447 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_definitions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	common "github.com/cryostatio/cryostat-operator/internal/controller/common"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StorageLifecycleRulesAnnotation records the age limits that a lifecycle Job applies
+	StorageLifecycleRulesAnnotation = "operator.cryostat.io/lifecycle-rules"
+	// StorageLifecycleInstanceAnnotation identifies the running object storage that a lifecycle Job configures
+	StorageLifecycleInstanceAnnotation = "operator.cryostat.io/storage-instance"
+	defaultStoragePruneSchedule        = "0 * * * *"
+	// Default bucket names used by Cryostat and the object storage deployed by the operator
+	defaultArchivedRecordingsBucket = "archivedrecordings"
+	defaultArchivedReportsBucket    = "archivedreports"
+	defaultHeapDumpsBucket          = "heapdumps"
+	defaultThreadDumpsBucket        = "threaddumps"
+)
+
+// Adds or updates the operator's expiration rule in the lifecycle configuration of each bucket with an
+// age limit, keeping any other rules. Buckets without an age limit only have the operator's rule removed,
+// if present. The rule is read back, since some servers accept but ignore it. The script exits with
+// nonRetryableExitCode if the object storage does not support lifecycle configuration.
+const storageLifecycleScript = `set -euo pipefail
+response="$(mktemp)"
+# Sends a request, saving the response body, and prints the HTTP status code, or 000 if there is none
+request() {
+  : > "${response}"
+  s3_request --output "${response}" --write-out '%{http_code}' "$@" || true
+}
+# Returns whether the response shows that lifecycle configuration is not implemented
+unsupported() {
+  [ "$1" = "405" ] || [ "$1" = "501" ] || grep -q '<Code>NotImplemented</Code>' "${response}"
+}
+# Fails unless the request succeeded
+check() {
+  if unsupported "$1"; then
+    echo "Object storage does not support lifecycle configuration: HTTP $1" >&2
+    exit 3
+  fi
+  if [[ "$1" != 2* ]]; then
+    echo "Request for the lifecycle configuration of $2 failed: HTTP $1 $(cat "${response}")" >&2
+    exit 1
+  fi
+}
+# Prints the lifecycle rules in the response, one per line
+rules() {
+  tr -d '\n' < "${response}" | sed -e 's#<Rule>#\n&#g' -e 's#</Rule>#&\n#g' | { grep '^<Rule>' || true; }
+}
+# Replaces the lifecycle configuration of a bucket with the given rules
+put() {
+  local body="<LifecycleConfiguration>$(printf '%s' "$2" | tr -d '\n')</LifecycleConfiguration>" md5
+  md5="$(printf '%s' "${body}" | md5sum | cut -c1-32 | sed 's/../\\x&/g')"
+  request --request PUT --header "Content-MD5: $(printf "${md5}" | base64)" --header "Content-Type: application/xml" \
+    --data-binary "${body}" "$1?lifecycle"
+}
+while read -r bucket days; do
+  [ -n "${bucket}" ] || continue
+  if [ "${days}" != "0" ]; then
+    s3 --request PUT "${bucket}" >/dev/null 2>&1 || true
+  fi
+  code="$(request "${bucket}?lifecycle")"
+  if [ "${days}" = "0" ] && unsupported "${code}"; then
+    continue
+  elif [ "${code}" = "404" ]; then
+    existing=""
+  else
+    check "${code}" "${bucket}"
+    existing="$(rules)"
+  fi
+  others="$(printf '%s\n' "${existing}" | { grep -v "<ID>cryostat-retention</ID>" || true; })"
+  if [ "${days}" = "0" ]; then
+    # Leave buckets that the operator has not configured alone
+    [ "${others}" != "${existing}" ] || continue
+    if [ -n "${others}" ]; then
+      code="$(put "${bucket}" "${others}")"
+    else
+      code="$(request --request DELETE "${bucket}?lifecycle")"
+    fi
+    check "${code}" "${bucket}"
+    echo "Removed lifecycle rule from ${bucket}"
+    continue
+  fi
+  rule="<Rule><ID>cryostat-retention</ID><Filter><Prefix></Prefix></Filter><Status>Enabled</Status><Expiration><Days>${days}</Days></Expiration></Rule>"
+  check "$(put "${bucket}" "${others}${rule}")" "${bucket}"
+  check "$(request "${bucket}?lifecycle")" "${bucket}"
+  if ! rules | grep '<ID>cryostat-retention</ID>' | grep "<Days>${days}</Days>" >/dev/null; then
+    echo "Object storage ignored the lifecycle configuration of ${bucket}" >&2
+    exit 3
+  fi
+  echo "Applied lifecycle rule to ${bucket}"
+done <<< "${LIFECYCLE_RULES}"
+`
+
+// Deletes objects older than the age limit, then the oldest objects exceeding the size limit
+const storagePruneScript = `set -euo pipefail
+urlencode() {
+  local LC_ALL=C s="$1" i c out=""
+  for ((i = 0; i < ${#s}; i++)); do
+    c="${s:i:1}"
+    case "${c}" in
+      [a-zA-Z0-9.~_-]) out+="${c}" ;;
+      *) out+="$(printf '%%%02X' "'${c}")" ;;
+    esac
+  done
+  printf '%s' "${out}"
+}
+# Prints "<last modified> <size> <key>" for each object in a bucket
+list() {
+  local token="" query page
+  while :; do
+    query="list-type=2"
+    if [ -n "${token}" ]; then
+      query="${query}&continuation-token=$(urlencode "${token}")"
+    fi
+    page="$(s3 "$1?${query}" | tr -d '\n')" || return 1
+    printf '%s\n' "${page}" | sed 's#<Contents>#\n#g' |
+      sed -n 's#.*<Key>\([^<]*\)</Key>.*<LastModified>\([^<]*\)</LastModified>.*<Size>\([0-9]*\)</Size>.*#\2 \3 \1#p' |
+      sed -e 's/&lt;/</g' -e 's/&gt;/>/g' -e 's/&quot;/"/g' -e "s/&apos;/'/g" -e 's/&amp;/\&/g'
+    token="$(printf '%s' "${page}" | sed -n 's#.*<NextContinuationToken>\([^<]*\)</NextContinuationToken>.*#\1#p')"
+    [ -n "${token}" ] || break
+  done
+}
+delete() {
+  s3 --request DELETE "$1/$(urlencode "$2" | sed 's#%2F#/#g')" >/dev/null
+  echo "Deleted ${2} from ${1}: ${3}"
+}
+status=0
+while read -r bucket days bytes; do
+  [ -n "${bucket}" ] || continue
+  if ! objects="$(list "${bucket}" | sort -r)"; then
+    echo "Could not list the objects in ${bucket}"
+    status=1
+    continue
+  fi
+  cutoff=""
+  if [ "${days}" != "0" ]; then
+    cutoff="$(date -u -d "-${days} days" +%Y-%m-%dT%H:%M:%S)"
+  fi
+  total=0
+  while read -r modified size key; do
+    [ -n "${key}" ] || continue
+    if [ -n "${cutoff}" ] && [[ "${modified}" < "${cutoff}" ]]; then
+      delete "${bucket}" "${key}" "older than ${days} days"
+      continue
+    fi
+    total=$((total + size))
+    if [ "${bytes}" != "0" ] && [ "${total}" -gt "${bytes}" ]; then
+      delete "${bucket}" "${key}" "bucket exceeds ${bytes} bytes"
+    fi
+  done <<< "${objects}"
+done <<< "${PRUNE_RULES}"
+exit "${status}"
+`
+
+// BucketRetention is the retention rule for a bucket used by Cryostat
+type BucketRetention struct {
+	// Name of the bucket
+	Bucket string
+	// Retention rule for the bucket, or nil if objects are kept forever
+	Rule *operatorv1beta2.BucketRetentionRule
+}
+
+// StorageRetentionLabels returns the labels of pods that apply retention policies to object storage
+func StorageRetentionLabels(cr *model.CryostatInstance) map[string]string {
+	return map[string]string{
+		"app":       cr.Name,
+		"kind":      "cryostat",
+		"component": "storage-retention",
+	}
+}
+
+// StorageRetentionEnabled returns whether any retention rules are configured for object storage
+func StorageRetentionEnabled(cr *model.CryostatInstance) bool {
+	return len(GetStorageRetention(cr)) > 0
+}
+
+// GetStorageRetention returns the buckets that have retention rules
+func GetStorageRetention(cr *model.CryostatInstance) []BucketRetention {
+	result := []BucketRetention{}
+	for _, retention := range getBucketRetention(cr) {
+		if retention.Rule != nil {
+			result = append(result, retention)
+		}
+	}
+	return result
+}
+
+// StorageLifecycleRules returns the age limits to apply as lifecycle configuration,
+// in the form "bucket=days,...", or an empty string if there are none
+func StorageLifecycleRules(cr *model.CryostatInstance) string {
+	rules := []string{}
+	for _, retention := range GetStorageRetention(cr) {
+		if retention.Rule.MaxAgeDays != nil {
+			rules = append(rules, fmt.Sprintf("%s=%d", retention.Bucket, *retention.Rule.MaxAgeDays))
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// StoragePruningEnabled returns whether any retention rules must be enforced by pruning the buckets,
+// given whether the age limits are applied as lifecycle configuration
+func StoragePruningEnabled(cr *model.CryostatInstance, lifecycleApplied bool) bool {
+	return len(getStoragePruneRules(cr, lifecycleApplied)) > 0
+}
+
+// getBucketRetention returns the retention rules for each bucket type, in a fixed order
+func getBucketRetention(cr *model.CryostatInstance) []BucketRetention {
+	var retention operatorv1beta2.ObjectStorageRetention
+	if cr.Spec.ObjectStorageOptions != nil && cr.Spec.ObjectStorageOptions.Retention != nil {
+		retention = *cr.Spec.ObjectStorageOptions.Retention
+	}
+	// Cryostat only uses custom bucket names with external object storage
+	var names operatorv1beta2.StorageBucketNameOptions
+	if !DeployManagedStorage(cr) && cr.Spec.ObjectStorageOptions.StorageBucketNameOptions != nil {
+		names = *cr.Spec.ObjectStorageOptions.StorageBucketNameOptions
+	}
+	bucketName := func(name *string, defaultName string) string {
+		if name != nil {
+			return *name
+		}
+		return defaultName
+	}
+	return []BucketRetention{
+		{
+			Bucket: bucketName(names.ArchivedRecordings, defaultArchivedRecordingsBucket),
+			Rule:   retention.ArchivedRecordings,
+		},
+		{
+			Bucket: bucketName(names.ArchivedReports, defaultArchivedReportsBucket),
+			Rule:   retention.ArchivedReports,
+		},
+		{
+			Bucket: bucketName(names.HeapDumps, defaultHeapDumpsBucket),
+			Rule:   retention.HeapDumps,
+		},
+		{
+			Bucket: bucketName(names.ThreadDumps, defaultThreadDumpsBucket),
+			Rule:   retention.ThreadDumps,
+		},
+	}
+}
+
+// getStoragePruneRules returns the retention rules enforced by pruning, omitting age limits
+// that are applied as lifecycle configuration
+func getStoragePruneRules(cr *model.CryostatInstance, lifecycleApplied bool) []BucketRetention {
+	result := []BucketRetention{}
+	for _, retention := range GetStorageRetention(cr) {
+		rule := retention.Rule.DeepCopy()
+		if lifecycleApplied {
+			rule.MaxAgeDays = nil
+		}
+		if rule.MaxAgeDays != nil || rule.MaxTotalSize != nil {
+			result = append(result, BucketRetention{Bucket: retention.Bucket, Rule: rule})
+		}
+	}
+	return result
+}
+
+// NewJobForStorageLifecycle returns a Job that applies the age limits as lifecycle configuration
+// of the buckets, and removes the operator's lifecycle rule from buckets without an age limit.
+// The storage instance identifies the running object storage deployed by the operator, if any.
+func NewJobForStorageLifecycle(cr *model.CryostatInstance, imageTags *ImageTags, tls *TLSConfig,
+	specs *ServiceSpecs, openshift bool, fsGroup int64, storageInstance string) (*batchv1.Job, error) {
+	client, err := newObjectStorageClient(cr, tls, specs, "object storage retention policies")
+	if err != nil {
+		return nil, err
+	}
+	rules := []string{}
+	for _, retention := range getBucketRetention(cr) {
+		days := int32(0)
+		if retention.Rule != nil && retention.Rule.MaxAgeDays != nil {
+			days = *retention.Rule.MaxAgeDays
+		}
+		rules = append(rules, fmt.Sprintf("%s %d", client.bucketURL(retention.Bucket), days))
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "LIFECYCLE_RULES",
+			Value: strings.Join(rules, "\n"),
+		},
+	}
+	podSpec := newPodForStorageClient(cr, imageTags, openshift, fsGroup, client, storageLifecycleScript, envs)
+
+	labels := StorageRetentionLabels(cr)
+	// Retry errors such as the object storage still starting up, but fail right away
+	// if the object storage doesn't support lifecycle configuration
+	backoffLimit := int32(6)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-storage-lifecycle",
+			Namespace: cr.InstallNamespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				StorageLifecycleRulesAnnotation:    StorageLifecycleRules(cr),
+				StorageLifecycleInstanceAnnotation: storageInstance,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:     &backoffLimit,
+			PodFailurePolicy: newNonRetryablePodFailurePolicy(podSpec.Containers[0].Name),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+// NewCronJobForStoragePruning returns a CronJob that periodically enforces the retention rules
+// not covered by lifecycle configuration
+func NewCronJobForStoragePruning(cr *model.CryostatInstance, imageTags *ImageTags, tls *TLSConfig,
+	specs *ServiceSpecs, openshift bool, fsGroup int64, lifecycleApplied bool) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-storage-retention",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	pruneRules := getStoragePruneRules(cr, lifecycleApplied)
+	if len(pruneRules) == 0 {
+		return cronJob, nil
+	}
+	client, err := newObjectStorageClient(cr, tls, specs, "object storage retention policies")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []string{}
+	for _, retention := range pruneRules {
+		days := int32(0)
+		if retention.Rule.MaxAgeDays != nil {
+			days = *retention.Rule.MaxAgeDays
+		}
+		bytes := int64(0)
+		if retention.Rule.MaxTotalSize != nil {
+			bytes = retention.Rule.MaxTotalSize.Value()
+		}
+		rules = append(rules, fmt.Sprintf("%s %d %s", client.bucketURL(retention.Bucket), days,
+			strconv.FormatInt(bytes, 10)))
+	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "PRUNE_RULES",
+			Value: strings.Join(rules, "\n"),
+		},
+	}
+	podSpec := newPodForStorageClient(cr, imageTags, openshift, fsGroup, client, storagePruneScript, envs)
+
+	schedule := defaultStoragePruneSchedule
+	if cr.Spec.ObjectStorageOptions.Retention.PruneSchedule != nil {
+		schedule = *cr.Spec.ObjectStorageOptions.Retention.PruneSchedule
+	}
+	labels := StorageRetentionLabels(cr)
+	backoffLimit := int32(2)
+	cronJob.Labels = labels
+	cronJob.Spec = batchv1.CronJobSpec{
+		Schedule:          schedule,
+		ConcurrencyPolicy: batchv1.ForbidConcurrent,
+		JobTemplate: batchv1.JobTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: batchv1.JobSpec{
+				BackoffLimit: &backoffLimit,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: labels,
+					},
+					Spec: *podSpec,
+				},
+			},
+		},
+	}
+	return cronJob, nil
+}
+
+// newPodForStorageClient returns a pod that runs a script sending signed requests to object storage,
+// using curl from the database image
+func newPodForStorageClient(cr *model.CryostatInstance, imageTags *ImageTags, openshift bool, fsGroup int64,
+	client *objectStorageClient, script string, extraEnvs []corev1.EnvVar) *corev1.PodSpec {
+	envs := append(extraEnvs, client.envs...)
+
+	var containerSc *corev1.SecurityContext
+	if cr.Spec.SecurityOptions != nil && cr.Spec.SecurityOptions.StorageSecurityContext != nil {
+		containerSc = cr.Spec.SecurityOptions.StorageSecurityContext
+	} else {
+		privEscalation := false
+		containerSc = &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &privEscalation,
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{constants.CapabilityAll},
+			},
+		}
+	}
+
+	// Use the same pod configuration as the object storage
+	podSpec := NewPodForStorage(cr, imageTags, nil, openshift, fsGroup)
+	automountSAToken := false
+	return &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:            cr.Name + "-storage-client",
+				Image:           imageTags.DatabaseImageTag,
+				ImagePullPolicy: common.GetPullPolicy(imageTags.DatabaseImageTag),
+				Command:         []string{"/bin/bash", "-c", objectStorageS3Function + script},
+				Env:             envs,
+				VolumeMounts:    client.mounts,
+				SecurityContext: containerSc,
+				Resources:       *NewStorageContainerResource(cr),
+			},
+		},
+		RestartPolicy:                corev1.RestartPolicyNever,
+		AutomountServiceAccountToken: &automountSAToken,
+		NodeSelector:                 podSpec.NodeSelector,
+		Affinity:                     podSpec.Affinity,
+		Tolerations:                  podSpec.Tolerations,
+		SecurityContext:              podSpec.SecurityContext,
+		Volumes:                      client.volumes,
+	}
+}
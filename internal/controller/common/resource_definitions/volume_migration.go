@@ -0,0 +1,286 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_definitions
+
+import (
+	"fmt"
+	"path"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	VolumeComponentDatabase = "database"
+	VolumeComponentStorage  = "storage"
+	// VolumeMigrationSourceAnnotation records the PersistentVolumeClaim that a migration Job copies from
+	VolumeMigrationSourceAnnotation = "operator.cryostat.io/migration-source"
+	// VolumeMigrationTargetAnnotation records the PersistentVolumeClaim that a migration Job copies to
+	VolumeMigrationTargetAnnotation = "operator.cryostat.io/migration-target"
+	volumeMigrationSourcePath       = "/var/lib/cryostat/migration/source"
+	volumeMigrationTargetPath       = "/var/lib/cryostat/migration/target"
+)
+
+// Replaces the contents of the target volume with those of the source volume.
+// The lost+found directory of ext filesystems is owned by root, so it is skipped.
+const volumeCopyScript = `set -eu
+for entry in "${TARGET_DIR}"/* "${TARGET_DIR}"/.[!.]* "${TARGET_DIR}"/..?*; do
+  if { [ -e "${entry}" ] || [ -L "${entry}" ]; } && [ "${entry##*/}" != "lost+found" ]; then
+    rm -rf "${entry}"
+  fi
+done
+for entry in "${SOURCE_DIR}"/* "${SOURCE_DIR}"/.[!.]* "${SOURCE_DIR}"/..?*; do
+  if { [ -e "${entry}" ] || [ -L "${entry}" ]; } && [ "${entry##*/}" != "lost+found" ]; then
+    cp -a "${entry}" "${TARGET_DIR}/"
+  fi
+done
+echo "Copied ${SOURCE_DIR} to ${TARGET_DIR}"
+`
+
+// Copies the running database into a temporary database server on the new volume. The server
+// is initialized by the database image, as it is in the database container. The PostgreSQL client
+// environment variables are set per command, so that they do not affect the image's scripts.
+const databaseCopyScript = `set -euo pipefail
+for entry in /var/lib/pgsql/* /var/lib/pgsql/.[!.]* /var/lib/pgsql/..?*; do
+  if { [ -e "${entry}" ] || [ -L "${entry}" ]; } && [ "${entry##*/}" != "lost+found" ]; then
+    rm -rf "${entry}"
+  fi
+done
+run-postgresql -c listen_addresses=127.0.0.1 &
+server=$!
+trap 'pg_ctl stop --pgdata="${PGDATA}" --mode=fast || true' EXIT
+until pg_isready --host=127.0.0.1 --timeout=5 >/dev/null 2>&1; do
+  if ! kill -0 "${server}" 2>/dev/null; then
+    echo "The temporary database server exited"
+    exit 1
+  fi
+  sleep 1
+done
+PGPASSWORD="${POSTGRESQL_PASSWORD}" PGSSLMODE="${SOURCE_SSLMODE}" PGSSLROOTCERT="${SOURCE_SSLROOTCERT:-}" \
+  pg_dump --host="${SOURCE_HOST}" --username="${POSTGRESQL_USER}" --format=custom "${POSTGRESQL_DATABASE}" |
+  PGPASSWORD="${POSTGRESQL_PASSWORD}" PGSSLMODE=disable \
+  pg_restore --host=127.0.0.1 --username="${POSTGRESQL_USER}" --dbname="${POSTGRESQL_DATABASE}" \
+    --no-owner --no-privileges --no-comments --single-transaction --exit-on-error
+echo "Copied the database to the new volume"
+`
+
+// GetVolumeClaimName returns the name of the PersistentVolumeClaim used by a component,
+// which may have changed from the default if the component's data was migrated
+func GetVolumeClaimName(cr *model.CryostatInstance, component string) string {
+	if status := GetVolumeStatus(cr, component); status != nil && len(status.ClaimName) > 0 {
+		return status.ClaimName
+	}
+	return fmt.Sprintf("%s-%s", cr.Name, component)
+}
+
+// GetVolumeStatus returns the status of the volume used by a component, if any
+func GetVolumeStatus(cr *model.CryostatInstance, component string) *operatorv1beta2.VolumeStatus {
+	if cr.Status == nil {
+		return nil
+	}
+	for i := range cr.Status.Volumes {
+		if cr.Status.Volumes[i].Component == component {
+			return &cr.Status.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// VolumeMigrationInProgress returns whether a component's data is being copied to a new volume
+func VolumeMigrationInProgress(cr *model.CryostatInstance, component string) bool {
+	status := GetVolumeStatus(cr, component)
+	return status != nil && len(status.MigrationTarget) > 0
+}
+
+// VolumeMigrationLabels returns the labels of pods that migrate a component's data to a new volume
+func VolumeMigrationLabels(cr *model.CryostatInstance, component string) map[string]string {
+	return map[string]string{
+		"app":       cr.Name,
+		"kind":      "cryostat",
+		"component": component + "-migration",
+	}
+}
+
+// NewJobForVolumeMigration returns a Job that copies a component's data from the source
+// PersistentVolumeClaim to the target. The component must be scaled down while the Job runs.
+// Without a source claim, the component is using an emptyDir volume. In that case, only the
+// database can be copied, by dumping it from the running database while Cryostat is scaled down.
+func NewJobForVolumeMigration(cr *model.CryostatInstance, component string, imageTags *ImageTags, tls *TLSConfig,
+	openshift bool, fsGroup int64, source string, target string) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-migration", cr.Name, component),
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	if len(target) == 0 {
+		return job
+	}
+
+	var podSpec *corev1.PodSpec
+	var container corev1.Container
+	if component == VolumeComponentDatabase {
+		podSpec = NewPodForDatabase(cr, imageTags, tls, openshift, fsGroup)
+		container = NewDatabaseContainer(cr, imageTags.DatabaseImageTag, tls)
+	} else {
+		podSpec = NewPodForStorage(cr, imageTags, tls, openshift, fsGroup)
+		container = NewStorageContainer(cr, imageTags.StorageImageTag, tls)
+	}
+
+	targetVolume := corev1.Volume{
+		Name: "target",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: target,
+			},
+		},
+	}
+	var command []string
+	var envs []corev1.EnvVar
+	var mounts []corev1.VolumeMount
+	var volumes []corev1.Volume
+	if len(source) > 0 {
+		command = []string{"/bin/sh", "-c", volumeCopyScript}
+		envs = []corev1.EnvVar{
+			{
+				Name:  "SOURCE_DIR",
+				Value: volumeMigrationSourcePath,
+			},
+			{
+				Name:  "TARGET_DIR",
+				Value: volumeMigrationTargetPath,
+			},
+		}
+		mounts = []corev1.VolumeMount{
+			{
+				Name:      "source",
+				MountPath: volumeMigrationSourcePath,
+				ReadOnly:  true,
+			},
+			{
+				Name:      "target",
+				MountPath: volumeMigrationTargetPath,
+			},
+		}
+		volumes = []corev1.Volume{
+			{
+				Name: "source",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: source,
+						ReadOnly:  true,
+					},
+				},
+			},
+			targetVolume,
+		}
+	} else {
+		// Run the temporary server with the database container's environment and data directory
+		command = []string{"/bin/bash", "-c", databaseCopyScript}
+		envs = append(container.Env, corev1.EnvVar{
+			Name:  "SOURCE_HOST",
+			Value: fmt.Sprintf("%s-database.%s.svc", cr.Name, cr.InstallNamespace),
+		})
+		mounts = []corev1.VolumeMount{
+			{
+				Name:      "target",
+				MountPath: "/var/lib/pgsql",
+			},
+		}
+		volumes = []corev1.Volume{targetVolume}
+		if tls != nil {
+			tlsPath := path.Join(SecretMountPrefix, tls.DatabaseSecret)
+			envs = append(envs,
+				corev1.EnvVar{
+					Name:  "SOURCE_SSLMODE",
+					Value: databaseSSLModeVerifyFull,
+				},
+				corev1.EnvVar{
+					Name:  "SOURCE_SSLROOTCERT",
+					Value: path.Join(tlsPath, constants.CAKey),
+				},
+			)
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      "database-tls-secret",
+				MountPath: tlsPath,
+				ReadOnly:  true,
+			})
+			readOnlyMode := int32(0440)
+			volumes = append(volumes, corev1.Volume{
+				Name: "database-tls-secret",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: tls.DatabaseSecret,
+						Items: []corev1.KeyToPath{
+							{
+								Key:  constants.CAKey,
+								Path: constants.CAKey,
+								Mode: &readOnlyMode,
+							},
+						},
+					},
+				},
+			})
+		} else {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "SOURCE_SSLMODE",
+				Value: "disable",
+			})
+		}
+	}
+
+	labels := VolumeMigrationLabels(cr, component)
+	backoffLimit := int32(2)
+	automountSAToken := false
+	job.Labels = labels
+	job.Annotations = map[string]string{
+		VolumeMigrationSourceAnnotation: source,
+		VolumeMigrationTargetAnnotation: target,
+	}
+	job.Spec = batchv1.JobSpec{
+		BackoffLimit: &backoffLimit,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:            fmt.Sprintf("%s-%s-migration", cr.Name, component),
+						Image:           container.Image,
+						ImagePullPolicy: container.ImagePullPolicy,
+						Command:         command,
+						Env:             envs,
+						VolumeMounts:    mounts,
+						SecurityContext: container.SecurityContext,
+						Resources:       container.Resources,
+					},
+				},
+				RestartPolicy:                corev1.RestartPolicyNever,
+				AutomountServiceAccountToken: &automountSAToken,
+				NodeSelector:                 podSpec.NodeSelector,
+				Affinity:                     podSpec.Affinity,
+				Tolerations:                  podSpec.Tolerations,
+				SecurityContext:              podSpec.SecurityContext,
+				Volumes:                      volumes,
+			},
+		},
+	}
+	return job
+}
@@ -22,6 +22,8 @@ import (
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	"github.com/cryostatio/cryostat-operator/internal/tlsprofile"
+	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -31,6 +33,7 @@ import (
 // TLS-related functionality
 type ReconcilerTLS interface {
 	IsCertManagerEnabled(cr *model.CryostatInstance) bool
+	IsTLSEnabled(cr *model.CryostatInstance) bool
 	GetCertificateSecret(ctx context.Context, cert *certv1.Certificate) (*corev1.Secret, error)
 }
 
@@ -65,9 +68,63 @@ func NewReconcilerTLS(config *ReconcilerTLSConfig) ReconcilerTLS {
 	}
 }
 
+// Values for spec.tlsOptions.certificateProvider
+const (
+	CertificateProviderCertManager = "CertManager"
+	CertificateProviderOperator    = "Operator"
+)
+
+// Values for spec.tlsOptions.caDistribution
+const (
+	CADistributionSecret       = "Secret"
+	CADistributionTrustManager = "TrustManager"
+)
+
+// Values for spec.networkOptions.coreConfig.tls.termination
+const (
+	ExternalTLSTerminationEdge      = "Edge"
+	ExternalTLSTerminationReencrypt = "Reencrypt"
+)
+
+// IsTrustManagerCADistribution returns whether the trusted CA certificates for this CR
+// should be distributed to target namespaces using a trust-manager Bundle
+func IsTrustManagerCADistribution(cr *model.CryostatInstance) bool {
+	return cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.CADistribution != nil &&
+		*cr.Spec.TLSOptions.CADistribution == CADistributionTrustManager
+}
+
+// IsSidecarTLSEnabled returns whether the Grafana and JFR datasource containers for this CR
+// should be issued certificates and serve HTTPS
+func IsSidecarTLSEnabled(cr *model.CryostatInstance) bool {
+	return cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.SidecarTLS
+}
+
+// GetTLSProfile returns the TLS security profile that Cryostat components and agents
+// should use. A profile specified in the CR takes precedence over the OpenShift
+// API server's profile.
+func GetTLSProfile(ctx context.Context, reader client.Reader, cr *model.CryostatInstance, openShift bool) (*tlsprofile.Profile, error) {
+	var profile *configv1.TLSSecurityProfile
+	if cr.Spec.TLSOptions != nil {
+		profile = cr.Spec.TLSOptions.SecurityProfile
+	}
+	return tlsprofile.ForCryostat(ctx, reader, profile, openShift)
+}
+
+// IsOperatorCertificateProvider returns whether the operator issues TLS certificates
+// for this CR itself, rather than cert-manager
+func IsOperatorCertificateProvider(cr *model.CryostatInstance) bool {
+	return cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.CertificateProvider != nil &&
+		*cr.Spec.TLSOptions.CertificateProvider == CertificateProviderOperator
+}
+
 // IsCertManagerEnabled returns whether TLS using cert-manager is enabled
 // for this operator
 func (r *reconcilerTLS) IsCertManagerEnabled(cr *model.CryostatInstance) bool {
+	// Certificates issued by the operator take precedence over cert-manager
+	if IsOperatorCertificateProvider(cr) {
+		return false
+	}
+
 	// First check if cert-manager is explicitly enabled or disabled in CR
 	if cr.Spec.EnableCertManager != nil {
 		return *cr.Spec.EnableCertManager
@@ -77,6 +134,12 @@ func (r *reconcilerTLS) IsCertManagerEnabled(cr *model.CryostatInstance) bool {
 	return strings.ToLower(r.OS.GetEnv(disableServiceTLS)) != "true"
 }
 
+// IsTLSEnabled returns whether TLS is enabled for this CR, using certificates
+// issued by either cert-manager or the operator
+func (r *reconcilerTLS) IsTLSEnabled(cr *model.CryostatInstance) bool {
+	return IsOperatorCertificateProvider(cr) || r.IsCertManagerEnabled(cr)
+}
+
 // ErrCertNotReady is returned when cert-manager has not marked the certificate
 // as ready, and no TLS secret has been populated yet.
 var ErrCertNotReady error = errors.New("certificate secret not yet ready")
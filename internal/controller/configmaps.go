@@ -17,10 +17,12 @@ package controller
 import (
 	"bytes"
 	"context"
+	cryptotls "crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"path"
+	"strings"
 	"text/template"
 
 	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
@@ -55,8 +57,10 @@ type alphaConfigServer struct {
 }
 
 type proxyTLS struct {
-	Key  tlsSecretSource `json:"Key,omitempty"`
-	Cert tlsSecretSource `json:"Cert,omitempty"`
+	Key          tlsSecretSource `json:"Key,omitempty"`
+	Cert         tlsSecretSource `json:"Cert,omitempty"`
+	MinVersion   string          `json:"MinVersion,omitempty"`
+	CipherSuites []string        `json:"CipherSuites,omitempty"`
 }
 
 type tlsSecretSource struct {
@@ -87,6 +91,10 @@ type alphaConfigUpstream struct {
 
 func (r *Reconciler) reconcileOAuth2ProxyConfig(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig) error {
 	bindHost := "0.0.0.0"
+	grafanaScheme := "http"
+	if resources.UseSidecarTLS(tls) {
+		grafanaScheme = "https"
+	}
 	cfg := &oauth2ProxyAlphaConfig{
 		Server: alphaConfigServer{},
 		UpstreamConfig: alphaConfigUpstreamConfig{ProxyRawPath: true, Upstreams: []alphaConfigUpstream{
@@ -98,7 +106,7 @@ func (r *Reconciler) reconcileOAuth2ProxyConfig(ctx context.Context, cr *model.C
 			{
 				Id:   "grafana",
 				Path: "/grafana/",
-				Uri:  fmt.Sprintf("http://localhost:%d", constants.GrafanaContainerPort),
+				Uri:  fmt.Sprintf("%s://localhost:%d", grafanaScheme, constants.GrafanaContainerPort),
 			},
 			{
 				Id:              "storage",
@@ -121,6 +129,11 @@ func (r *Reconciler) reconcileOAuth2ProxyConfig(ctx context.Context, cr *model.C
 			Cert: tlsSecretSource{
 				FromFile: path.Join(resources.SecretMountPrefix, tls.CryostatSecret, corev1.TLSCertKey),
 			},
+			CipherSuites: tls.Profile.GoCipherSuiteNames(),
+		}
+		// OAuth2 Proxy only supports TLS1.2 and TLS1.3 as minimum versions
+		if minVersion := tls.Profile.GoMinVersion(); minVersion >= cryptotls.VersionTLS12 {
+			cfg.Server.TLS.MinVersion = strings.ReplaceAll(cryptotls.VersionName(minVersion), " ", "")
 		}
 	} else {
 		cfg.Server.BindAddress = fmt.Sprintf("http://%s:%d", bindHost, constants.AuthProxyHttpContainerPort)
@@ -161,6 +174,10 @@ type nginxConfParams struct {
 	CACertFile string
 	// Diffie-Hellman parameters file
 	DHParamFile string
+	// Space-separated TLS versions to accept
+	TLSProtocols string
+	// Colon-separated OpenSSL ciphers to accept for TLSv1.2 and older
+	TLSCiphers string
 	// Nginx proxy container port
 	ContainerPort int32
 	// Nginx health container port
@@ -215,9 +232,11 @@ http {
 
 		ssl_dhparam {{ .DHParamFile }};
 
-		# intermediate configuration
-		ssl_protocols TLSv1.2 TLSv1.3;
-		ssl_ciphers ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384:DHE-RSA-CHACHA20-POLY1305;
+		# TLS security profile configuration
+		ssl_protocols {{ .TLSProtocols }};
+		{{ if .TLSCiphers -}}
+		ssl_ciphers {{ .TLSCiphers }};
+		{{ end -}}
 		ssl_prefer_server_ciphers off;
 
 		# HSTS (ngx_http_headers_module is required) (63072000 seconds)
@@ -272,7 +291,8 @@ http {
 }`))
 
 const (
-	dhFileName = "dhparam.pem"
+	caBundleFileName = "ca-bundle.crt"
+	dhFileName       = "dhparam.pem"
 	// From https://ssl-config.mozilla.org/ffdhe2048.txt
 	dhParams = `-----BEGIN DH PARAMETERS-----
 MIIBCAKCAQEA//////////+t+FRYortKmq/cViAnPTzx2LnFg84tNpWp4TZBFGQz
@@ -314,11 +334,16 @@ func (r *Reconciler) reconcileAgentProxyConfig(ctx context.Context, cr *model.Cr
 		params.TLSEnabled = true
 		params.TLSCertFile = path.Join(resources.SecretMountPrefix, tls.AgentProxySecret, corev1.TLSCertKey)
 		params.TLSKeyFile = path.Join(resources.SecretMountPrefix, tls.AgentProxySecret, corev1.TLSPrivateKeyKey)
-		params.CACertFile = path.Join(resources.SecretMountPrefix, tls.AgentProxySecret, constants.CAKey)
+		params.CACertFile = path.Join(constants.AgentProxyConfigFilePath, caBundleFileName)
 		params.DHParamFile = path.Join(constants.AgentProxyConfigFilePath, dhFileName)
+		params.TLSProtocols = strings.Join(tls.Profile.Versions(), " ")
+		params.TLSCiphers = strings.Join(tls.Profile.OpenSSLCiphers(), ":")
 
 		// Add Diffie-Hellman parameters to config map
 		data[dhFileName] = dhParams
+		// Add the trusted CA certificates, so that agents with certificates signed by
+		// a previous CA are accepted while the CA certificate changes
+		data[caBundleFileName] = string(tls.CABundle)
 	}
 
 	// Create an nginx.conf where:
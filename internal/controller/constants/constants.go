@@ -69,8 +69,13 @@ const (
 	targetNamespaceCRLabelPrefix    = "operator.cryostat.io/"
 	TargetNamespaceCRNameLabel      = targetNamespaceCRLabelPrefix + "name"
 	TargetNamespaceCRNamespaceLabel = targetNamespaceCRLabelPrefix + "namespace"
+	// Prefix of the label added to target namespaces that receive the CA certificate through trust-manager
+	CABundleNamespaceLabelPrefix = targetNamespaceCRLabelPrefix
+	// Pod template annotation containing the fingerprint of the agent certificate
+	// that a workload was last restarted for
+	AgentCertificateHashAnnotation = targetNamespaceCRLabelPrefix + "agent-certificate-hash"
 
-	// Labels for agent auto-configuration
+	// Labels for agent auto-configuration, which may also be given as annotations
 	AgentLabelPrefix                  = "cryostat.io/"
 	AgentLabelCryostatName            = AgentLabelPrefix + "name"
 	AgentLabelCryostatNamespace       = AgentLabelPrefix + "namespace"
@@ -85,6 +90,15 @@ const (
 	AgentLabelHarvesterExitMaxAge     = AgentLabelPrefix + "harvester-exit-max-age"
 	AgentLabelHarvesterExitMaxSize    = AgentLabelPrefix + "harvester-exit-max-size"
 	AgentLabelSmartTriggersConfigMaps = AgentLabelPrefix + "smart-triggers"
+	AgentLabelConfigMap               = AgentLabelPrefix + "agent-config"
+	// Namespace label selecting the Cryostat for all pods in the namespace, as "<cr-namespace>.<cr-name>".
+	// Pods may opt out by setting this label to "false".
+	AgentLabelInject = AgentLabelPrefix + "inject"
+
+	// Name of the init container added to pods injected with the Cryostat agent
+	AgentInitContainerName = "cryostat-agent-init"
+	// Annotation-only agent auto-configuration, for values not allowed in labels
+	AgentAnnotationSystemProperties = AgentLabelPrefix + "java-system-properties"
 
 	CryostatCATLSCommonName     = "cryostat-ca-cert-manager"
 	CryostatTLSCommonName       = "cryostat"
@@ -93,6 +107,8 @@ const (
 	ReportsTLSCommonName        = "cryostat-reports"
 	AgentsTLSCommonName         = "cryostat-agent"
 	AgentAuthProxyTLSCommonName = "cryostat-agent-proxy"
+	GrafanaTLSCommonName        = "cryostat-grafana"
+	DatasourceTLSCommonName     = "cryostat-jfr-datasource"
 
 	// OpenShift Console Plugin constants
 	ConsolePluginName               = "cryostat-plugin"
@@ -20,6 +20,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -55,16 +56,19 @@ func NewCryostatReconciler(config *ReconcilerConfig) (*CryostatReconciler, error
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;get;list;update;watch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=create;get;list;update;watch;delete
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=apiservers,verbs=get;list;update;watch
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 // +kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=get
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=*
 // +kubebuilder:rbac:namespace=system,groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;create
 // +kubebuilder:rbac:groups=cert-manager.io,resources=issuers;certificates,verbs=create;get;list;update;watch;delete
-// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/finalizers,verbs=update
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates/finalizers;certificates/status,verbs=update
+// +kubebuilder:rbac:groups=trust.cert-manager.io,resources=bundles,verbs=create;get;list;update;watch;delete
 // +kubebuilder:rbac:groups=console.openshift.io,resources=consolelinks,verbs=get;create;list;update;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=*
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=create;get;list;update;watch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 
 // RBAC for Insights controller, remove these when moving to a separate container
 // +kubebuilder:rbac:namespace=system,groups=apps,resources=deployments;deployments/finalizers,verbs=create;update;get;list;watch
@@ -96,7 +100,13 @@ func (r *CryostatReconciler) Reconcile(ctx context.Context, request ctrl.Request
 	}
 
 	instance := model.FromCryostat(cr)
-	return r.delegate.reconcileCryostat(ctx, instance)
+	result, err := r.delegate.reconcileCryostat(ctx, instance)
+
+	// Report the latest conditions, unless the Cryostat is being deleted
+	if cr.DeletionTimestamp.IsZero() {
+		metrics.SetConditions(cr.Namespace, cr.Name, cr.Status.Conditions)
+	}
+	return result, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,213 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	reasonDatabaseRestoreInProgress = "DatabaseRestoreInProgress"
+	reasonDatabaseRestoreFailed     = "DatabaseRestoreFailed"
+	// Reason used when scripts run by the operator cannot authenticate with object storage
+	reasonObjectStorageCredentialsUnsupported = "ObjectStorageCredentialsUnsupported"
+)
+
+// ErrDatabaseRestoreInProgress is returned while the database is being restored from a backup
+var ErrDatabaseRestoreInProgress = errors.New("waiting for the database to be restored from a backup")
+
+// databaseRestoreError indicates that the restore Job failed
+type databaseRestoreError struct {
+	jobName string
+	message string
+}
+
+func (e *databaseRestoreError) Error() string {
+	return fmt.Sprintf("failed to restore the database using Job %s: %s. Select another backup, delete the Job to "+
+		"try again, or remove the restore configuration to start Cryostat without the backup", e.jobName, e.message)
+}
+
+func isDatabaseRestoreError(err error) bool {
+	var restoreErr *databaseRestoreError
+	return errors.As(err, &restoreErr)
+}
+
+func isObjectStorageCredentialsError(err error) bool {
+	var credentialsErr *resources.ObjectStorageCredentialsError
+	return errors.As(err, &credentialsErr)
+}
+
+// reconcileDatabaseBackup schedules database backups and restores the database from a backup.
+// Cryostat can run without backups, so unusable object storage credentials are reported in the
+// DatabaseBackupReady condition instead of failing the reconcile. Cryostat must not start until a
+// restore completes, since it would create the schema in the empty database, and the backup could
+// then no longer be restored.
+func (r *Reconciler) reconcileDatabaseBackup(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
+	backupErr := r.reconcileDatabaseBackupCronJob(ctx, cr, tls, imageTags, serviceSpecs, fsGroup)
+	if backupErr != nil && !isObjectStorageCredentialsError(backupErr) {
+		return backupErr
+	}
+	restoreErr := r.reconcileDatabaseRestore(ctx, cr, tls, imageTags, serviceSpecs, fsGroup)
+
+	if !resources.DatabaseBackupEnabled(cr) && !resources.DatabaseRestoreEnabled(cr) {
+		removeConditionIfPresent(cr, operatorv1beta2.ConditionTypeDatabaseBackupReady)
+	} else if backupErr != nil {
+		setCondition(cr, operatorv1beta2.ConditionTypeDatabaseBackupReady, metav1.ConditionFalse,
+			conditionReasonForError(backupErr), backupErr.Error())
+	} else if restoreErr == nil {
+		setCondition(cr, operatorv1beta2.ConditionTypeDatabaseBackupReady, metav1.ConditionTrue, reasonReconciled,
+			"Database backups are scheduled and any restore has completed.")
+	}
+	return restoreErr
+}
+
+func (r *Reconciler) reconcileDatabaseBackupCronJob(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
+	cronJob, err := resources.NewCronJobForDatabaseBackup(cr, imageTags, tls, serviceSpecs, r.IsOpenShift, fsGroup)
+	if isObjectStorageCredentialsError(err) {
+		// Backups would fail to authenticate, so stop scheduling them
+		cronJob = &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cr.Name + "-database-backup",
+				Namespace: cr.InstallNamespace,
+			},
+		}
+		if deleteErr := r.deleteCronJob(ctx, cronJob); deleteErr != nil {
+			return deleteErr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !resources.DatabaseBackupEnabled(cr) {
+		return r.deleteCronJob(ctx, cronJob)
+	}
+	return r.createOrUpdateCronJob(ctx, cronJob, cr.Object)
+}
+
+// reconcileDatabaseRestore runs a Job to restore the database from a backup, and returns
+// ErrDatabaseRestoreInProgress until the Job completes
+func (r *Reconciler) reconcileDatabaseRestore(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
+	desired, err := resources.NewJobForDatabaseRestore(cr, imageTags, tls, serviceSpecs, r.IsOpenShift, fsGroup)
+	if err != nil {
+		return err
+	}
+	if !resources.DatabaseRestoreEnabled(cr) {
+		return r.deleteJob(ctx, desired)
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, job)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		if err := controllerutil.SetControllerReference(cr.Object, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.Log.Info("Job created", "name", desired.Name, "namespace", desired.Namespace)
+		return ErrDatabaseRestoreInProgress
+	}
+
+	// The Job is immutable, so replace it when a different backup is selected
+	if job.Annotations[resources.DatabaseRestoreBackupAnnotation] != desired.Annotations[resources.DatabaseRestoreBackupAnnotation] {
+		if err := r.deleteJob(ctx, job); err != nil {
+			return err
+		}
+		return ErrDatabaseRestoreInProgress
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return nil
+		case batchv1.JobFailed:
+			return &databaseRestoreError{jobName: job.Name, message: condition.Message}
+		}
+	}
+	return ErrDatabaseRestoreInProgress
+}
+
+func (r *Reconciler) createOrUpdateCronJob(ctx context.Context, cronJob *batchv1.CronJob, owner metav1.Object) error {
+	cronJobCopy := cronJob.DeepCopy()
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+		// Merge any required labels
+		for key, value := range cronJobCopy.Labels {
+			metav1.SetMetaDataLabel(&cronJob.ObjectMeta, key, value)
+		}
+		// Set the Cryostat CR as controller
+		if err := controllerutil.SetControllerReference(owner, cronJob, r.Scheme); err != nil {
+			return err
+		}
+		cronJob.Spec = cronJobCopy.Spec
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.Log.Info(fmt.Sprintf("CronJob %s", op), "name", cronJob.Name, "namespace", cronJob.Namespace)
+	return nil
+}
+
+func (r *Reconciler) deleteCronJob(ctx context.Context, cronJob *batchv1.CronJob) error {
+	err := r.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !kerrors.IsNotFound(err) {
+		r.Log.Error(err, "Could not delete CronJob", "name", cronJob.Name, "namespace", cronJob.Namespace)
+		return err
+	}
+	return nil
+}
+
+func (r *Reconciler) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	// Delete the Job's pods along with it
+	err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !kerrors.IsNotFound(err) {
+		r.Log.Error(err, "Could not delete Job", "name", job.Name, "namespace", job.Namespace)
+		return err
+	}
+	return nil
+}
+
+// requeueIfStorageInProgress polls while the database is restored or a volume is migrated
+func requeueIfStorageInProgress(err error) (reconcile.Result, error) {
+	if err == ErrDatabaseRestoreInProgress || err == ErrVolumeMigrationInProgress {
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	return reconcile.Result{}, err
+}
@@ -0,0 +1,140 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	common "github.com/cryostatio/cryostat-operator/internal/controller/common"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const reasonInvalidExternalCertificate = "InvalidExternalCertificate"
+
+// externalTLS holds the certificate from a user-provided Secret that
+// is presented to clients outside the cluster
+type externalTLS struct {
+	secretName  string
+	termination string
+	// PEM-encoded CA certificate, if provided
+	caCert []byte
+	// Parsed leaf certificate, used to validate the external host
+	leaf *x509.Certificate
+}
+
+// externalTLSError indicates that the external TLS configuration cannot be used
+type externalTLSError struct {
+	message string
+}
+
+func (e *externalTLSError) Error() string {
+	return e.message
+}
+
+func newExternalTLSError(format string, args ...any) error {
+	return &externalTLSError{message: fmt.Sprintf(format, args...)}
+}
+
+func isExternalTLSError(err error) bool {
+	var tlsErr *externalTLSError
+	return errors.As(err, &tlsErr)
+}
+
+// getExternalTLS reads and validates the certificate Secret referenced by the
+// network configuration, returning nil if none is referenced
+func (r *Reconciler) getExternalTLS(ctx context.Context, cr *model.CryostatInstance,
+	config *operatorv1beta2.NetworkConfiguration, tlsConfig *resources.TLSConfig) (*externalTLS, error) {
+	if config == nil || config.TLS == nil {
+		return nil, nil
+	}
+
+	termination, err := getExternalTLSTermination(config.TLS, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = r.Client.Get(ctx, types.NamespacedName{Name: config.TLS.SecretName, Namespace: cr.InstallNamespace}, secret)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, newExternalTLSError("external TLS secret %s does not exist", config.TLS.SecretName)
+		}
+		return nil, err
+	}
+
+	certificate := secret.Data[corev1.TLSCertKey]
+	key := secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certificate) == 0 || len(key) == 0 {
+		return nil, newExternalTLSError("external TLS secret %s must contain the keys %s and %s",
+			secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	pair, err := tls.X509KeyPair(certificate, key)
+	if err != nil {
+		return nil, newExternalTLSError("external TLS secret %s does not contain a valid key pair: %s",
+			secret.Name, err.Error())
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, newExternalTLSError("failed to parse certificate in external TLS secret %s: %s",
+			secret.Name, err.Error())
+	}
+
+	return &externalTLS{
+		secretName:  secret.Name,
+		termination: termination,
+		caCert:      secret.Data[constants.CAKey],
+		leaf:        leaf,
+	}, nil
+}
+
+func getExternalTLSTermination(config *operatorv1beta2.ExternalTLSConfig, tlsConfig *resources.TLSConfig) (string, error) {
+	if config.Termination == nil {
+		// Connect to Cryostat using the same protocol it serves
+		if tlsConfig == nil {
+			return common.ExternalTLSTerminationEdge, nil
+		}
+		return common.ExternalTLSTerminationReencrypt, nil
+	}
+
+	termination := *config.Termination
+	if termination == common.ExternalTLSTerminationReencrypt && tlsConfig == nil {
+		return "", newExternalTLSError("external TLS termination %s requires TLS to be enabled within the cluster",
+			termination)
+	}
+	if termination == common.ExternalTLSTerminationEdge && tlsConfig != nil {
+		return "", newExternalTLSError("external TLS termination %s requires TLS to be disabled within the cluster",
+			termination)
+	}
+	return termination, nil
+}
+
+// verifyHost checks that the external certificate is valid for the provided host
+func (t *externalTLS) verifyHost(host string) error {
+	if err := t.leaf.VerifyHostname(host); err != nil {
+		return newExternalTLSError("certificate in external TLS secret %s is not valid for host %s: %s",
+			t.secretName, host, err.Error())
+	}
+	return nil
+}
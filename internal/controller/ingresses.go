@@ -30,7 +30,7 @@ import (
 )
 
 func (r *Reconciler) reconcileCoreIngress(ctx context.Context, cr *model.CryostatInstance,
-	specs *resource_definitions.ServiceSpecs) error {
+	tls *resource_definitions.TLSConfig, specs *resource_definitions.ServiceSpecs) error {
 	ingress := &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Name,
@@ -44,7 +44,11 @@ func (r *Reconciler) reconcileCoreIngress(ctx context.Context, cr *model.Cryosta
 		return r.deleteIngress(ctx, ingress)
 	}
 	coreConfig := configureCoreIngress(cr)
-	ingressURL, err := r.reconcileIngress(ctx, ingress, cr, coreConfig)
+	external, err := r.getExternalTLS(ctx, cr, coreConfig, tls)
+	if err != nil {
+		return err
+	}
+	ingressURL, err := r.reconcileIngress(ctx, ingress, cr, external, coreConfig)
 	if err != nil {
 		return err
 	}
@@ -54,8 +58,8 @@ func (r *Reconciler) reconcileCoreIngress(ctx context.Context, cr *model.Cryosta
 }
 
 func (r *Reconciler) reconcileIngress(ctx context.Context, ingress *netv1.Ingress, cr *model.CryostatInstance,
-	config *operatorv1beta2.NetworkConfiguration) (*url.URL, error) {
-	ingress, err := r.createOrUpdateIngress(ctx, ingress, cr.Object, config)
+	external *externalTLS, config *operatorv1beta2.NetworkConfiguration) (*url.URL, error) {
+	ingress, err := r.createOrUpdateIngress(ctx, ingress, cr.Object, external, config)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +80,7 @@ func (r *Reconciler) reconcileIngress(ctx context.Context, ingress *netv1.Ingres
 }
 
 func (r *Reconciler) createOrUpdateIngress(ctx context.Context, ingress *netv1.Ingress, owner metav1.Object,
-	config *operatorv1beta2.NetworkConfiguration) (*netv1.Ingress, error) {
+	external *externalTLS, config *operatorv1beta2.NetworkConfiguration) (*netv1.Ingress, error) {
 	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, ingress, func() error {
 		// Set labels and annotations from CR
 		common.MergeLabelsAndAnnotations(&ingress.ObjectMeta, config.Labels, config.Annotations)
@@ -86,7 +90,35 @@ func (r *Reconciler) createOrUpdateIngress(ctx context.Context, ingress *netv1.I
 			return err
 		}
 		// Update Ingress spec
-		ingress.Spec = *config.IngressSpec
+		ingress.Spec = *config.IngressSpec.DeepCopy()
+
+		// Serve the hosts of this Ingress using the user-provided certificate, leaving
+		// any hosts already listed in the IngressSpec's TLS entries as they are
+		if external != nil {
+			configured := map[string]bool{}
+			for _, entry := range ingress.Spec.TLS {
+				for _, host := range entry.Hosts {
+					configured[host] = true
+				}
+			}
+			hosts := []string{}
+			for _, rule := range ingress.Spec.Rules {
+				if len(rule.Host) == 0 || configured[rule.Host] {
+					continue
+				}
+				if err := external.verifyHost(rule.Host); err != nil {
+					return err
+				}
+				configured[rule.Host] = true
+				hosts = append(hosts, rule.Host)
+			}
+			if len(hosts) > 0 {
+				ingress.Spec.TLS = append(ingress.Spec.TLS, netv1.IngressTLS{
+					Hosts:      hosts,
+					SecretName: external.secretName,
+				})
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -0,0 +1,208 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics contains the Prometheus collectors for the operator's
+// own activity. They are registered with controller-runtime's registry
+// and served by the manager's metrics server.
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "cryostat_operator"
+
+// Labels identifying the Cryostat CR a metric belongs to
+const (
+	labelNamespace = "namespace"
+	labelName      = "name"
+)
+
+var (
+	reconcileStageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "reconcile_stage_duration_seconds",
+		Help:      "Time taken by each stage when reconciling a Cryostat",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{labelNamespace, labelName, "stage"})
+
+	certificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "certificate_expiration_timestamp_seconds",
+		Help:      "Time when a certificate managed for a Cryostat expires, in seconds since the Unix epoch",
+	}, []string{labelNamespace, labelName, "certificate"})
+
+	agentInjectionAdmissions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "agent_injection_admissions_total",
+		Help:      "Number of pod admissions in which the Cryostat agent was injected",
+	}, []string{labelNamespace, labelName, "pod_namespace"})
+
+	agentInjectedPods = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "agent_injected_pods",
+		Help:      "Number of existing pods that the Cryostat agent is injected into",
+	}, []string{labelNamespace, labelName, "pod_namespace"})
+
+	agentInjectionFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "agent_injection_failures_total",
+		Help:      "Number of pods that the Cryostat agent could not be injected into",
+	}, []string{labelNamespace, labelName, "pod_namespace", "reason"})
+
+	conditions = newConditionCollector()
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileStageDuration, certificateExpiry, agentInjectionAdmissions,
+		agentInjectedPods, agentInjectionFailures, conditions)
+}
+
+// StageTimer measures the time taken by consecutive stages of a reconcile.
+type StageTimer struct {
+	namespace string
+	name      string
+	start     time.Time
+}
+
+// NewStageTimer returns a StageTimer for the Cryostat with the given namespace and name,
+// starting from the current time.
+func NewStageTimer(namespace string, name string) *StageTimer {
+	return &StageTimer{
+		namespace: namespace,
+		name:      name,
+		start:     time.Now(),
+	}
+}
+
+// Observe records the time since the previous stage ended as the duration of the named stage.
+func (t *StageTimer) Observe(stage string) {
+	now := time.Now()
+	reconcileStageDuration.WithLabelValues(t.namespace, t.name, stage).Observe(now.Sub(t.start).Seconds())
+	t.start = now
+}
+
+// SetCertificateExpiry records when the named certificate belonging to a Cryostat expires.
+func SetCertificateExpiry(namespace string, name string, certificate string, notAfter time.Time) {
+	certificateExpiry.WithLabelValues(namespace, name, certificate).Set(float64(notAfter.Unix()))
+}
+
+// DeleteCertificateExpiry stops reporting the expiry of the named certificate belonging to a Cryostat.
+func DeleteCertificateExpiry(namespace string, name string, certificate string) {
+	certificateExpiry.DeleteLabelValues(namespace, name, certificate)
+}
+
+// DeleteAllCertificateExpiry stops reporting the expiry of all certificates belonging to a Cryostat.
+func DeleteAllCertificateExpiry(namespace string, name string) {
+	certificateExpiry.DeletePartialMatch(prometheus.Labels{labelNamespace: namespace, labelName: name})
+}
+
+// RecordAgentInjection counts an admission of a pod in podNamespace that was injected
+// with the agent for a Cryostat. The pod may not be created, or may later be deleted.
+func RecordAgentInjection(namespace string, name string, podNamespace string) {
+	agentInjectionAdmissions.WithLabelValues(namespace, name, podNamespace).Inc()
+}
+
+// SetAgentInjectedPods replaces the number of existing pods injected with the agent for
+// a Cryostat, keyed by the namespace of the pods.
+func SetAgentInjectedPods(namespace string, name string, pods map[string]int) {
+	agentInjectedPods.DeletePartialMatch(prometheus.Labels{labelNamespace: namespace, labelName: name})
+	for podNamespace, count := range pods {
+		agentInjectedPods.WithLabelValues(namespace, name, podNamespace).Set(float64(count))
+	}
+}
+
+// RecordAgentInjectionFailure counts a pod in podNamespace that the agent could not be
+// injected into for a Cryostat, along with the reason for the failure.
+func RecordAgentInjectionFailure(namespace string, name string, podNamespace string, reason string) {
+	agentInjectionFailures.WithLabelValues(namespace, name, podNamespace, reason).Inc()
+}
+
+// SetConditions replaces the reported conditions of a Cryostat.
+func SetConditions(namespace string, name string, conds []metav1.Condition) {
+	conditions.set(types.NamespacedName{Namespace: namespace, Name: name}, conds)
+}
+
+// DeleteCryostat stops reporting all metrics for a Cryostat.
+func DeleteCryostat(namespace string, name string) {
+	labels := prometheus.Labels{labelNamespace: namespace, labelName: name}
+	reconcileStageDuration.DeletePartialMatch(labels)
+	certificateExpiry.DeletePartialMatch(labels)
+	agentInjectionAdmissions.DeletePartialMatch(labels)
+	agentInjectedPods.DeletePartialMatch(labels)
+	agentInjectionFailures.DeletePartialMatch(labels)
+	conditions.delete(types.NamespacedName{Namespace: namespace, Name: name})
+}
+
+// conditionCollector reports a gauge for each status of each condition of each Cryostat,
+// where the current status of the condition has the value 1 and the others have the value 0.
+type conditionCollector struct {
+	desc       *prometheus.Desc
+	lock       sync.RWMutex
+	conditions map[types.NamespacedName][]metav1.Condition
+}
+
+var _ prometheus.Collector = (*conditionCollector)(nil)
+
+var conditionStatuses = []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+
+func newConditionCollector() *conditionCollector {
+	return &conditionCollector{
+		desc: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", "cryostat_condition"),
+			"The current status of a Cryostat condition",
+			[]string{labelNamespace, labelName, "type", "status"}, nil),
+		conditions: map[types.NamespacedName][]metav1.Condition{},
+	}
+}
+
+func (c *conditionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *conditionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	for key, conds := range c.conditions {
+		for _, cond := range conds {
+			for _, status := range conditionStatuses {
+				value := 0.0
+				if cond.Status == status {
+					value = 1.0
+				}
+				ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, value,
+					key.Namespace, key.Name, cond.Type, strings.ToLower(string(status)))
+			}
+		}
+	}
+}
+
+func (c *conditionCollector) set(key types.NamespacedName, conds []metav1.Condition) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// Copy, since the caller may modify its conditions later
+	c.conditions[key] = append([]metav1.Condition(nil), conds...)
+}
+
+func (c *conditionCollector) delete(key types.NamespacedName) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.conditions, key)
+}
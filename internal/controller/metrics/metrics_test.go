@@ -0,0 +1,166 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "test"
+	name      = "cryostat"
+)
+
+var _ = Describe("Metrics", func() {
+	AfterEach(func() {
+		metrics.DeleteCryostat(namespace, name)
+	})
+
+	Context("timing reconcile stages", func() {
+		BeforeEach(func() {
+			timer := metrics.NewStageTimer(namespace, name)
+			timer.Observe("secrets")
+			timer.Observe("rbac")
+		})
+
+		It("should observe each stage", func() {
+			Expect(countSeries("cryostat_operator_reconcile_stage_duration_seconds")).To(Equal(2))
+		})
+	})
+
+	Context("recording certificate expiry", func() {
+		var notAfter time.Time
+
+		BeforeEach(func() {
+			notAfter = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+			metrics.SetCertificateExpiry(namespace, name, "cryostat", notAfter)
+			metrics.SetCertificateExpiry(namespace, name, "cryostat-ca", notAfter)
+		})
+
+		It("should report the expiry time", func() {
+			expected := `
+# HELP cryostat_operator_certificate_expiration_timestamp_seconds Time when a certificate managed for a Cryostat expires, in seconds since the Unix epoch
+# TYPE cryostat_operator_certificate_expiration_timestamp_seconds gauge
+cryostat_operator_certificate_expiration_timestamp_seconds{certificate="cryostat",name="cryostat",namespace="test"} 1.893456e+09
+cryostat_operator_certificate_expiration_timestamp_seconds{certificate="cryostat-ca",name="cryostat",namespace="test"} 1.893456e+09
+`
+			Expect(gather(expected, "cryostat_operator_certificate_expiration_timestamp_seconds")).To(Succeed())
+		})
+
+		It("should delete a single certificate", func() {
+			metrics.DeleteCertificateExpiry(namespace, name, "cryostat")
+			Expect(countSeries("cryostat_operator_certificate_expiration_timestamp_seconds")).To(Equal(1))
+		})
+
+		It("should delete all certificates", func() {
+			metrics.DeleteAllCertificateExpiry(namespace, name)
+			Expect(countSeries("cryostat_operator_certificate_expiration_timestamp_seconds")).To(Equal(0))
+		})
+	})
+
+	Context("recording agent injection", func() {
+		BeforeEach(func() {
+			metrics.RecordAgentInjection(namespace, name, "apps")
+			metrics.RecordAgentInjection(namespace, name, "apps")
+		})
+
+		It("should count admissions", func() {
+			expected := `
+# HELP cryostat_operator_agent_injection_admissions_total Number of pod admissions in which the Cryostat agent was injected
+# TYPE cryostat_operator_agent_injection_admissions_total counter
+cryostat_operator_agent_injection_admissions_total{name="cryostat",namespace="test",pod_namespace="apps"} 2
+`
+			Expect(gather(expected, "cryostat_operator_agent_injection_admissions_total")).To(Succeed())
+		})
+	})
+
+	Context("reporting agent injected pods", func() {
+		BeforeEach(func() {
+			metrics.SetAgentInjectedPods(namespace, name, map[string]int{"apps": 3, "other": 1})
+		})
+
+		It("should report the number of pods", func() {
+			expected := `
+# HELP cryostat_operator_agent_injected_pods Number of existing pods that the Cryostat agent is injected into
+# TYPE cryostat_operator_agent_injected_pods gauge
+cryostat_operator_agent_injected_pods{name="cryostat",namespace="test",pod_namespace="apps"} 3
+cryostat_operator_agent_injected_pods{name="cryostat",namespace="test",pod_namespace="other"} 1
+`
+			Expect(gather(expected, "cryostat_operator_agent_injected_pods")).To(Succeed())
+		})
+
+		It("should replace the previous namespaces", func() {
+			metrics.SetAgentInjectedPods(namespace, name, map[string]int{"apps": 2})
+			Expect(countSeries("cryostat_operator_agent_injected_pods")).To(Equal(1))
+		})
+	})
+
+	Context("recording agent injection failures", func() {
+		BeforeEach(func() {
+			metrics.RecordAgentInjectionFailure(namespace, name, "apps", "ContainerNotFound")
+		})
+
+		It("should count failures by reason", func() {
+			expected := `
+# HELP cryostat_operator_agent_injection_failures_total Number of pods that the Cryostat agent could not be injected into
+# TYPE cryostat_operator_agent_injection_failures_total counter
+cryostat_operator_agent_injection_failures_total{name="cryostat",namespace="test",pod_namespace="apps",reason="ContainerNotFound"} 1
+`
+			Expect(gather(expected, "cryostat_operator_agent_injection_failures_total")).To(Succeed())
+		})
+	})
+
+	Context("reporting conditions", func() {
+		BeforeEach(func() {
+			metrics.SetConditions(namespace, name, []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse},
+			})
+		})
+
+		It("should report the current status", func() {
+			expected := `
+# HELP cryostat_operator_cryostat_condition The current status of a Cryostat condition
+# TYPE cryostat_operator_cryostat_condition gauge
+cryostat_operator_cryostat_condition{name="cryostat",namespace="test",status="false",type="Ready"} 1
+cryostat_operator_cryostat_condition{name="cryostat",namespace="test",status="true",type="Ready"} 0
+cryostat_operator_cryostat_condition{name="cryostat",namespace="test",status="unknown",type="Ready"} 0
+`
+			Expect(gather(expected, "cryostat_operator_cryostat_condition")).To(Succeed())
+		})
+
+		It("should stop reporting a deleted Cryostat", func() {
+			metrics.DeleteCryostat(namespace, name)
+			Expect(countSeries("cryostat_operator_cryostat_condition")).To(Equal(0))
+		})
+	})
+})
+
+func gather(expected string, name string) error {
+	return testutil.GatherAndCompare(ctrlmetrics.Registry, strings.NewReader(expected), name)
+}
+
+func countSeries(name string) int {
+	count, err := testutil.GatherAndCount(ctrlmetrics.Registry, name)
+	Expect(err).ToNot(HaveOccurred())
+	return count
+}
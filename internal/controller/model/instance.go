@@ -27,7 +27,8 @@ type CryostatInstance struct {
 	// CR's namespace.
 	InstallNamespace string
 	// Namespaces that Cryostat should look for targets. For Cryostat, this
-	// comes from spec.TargetNamespaces.
+	// comes from spec.TargetNamespaces, along with any namespaces matching
+	// spec.TargetNamespaceSelector once resolved by the reconciler.
 	TargetNamespaces []string
 	// Namespaces that the operator has successfully set up RBAC for Cryostat to monitor targets
 	// in that namespace. For Cryostat, this is a reference to status.TargetNamespaces.
@@ -0,0 +1,97 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// resolveTargetNamespaces adds any namespaces matching the CR's target namespace
+// selector to the statically listed target namespaces.
+func (r *Reconciler) resolveTargetNamespaces(ctx context.Context, cr *model.CryostatInstance) error {
+	if cr.Spec.TargetNamespaceSelector == nil {
+		return nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.TargetNamespaceSelector)
+	if err != nil {
+		return err
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	err = r.List(ctx, namespaces, &client.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+
+	selected := []string{}
+	for _, ns := range namespaces.Items {
+		// Skip namespaces being deleted, we can't create anything in them
+		if ns.DeletionTimestamp != nil || ns.Status.Phase == corev1.NamespaceTerminating {
+			continue
+		}
+		if !containsNamespace(cr.TargetNamespaces, ns.Name) {
+			selected = append(selected, ns.Name)
+		}
+	}
+	slices.Sort(selected)
+
+	// Copy to avoid modifying the spec
+	targetNamespaces := make([]string, 0, len(cr.TargetNamespaces)+len(selected))
+	targetNamespaces = append(targetNamespaces, cr.TargetNamespaces...)
+	cr.TargetNamespaces = append(targetNamespaces, selected...)
+	return nil
+}
+
+func (r *Reconciler) mapFromNamespace() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		// Find all Cryostat CRs whose target namespace selector matches this namespace,
+		// or that currently target this namespace through their selector
+		crs := &operatorv1beta2.CryostatList{}
+		err := r.List(ctx, crs)
+		if err != nil {
+			r.Log.Error(err, "Failed to list Cryostats for namespace event", "namespace", obj.GetName())
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, cr := range crs.Items {
+			if cr.Spec.TargetNamespaceSelector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(cr.Spec.TargetNamespaceSelector)
+			if err != nil {
+				r.Log.Error(err, "Invalid target namespace selector", "name", cr.Name, "namespace", cr.Namespace)
+				continue
+			}
+			if selector.Matches(labels.Set(obj.GetLabels())) ||
+				slices.Contains(cr.Status.TargetNamespaces, obj.GetName()) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+				})
+			}
+		}
+		return requests
+	}
+}
@@ -118,7 +118,7 @@ func (r *Reconciler) reconcileCoreNetworkPolicy(ctx context.Context, cr *model.C
 										{
 											Key:      namespaceNameLabel,
 											Operator: metav1.LabelSelectorOpIn,
-											Values:   cr.Spec.TargetNamespaces,
+											Values:   cr.TargetNamespaces,
 										},
 									},
 								},
@@ -214,11 +214,28 @@ func (r *Reconciler) reconcileDatabaseNetworkPolicy(ctx context.Context, cr *mod
 		},
 	}
 	allDisabled := cr.Spec.NetworkPolicies != nil && cr.Spec.NetworkPolicies.DatabaseConfig != nil && cr.Spec.NetworkPolicies.DatabaseConfig.Disabled != nil && *cr.Spec.NetworkPolicies.DatabaseConfig.Disabled
+	deployManagedDatabase := resources.DeployManagedDatabase(cr)
 	ingressDisabled := cr.Spec.NetworkPolicies != nil && cr.Spec.NetworkPolicies.DatabaseConfig != nil && cr.Spec.NetworkPolicies.DatabaseConfig.IngressDisabled != nil && *cr.Spec.NetworkPolicies.DatabaseConfig.IngressDisabled
-	if allDisabled || ingressDisabled {
+	if allDisabled || !deployManagedDatabase || ingressDisabled {
 		return r.deletePolicy(ctx, ingressPolicy)
 	}
 
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: installationNamespaceSelector(cr),
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: resources.CorePodLabels(cr),
+			},
+		},
+	}
+	if resources.DatabaseBackupEnabled(cr) || resources.DatabaseRestoreEnabled(cr) {
+		peers = append(peers, databaseBackupPeer(cr))
+	}
+	if resources.VolumeMigrationInProgress(cr, resources.VolumeComponentDatabase) {
+		// The migration Job may copy the database from the running server
+		peers = append(peers, volumeMigrationPeer(cr, resources.VolumeComponentDatabase))
+	}
+
 	return r.createOrUpdatePolicy(ctx, ingressPolicy, cr.Object, func() error {
 		ingressPolicy.Spec = networkingv1.NetworkPolicySpec{
 			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
@@ -227,14 +244,7 @@ func (r *Reconciler) reconcileDatabaseNetworkPolicy(ctx context.Context, cr *mod
 			},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							NamespaceSelector: installationNamespaceSelector(cr),
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: resources.CorePodLabels(cr),
-							},
-						},
-					},
+					From: peers,
 					Ports: []networkingv1.NetworkPolicyPort{
 						{
 							Port: &intstr.IntOrString{IntVal: constants.DatabasePort},
@@ -261,6 +271,33 @@ func (r *Reconciler) reconcileStorageNetworkPolicy(ctx context.Context, cr *mode
 		return r.deletePolicy(ctx, ingressPolicy)
 	}
 
+	peers := []networkingv1.NetworkPolicyPeer{
+		{
+			NamespaceSelector: installationNamespaceSelector(cr),
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: resources.CorePodLabels(cr),
+			},
+		},
+		{
+			NamespaceSelector: installationNamespaceSelector(cr),
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: resources.ReportsPodLabels(cr),
+			},
+		},
+	}
+	if resources.DatabaseBackupUsesObjectStorage(cr) {
+		peers = append(peers, databaseBackupPeer(cr))
+	}
+	if resources.StorageRetentionEnabled(cr) || len(cr.Status.StorageRetention) > 0 {
+		// Allow connections until any lifecycle configuration is removed from the buckets
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: installationNamespaceSelector(cr),
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: resources.StorageRetentionLabels(cr),
+			},
+		})
+	}
+
 	return r.createOrUpdatePolicy(ctx, ingressPolicy, cr.Object, func() error {
 		ingressPolicy.Spec = networkingv1.NetworkPolicySpec{
 			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
@@ -269,20 +306,7 @@ func (r *Reconciler) reconcileStorageNetworkPolicy(ctx context.Context, cr *mode
 			},
 			Ingress: []networkingv1.NetworkPolicyIngressRule{
 				{
-					From: []networkingv1.NetworkPolicyPeer{
-						{
-							NamespaceSelector: installationNamespaceSelector(cr),
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: resources.CorePodLabels(cr),
-							},
-						},
-						{
-							NamespaceSelector: installationNamespaceSelector(cr),
-							PodSelector: &metav1.LabelSelector{
-								MatchLabels: resources.ReportsPodLabels(cr),
-							},
-						},
-					},
+					From: peers,
 					Ports: []networkingv1.NetworkPolicyPort{
 						{
 							Port: &intstr.IntOrString{IntVal: constants.StoragePort},
@@ -295,6 +319,26 @@ func (r *Reconciler) reconcileStorageNetworkPolicy(ctx context.Context, cr *mode
 	})
 }
 
+// databaseBackupPeer selects the pods that back up and restore the database
+func databaseBackupPeer(cr *model.CryostatInstance) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: installationNamespaceSelector(cr),
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: resources.DatabaseBackupLabels(cr),
+		},
+	}
+}
+
+// volumeMigrationPeer selects the pods that copy a component's data to a new volume
+func volumeMigrationPeer(cr *model.CryostatInstance, component string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: installationNamespaceSelector(cr),
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: resources.VolumeMigrationLabels(cr, component),
+		},
+	}
+}
+
 func (r *Reconciler) reconcileReportsNetworkPolicy(ctx context.Context, cr *model.CryostatInstance) error {
 	ingressPolicy := &networkingv1.NetworkPolicy{
 		ObjectMeta: metav1.ObjectMeta{
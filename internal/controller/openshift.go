@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"regexp"
 
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
@@ -28,7 +29,9 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func (r *Reconciler) reconcileOpenShift(ctx context.Context, cr *model.CryostatInstance) error {
@@ -144,3 +147,29 @@ func (r *Reconciler) deleteCorsAllowedOrigins(ctx context.Context, cr *model.Cry
 	}
 	return nil
 }
+
+func (r *Reconciler) mapFromAPIServer() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		if obj.GetName() != apiServerName {
+			return nil
+		}
+		// Find all Cryostat CRs that use the API server's TLS security profile
+		crs := &operatorv1beta2.CryostatList{}
+		err := r.List(ctx, crs)
+		if err != nil {
+			r.Log.Error(err, "Failed to list Cryostats for APIServer event")
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, cr := range crs.Items {
+			if cr.Spec.TLSOptions != nil && cr.Spec.TLSOptions.SecurityProfile != nil {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+			})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"time"
+
+	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common/pki"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Same as the default duration of certificates issued by cert-manager
+const defaultCertificateDuration = 90 * 24 * time.Hour
+
+// setupOperatorTLS issues the CA and certificates for Cryostat components without cert-manager.
+// The certificates are stored in secrets with the same names and keys that cert-manager would use.
+// Certificates are renewed during a later reconcile once a third of their lifetime remains, which
+// is scheduled using the RequeueAt time of the returned TLS configuration.
+func (r *Reconciler) setupOperatorTLS(ctx context.Context, cr *model.CryostatInstance) (*resources.TLSConfig, error) {
+	// Remove any resources previously created for cert-manager
+	if r.IsCertManagerInstalled {
+		err := r.deleteCertManagerResources(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Create or renew the self-signed CA
+	caCert := resources.NewCryostatCACert(r.gvk, cr)
+	caSecret, renewAt, err := r.reconcileOperatorCertificate(ctx, cr, caCert, nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	ca, err := pki.ParseKeyPair(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+	caBytes := ca.CertificatePEM()
+
+	// Publish the trusted CA certificates before any certificates are issued by a new CA
+	bundle, err := r.reconcileCABundle(ctx, cr, caBytes)
+	if err != nil {
+		return nil, err
+	}
+	caBundle := bundle.PEM()
+	err = r.distributeCACertificate(ctx, cr, caBytes, caBundle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create secret to hold keystore password
+	keystoreSecret := newKeystoreSecret(cr)
+	err = r.createOrUpdateKeystoreSecret(ctx, keystoreSecret, cr.Object)
+	if err != nil {
+		return nil, err
+	}
+	keystorePass := getKeystorePassword(keystoreSecret)
+
+	cryostatCert := resources.NewCryostatCert(cr, keystoreSecret.Name)
+	reportsCert := resources.NewReportsCert(cr)
+	databaseCert := resources.NewDatabaseCert(cr)
+	storageCert := resources.NewStorageCert(cr)
+	agentProxyCert := resources.NewAgentProxyCert(cr)
+	certs := []*certv1.Certificate{cryostatCert, reportsCert, storageCert, agentProxyCert}
+	unused := []*certv1.Certificate{}
+	if resources.DeployManagedDatabase(cr) {
+		certs = append(certs, databaseCert)
+	} else {
+		// Remove any certificate for the database, since Cryostat is using an external database
+		unused = append(unused, databaseCert)
+	}
+	if common.IsSidecarTLSEnabled(cr) {
+		certs = append(certs, resources.NewSidecarCerts(cr)...)
+	} else {
+		// Remove any certificates for the Grafana and JFR datasource containers
+		unused = append(unused, resources.NewSidecarCerts(cr)...)
+	}
+	for _, cert := range unused {
+		err = r.deleteSecret(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cert.Spec.SecretName,
+				Namespace: cert.Namespace,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, cert.Name)
+	}
+	for _, cert := range certs {
+		_, certRenewAt, err := r.reconcileOperatorCertificate(ctx, cr, cert, ca, caBundle, keystorePass)
+		if err != nil {
+			return nil, err
+		}
+		renewAt = earliest(renewAt, certRenewAt)
+	}
+
+	tlsConfig := &resources.TLSConfig{
+		CryostatSecret:     cryostatCert.Spec.SecretName,
+		StorageSecret:      storageCert.Spec.SecretName,
+		ReportsSecret:      reportsCert.Spec.SecretName,
+		AgentProxySecret:   agentProxyCert.Spec.SecretName,
+		KeystorePassSecret: keystoreSecret.Name,
+		CABundleSecret:     newCABundleSecret(cr).Name,
+		CABundle:           caBundle,
+	}
+	if resources.DeployManagedDatabase(cr) {
+		tlsConfig.DatabaseSecret = databaseCert.Spec.SecretName
+	}
+	if common.IsSidecarTLSEnabled(cr) {
+		tlsConfig.GrafanaSecret = resources.NewGrafanaCert(cr).Spec.SecretName
+		tlsConfig.DatasourceSecret = resources.NewDatasourceCert(cr).Spec.SecretName
+	}
+
+	for _, ns := range cr.TargetNamespaces {
+		// Create a certificate for Cryostat agents in each target namespace
+		agentCert := resources.NewAgentCert(cr, ns, r.gvk)
+		agentSecret, certRenewAt, err := r.reconcileOperatorCertificate(ctx, cr, agentCert, ca, caBundle, "")
+		if err != nil {
+			return nil, err
+		}
+		renewAt = earliest(renewAt, certRenewAt)
+
+		// Copy the agent certificate secret into each target namespace
+		if ns != cr.InstallNamespace {
+			err = r.copyAgentCertSecret(ctx, cr, agentSecret, ns, caBundle)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Certificates signed by the previous CA were reissued above
+	setCARotationCondition(cr, bundle, nil)
+
+	// Clean up resources from target namespaces that are no longer requested
+	for _, ns := range toDelete(cr) {
+		if ns != cr.InstallNamespace {
+			err = r.deleteTargetNamespaceSecrets(ctx, cr, ns)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		agentCert := resources.NewAgentCert(cr, ns, r.gvk)
+		agentSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentCert.Spec.SecretName,
+				Namespace: agentCert.Namespace,
+			},
+		}
+		err = r.deleteSecret(ctx, agentSecret)
+		if err != nil {
+			return nil, err
+		}
+		metrics.DeleteCertificateExpiry(cr.InstallNamespace, cr.Name, agentCert.Name)
+	}
+
+	// Reconcile again to renew the certificates, including the keystore, before they expire,
+	// and to stop trusting the previous CA once the grace period ends
+	tlsConfig.RequeueAt = earliest(renewAt, bundle.previousTrustedUntil)
+	return tlsConfig, nil
+}
+
+// reconcileOperatorCertificate issues the certificate described by the cert-manager Certificate into
+// its secret, unless the secret already contains a certificate that does not need to be reissued.
+// The certificate is signed by the provided CA, or is self-signed if the CA is nil. The "ca.crt" key
+// contains the bundle of trusted CA certificates, so that peers signed by a previous CA remain trusted.
+// Also returns when the certificate should be renewed.
+func (r *Reconciler) reconcileOperatorCertificate(ctx context.Context, cr *model.CryostatInstance,
+	cert *certv1.Certificate, ca *pki.KeyPair, caBundle []byte, keystorePass string) (*corev1.Secret, time.Time, error) {
+	request := newCertificateRequest(cert)
+	var renewAt time.Time
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cert.Spec.SecretName,
+			Namespace: cert.Namespace,
+		},
+	}
+	err := r.createOrUpdateSecret(ctx, secret, cr.Object, func() error {
+		keyPair, err := pki.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		reissue := err != nil || keyPair.NeedsReissue(request, ca, getRenewBefore(cert))
+		if reissue {
+			r.Log.Info("Issuing certificate", "name", cert.Name, "namespace", cert.Namespace)
+			if keyPair != nil && cert.Spec.PrivateKey != nil && cert.Spec.PrivateKey.RotationPolicy == certv1.RotationPolicyNever {
+				// Reuse the existing private key, if it still matches the requested algorithm and size
+				request.PrivateKey = keyPair.PrivateKey
+			}
+			if ca == nil {
+				keyPair, err = pki.NewSelfSignedCA(request)
+			} else {
+				keyPair, err = ca.Issue(request)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		keyBytes, err := keyPair.PrivateKeyPEM()
+		if err != nil {
+			return err
+		}
+		caBytes := keyPair.CertificatePEM()
+		if ca != nil {
+			caBytes = caBundle
+		}
+		data := map[string][]byte{
+			corev1.TLSCertKey:       keyPair.CertificatePEM(),
+			corev1.TLSPrivateKeyKey: keyBytes,
+			constants.CAKey:         caBytes,
+		}
+
+		// Keep the existing keystore unless the certificate changed, since encoding is not deterministic
+		if cert.Spec.Keystores != nil && cert.Spec.Keystores.PKCS12 != nil && cert.Spec.Keystores.PKCS12.Create {
+			keystore := secret.Data[constants.KeyStoreFile]
+			if reissue || len(keystore) == 0 {
+				keystore, err = keyPair.EncodePKCS12(keystorePass, ca.Certificate)
+				if err != nil {
+					return err
+				}
+			}
+			data[constants.KeyStoreFile] = keystore
+		}
+
+		if secret.CreationTimestamp.IsZero() {
+			secret.Type = corev1.SecretTypeTLS
+		}
+		secret.Data = data
+		metrics.SetCertificateExpiry(cr.InstallNamespace, cr.Name, cert.Name, keyPair.Certificate.NotAfter)
+		renewAt = keyPair.RenewalTime(getRenewBefore(cert))
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return secret, renewAt, nil
+}
+
+// deleteCertManagerResources deletes any certificates and issuers previously created for cert-manager,
+// along with the certificate secrets, so that they can be replaced by those issued by the operator
+func (r *Reconciler) deleteCertManagerResources(ctx context.Context, cr *model.CryostatInstance) error {
+	certs := []*certv1.Certificate{
+		resources.NewCryostatCACert(r.gvk, cr),
+		resources.NewCryostatCert(cr, newKeystoreSecret(cr).Name),
+		resources.NewReportsCert(cr),
+		resources.NewDatabaseCert(cr),
+		resources.NewStorageCert(cr),
+		resources.NewAgentProxyCert(cr),
+	}
+	certs = append(certs, resources.NewSidecarCerts(cr)...)
+	for _, ns := range cr.TargetNamespaces {
+		certs = append(certs, resources.NewAgentCert(cr, ns, r.gvk))
+	}
+	for _, cert := range certs {
+		// Only delete the secret if cert-manager issued it, since it now holds our certificate otherwise
+		err := r.Get(ctx, types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, &certv1.Certificate{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		err = r.deleteCertWithSecret(ctx, cert)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := r.deleteCAIssuers(ctx, cr)
+	if err != nil {
+		return err
+	}
+	// Remove any CA certificate previously copied from a user-provided issuer
+	return r.deleteIssuerCASecret(ctx, cr, resources.NewCryostatCACert(r.gvk, cr))
+}
+
+func newCertificateRequest(cert *certv1.Certificate) *pki.CertificateRequest {
+	duration := defaultCertificateDuration
+	if cert.Spec.Duration != nil {
+		duration = cert.Spec.Duration.Duration
+	}
+	extKeyUsages := []x509.ExtKeyUsage{}
+	for _, usage := range cert.Spec.Usages {
+		switch usage {
+		case certv1.UsageServerAuth:
+			extKeyUsages = append(extKeyUsages, x509.ExtKeyUsageServerAuth)
+		case certv1.UsageClientAuth:
+			extKeyUsages = append(extKeyUsages, x509.ExtKeyUsageClientAuth)
+		}
+	}
+	ipAddresses := []net.IP{}
+	for _, addr := range cert.Spec.IPAddresses {
+		if ip := net.ParseIP(addr); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		}
+	}
+	request := &pki.CertificateRequest{
+		CommonName:   cert.Spec.CommonName,
+		DNSNames:     cert.Spec.DNSNames,
+		IPAddresses:  ipAddresses,
+		IsCA:         cert.Spec.IsCA,
+		ExtKeyUsages: extKeyUsages,
+		Duration:     duration,
+	}
+	if cert.Spec.PrivateKey != nil {
+		request.KeyAlgorithm = pki.KeyAlgorithm(cert.Spec.PrivateKey.Algorithm)
+		request.KeySize = cert.Spec.PrivateKey.Size
+	}
+	return request
+}
+
+func getRenewBefore(cert *certv1.Certificate) time.Duration {
+	if cert.Spec.RenewBefore != nil {
+		return cert.Spec.RenewBefore.Duration
+	}
+	// Renew once two thirds of the certificate's lifetime has passed, like cert-manager
+	return newCertificateRequest(cert).Duration / 3
+}
+
+// earliest returns the earlier of two times, ignoring zero times
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+func getKeystorePassword(secret *corev1.Secret) string {
+	if pass, ok := secret.Data[constants.KeystorePassSecretKey]; ok {
+		return string(pass)
+	}
+	// The secret was just created, so its data may not have been populated from its string data
+	return secret.StringData[constants.KeystorePassSecretKey]
+}
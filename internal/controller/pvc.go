@@ -16,66 +16,173 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"slices"
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	// Event type to inform users of invalid PVC specs
 	eventPersistentVolumeClaimInvalidType = "PersistentVolumeClaimInvalid"
-	mib                                   = 1024 * 1024
-	gib                                   = 1024 * mib
-	DefaultDatabasePVCSize                = 500 * mib
-	DefaultStoragePVCSize                 = 32 * gib
+	// Event type to inform users of the progress of a volume migration
+	eventPersistentVolumeClaimMigrationType = "PersistentVolumeClaimMigration"
+	// Event type to inform users that a volume migration completed
+	eventPersistentVolumeClaimMigratedType = "PersistentVolumeClaimMigrated"
+	mib                                    = 1024 * 1024
+	gib                                    = 1024 * mib
+	DefaultDatabasePVCSize                 = 500 * mib
+	DefaultStoragePVCSize                  = 32 * gib
+
+	reasonVolumePending                 = "Pending"
+	reasonVolumeResizing                = "Resizing"
+	reasonVolumeFileSystemResizePending = "FileSystemResizePending"
+	reasonVolumeMigrating               = "Migrating"
+	reasonVolumeCopyFailed              = "MigrationFailed"
+	reasonVolumeMigrationInProgress     = "VolumeMigrationInProgress"
+	reasonVolumeMigrationFailed         = "VolumeMigrationFailed"
+	reasonVolumeDataLossNotConfirmed    = "DataLossNotConfirmed"
+
+	// Annotation on the Cryostat confirming that the contents of an emptyDir volume that cannot
+	// be copied may be discarded when switching to a PersistentVolumeClaim
+	annotationDiscardEmptyDirData = "operator.cryostat.io/discard-emptydir-data"
+	// Annotation on a StorageClass marking it as the cluster's default
+	annotationDefaultStorageClass = "storageclass.kubernetes.io/is-default-class"
 )
 
-func (r *Reconciler) reconcilePVC(ctx context.Context, cr *model.CryostatInstance, storageConfiguration *operatorv1beta2.StorageConfiguration, defaultSize resource.Quantity, nameSuffix *string) error {
-	emptyDir := storageConfiguration != nil && storageConfiguration.EmptyDir != nil && storageConfiguration.EmptyDir.Enabled
+// ErrVolumeMigrationInProgress is returned while data is being copied to a new volume
+var ErrVolumeMigrationInProgress = errors.New("waiting for data to be copied to a new volume")
+
+// volumeMigrationError indicates that the migration Job failed
+type volumeMigrationError struct {
+	jobName string
+	message string
+}
+
+func (e *volumeMigrationError) Error() string {
+	return fmt.Sprintf("failed to copy data to a new volume using Job %s: %s", e.jobName, e.message)
+}
+
+func isVolumeMigrationError(err error) bool {
+	var migrationErr *volumeMigrationError
+	return errors.As(err, &migrationErr)
+}
+
+// volumeDataLossError indicates that switching a component's volume would discard its contents,
+// which the user has not confirmed
+type volumeDataLossError struct {
+	component string
+}
+
+func (e *volumeDataLossError) Error() string {
+	return fmt.Sprintf("switching the %s volume from emptyDir to a PersistentVolumeClaim discards its contents, which cannot be copied. "+
+		"Annotate the Cryostat with %s=true to confirm, or revert the configuration", e.component, annotationDiscardEmptyDirData)
+}
+
+func isVolumeDataLossError(err error) bool {
+	var dataLossErr *volumeDataLossError
+	return errors.As(err, &dataLossErr)
+}
+
+// volumeOptions describes the volume of a component backed by a PersistentVolumeClaim
+type volumeOptions struct {
+	component   string
+	config      *operatorv1beta2.StorageConfiguration
+	defaultSize resource.Quantity
+	condition   operatorv1beta2.CryostatConditionType
+}
+
+// reconcilePVC ensures the PersistentVolumeClaim used by a component matches its configuration.
+// Resource requests are expanded in place when the storage class allows it. Other changes
+// are applied by copying the data to a new PersistentVolumeClaim using a Job. While the Job
+// is running, reconcilePVC returns true and the component's Deployment must not be updated.
+func (r *Reconciler) reconcilePVC(ctx context.Context, cr *model.CryostatInstance, vol *volumeOptions,
+	tls *resources.TLSConfig, imageTags *resources.ImageTags, fsGroup int64) (bool, error) {
+	emptyDir := vol.config != nil && vol.config.EmptyDir != nil && vol.config.EmptyDir.Enabled
 	if emptyDir {
 		// If user requested an emptyDir volume, then do nothing.
 		// Don't delete the PVC to prevent accidental data loss
 		// depending on the reclaim policy.
-		return nil
-	}
-	var name string
-	if nameSuffix == nil {
-		name = cr.Name
-	} else {
-		name = fmt.Sprintf("%s-%s", cr.Name, *nameSuffix)
-	}
-	pvc := &corev1.PersistentVolumeClaim{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: cr.InstallNamespace,
-		},
+		removeVolumeStatus(cr, vol.component)
+		removeConditionIfPresent(cr, vol.condition)
+		return false, r.cleanUpVolumeMigration(ctx, cr, vol, "")
 	}
 
 	// Look up PVC configuration, applying defaults where needed
-	config := configurePVC(cr.Name, storageConfiguration, defaultSize)
+	config := configurePVC(cr.Name, vol.config, vol.defaultSize)
 
-	err := r.createOrUpdatePVC(ctx, pvc, cr.Object, config)
+	// The component's Deployment determines which volume holds its data
+	current, err := r.getDeploymentVolume(ctx, cr, vol.component)
 	if err != nil {
-		// If the API server says the PVC is invalid, emit a warning event
-		// to inform the user.
-		if kerrors.IsInvalid(err) {
-			r.EventRecorder.Event(cr.Object, corev1.EventTypeWarning, eventPersistentVolumeClaimInvalidType, err.Error())
+		return false, err
+	}
+	if current == nil {
+		// Nothing has been stored yet
+		return false, r.reconcileActivePVC(ctx, cr, vol, resources.GetVolumeClaimName(cr, vol.component), config)
+	}
+	if current.PersistentVolumeClaim == nil {
+		if vol.component == resources.VolumeComponentStorage {
+			// Recordings in the emptyDir volume are only accessible through the storage container,
+			// so keep using it until the user accepts that they are lost
+			if cr.Object.GetAnnotations()[annotationDiscardEmptyDirData] != "true" {
+				dataLossErr := &volumeDataLossError{component: vol.component}
+				setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumeDataLossNotConfirmed,
+					dataLossErr.Error()+".")
+				return false, dataLossErr
+			}
+			r.EventRecorder.Eventf(cr.Object, corev1.EventTypeWarning, eventPersistentVolumeClaimMigrationType,
+				"Switching the %s volume from emptyDir to a PersistentVolumeClaim, the contents of the emptyDir volume are not copied",
+				vol.component)
+			removeVolumeStatus(cr, vol.component)
+			return false, r.reconcileActivePVC(ctx, cr, vol, resources.GetVolumeClaimName(cr, vol.component), config)
 		}
-		return err
+		// The database can be copied from the running database server
+		return r.migrateVolume(ctx, cr, vol, "", config, "the volume is switching from emptyDir to a PersistentVolumeClaim",
+			tls, imageTags, fsGroup)
 	}
-	return nil
+
+	source := current.PersistentVolumeClaim.ClaimName
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = r.Get(ctx, types.NamespacedName{Name: source, Namespace: cr.InstallNamespace}, pvc)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			// The claim was deleted, so there is nothing to copy
+			return false, r.reconcileActivePVC(ctx, cr, vol, source, config)
+		}
+		return false, err
+	}
+	reason, err := r.volumeMigrationReason(ctx, pvc, config)
+	if err != nil {
+		return false, err
+	}
+	if len(reason) > 0 {
+		return r.migrateVolume(ctx, cr, vol, source, config, reason, tls, imageTags, fsGroup)
+	}
+
+	err = r.cleanUpVolumeMigration(ctx, cr, vol, source)
+	if err != nil {
+		return false, err
+	}
+	return false, r.reconcileActivePVC(ctx, cr, vol, source, config)
 }
 
-func (r *Reconciler) reconcileDatabasePVC(ctx context.Context, cr *model.CryostatInstance) error {
-	name := "database"
+func (r *Reconciler) reconcileDatabasePVC(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, fsGroup int64) (bool, error) {
 	var cfg *operatorv1beta2.StorageConfiguration
 	if cr.Spec.StorageOptions != nil {
 		cfg = cr.Spec.StorageOptions.Database
@@ -83,11 +190,24 @@ func (r *Reconciler) reconcileDatabasePVC(ctx context.Context, cr *model.Cryosta
 			cfg = (*operatorv1beta2.StorageConfiguration)(&cr.Spec.StorageOptions.LegacyStorageConfiguration)
 		}
 	}
-	return r.reconcilePVC(ctx, cr, cfg, *resource.NewQuantity(DefaultDatabasePVCSize, resource.BinarySI), &name)
+	if !resources.DeployManagedDatabase(cr) {
+		// If using an external database, do nothing.
+		// Don't delete the PVC, since it may still contain data
+		// the user wants to migrate to the external database.
+		removeVolumeStatus(cr, resources.VolumeComponentDatabase)
+		removeConditionIfPresent(cr, operatorv1beta2.ConditionTypeDatabaseVolumeReady)
+		return false, nil
+	}
+	return r.reconcilePVC(ctx, cr, &volumeOptions{
+		component:   resources.VolumeComponentDatabase,
+		config:      cfg,
+		defaultSize: *resource.NewQuantity(DefaultDatabasePVCSize, resource.BinarySI),
+		condition:   operatorv1beta2.ConditionTypeDatabaseVolumeReady,
+	}, tls, imageTags, fsGroup)
 }
 
-func (r *Reconciler) reconcileStoragePVC(ctx context.Context, cr *model.CryostatInstance) error {
-	name := "storage"
+func (r *Reconciler) reconcileStoragePVC(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, fsGroup int64) (bool, error) {
 	var cfg *operatorv1beta2.StorageConfiguration
 	if cr.Spec.StorageOptions != nil {
 		cfg = cr.Spec.StorageOptions.ObjectStorage
@@ -102,9 +222,419 @@ func (r *Reconciler) reconcileStoragePVC(ctx context.Context, cr *model.Cryostat
 		// depending on the reclaim policy. The user may be transitioning
 		// from a managed cryostat-storage instance to external storage,
 		// but the pre-existing cryostat-storage PVC may still contain data the user wants to retain.
+		removeVolumeStatus(cr, resources.VolumeComponentStorage)
+		removeConditionIfPresent(cr, operatorv1beta2.ConditionTypeStorageVolumeReady)
+		return false, nil
+	}
+	return r.reconcilePVC(ctx, cr, &volumeOptions{
+		component:   resources.VolumeComponentStorage,
+		config:      cfg,
+		defaultSize: *resource.NewQuantity(DefaultStoragePVCSize, resource.BinarySI),
+		condition:   operatorv1beta2.ConditionTypeStorageVolumeReady,
+	}, tls, imageTags, fsGroup)
+}
+
+// reconcileActivePVC creates or updates the PersistentVolumeClaim used by the component,
+// and reports its status
+func (r *Reconciler) reconcileActivePVC(ctx context.Context, cr *model.CryostatInstance, vol *volumeOptions,
+	name string, config *operatorv1beta2.PersistentVolumeClaimConfig) error {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	err := r.createOrUpdatePVC(ctx, pvc, cr.Object, config)
+	if err != nil {
+		// If the API server says the PVC is invalid, emit a warning event
+		// to inform the user.
+		if kerrors.IsInvalid(err) {
+			r.EventRecorder.Event(cr.Object, corev1.EventTypeWarning, eventPersistentVolumeClaimInvalidType, err.Error())
+		}
+		return err
+	}
+
+	status := setVolumeStatus(cr, vol.component)
+	status.ClaimName = name
+	status.MigrationTarget = ""
+	status.StoppedReplicas = nil
+	status.Requested = pvc.Spec.Resources.Requests.Storage()
+	status.Capacity = nil
+	if capacity, pres := pvc.Status.Capacity[corev1.ResourceStorage]; pres {
+		status.Capacity = &capacity
+	}
+
+	switch {
+	case pvc.Status.Phase != corev1.ClaimBound:
+		setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumePending,
+			fmt.Sprintf("Waiting for PersistentVolumeClaim %s to be bound.", name))
+	case hasPVCCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending):
+		setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumeFileSystemResizePending,
+			fmt.Sprintf("Waiting for the file system of PersistentVolumeClaim %s to be resized to %s.",
+				name, status.Requested.String()))
+	case hasPVCCondition(pvc, corev1.PersistentVolumeClaimResizing) ||
+		(status.Capacity != nil && status.Capacity.Cmp(*status.Requested) < 0):
+		setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumeResizing,
+			fmt.Sprintf("Waiting for PersistentVolumeClaim %s to be resized to %s.", name, status.Requested.String()))
+	default:
+		r.setStageReady(cr, vol.condition, fmt.Sprintf("PersistentVolumeClaim %s is bound.", name))
+	}
+	return nil
+}
+
+// volumeMigrationReason describes why the existing PersistentVolumeClaim cannot be updated to
+// match the configuration. It returns an empty string if it can be updated in place.
+func (r *Reconciler) volumeMigrationReason(ctx context.Context, pvc *corev1.PersistentVolumeClaim,
+	config *operatorv1beta2.PersistentVolumeClaimConfig) (string, error) {
+	if config.Spec.StorageClassName != nil &&
+		(pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != *config.Spec.StorageClassName) {
+		return fmt.Sprintf("the storage class changed to %s", *config.Spec.StorageClassName), nil
+	}
+	if !sameAccessModes(pvc.Spec.AccessModes, config.Spec.AccessModes) {
+		return "the access modes changed", nil
+	}
+	if config.Spec.VolumeMode != nil &&
+		(pvc.Spec.VolumeMode == nil || *pvc.Spec.VolumeMode != *config.Spec.VolumeMode) {
+		return fmt.Sprintf("the volume mode changed to %s", *config.Spec.VolumeMode), nil
+	}
+
+	requested := config.Spec.Resources.Requests.Storage()
+	current := pvc.Spec.Resources.Requests.Storage()
+	switch requested.Cmp(*current) {
+	case -1:
+		return fmt.Sprintf("the requested storage of %s is smaller than %s", requested.String(), current.String()), nil
+	case 1:
+		expandable, err := r.storageClassAllowsExpansion(ctx, pvc.Spec.StorageClassName)
+		if err != nil {
+			return "", err
+		}
+		if !expandable {
+			return fmt.Sprintf("the storage class does not allow expanding the volume to %s", requested.String()), nil
+		}
+	}
+	return "", nil
+}
+
+// storageClassAllowsExpansion returns whether volumes of the storage class can be expanded.
+// Without a storage class name, the cluster's default storage class is used.
+func (r *Reconciler) storageClassAllowsExpansion(ctx context.Context, name *string) (bool, error) {
+	var storageClass *storagev1.StorageClass
+	if name == nil {
+		defaultClass, err := r.getDefaultStorageClass(ctx)
+		if err != nil {
+			return false, err
+		}
+		storageClass = defaultClass
+	} else if len(*name) > 0 {
+		storageClass = &storagev1.StorageClass{}
+		err := r.Get(ctx, types.NamespacedName{Name: *name}, storageClass)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	// An empty storage class name requests a volume without a storage class
+	return storageClass != nil && storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
+// getDefaultStorageClass returns the cluster's default storage class, or nil if there is none.
+// If several are marked as the default, the most recently created is used, as Kubernetes does.
+func (r *Reconciler) getDefaultStorageClass(ctx context.Context) (*storagev1.StorageClass, error) {
+	storageClasses := &storagev1.StorageClassList{}
+	err := r.List(ctx, storageClasses)
+	if err != nil {
+		return nil, err
+	}
+	var result *storagev1.StorageClass
+	for i, storageClass := range storageClasses.Items {
+		if storageClass.Annotations[annotationDefaultStorageClass] != "true" {
+			continue
+		}
+		if result == nil || storageClass.CreationTimestamp.After(result.CreationTimestamp.Time) {
+			result = &storageClasses.Items[i]
+		}
+	}
+	return result, nil
+}
+
+// migrateVolume copies the component's data from the source PersistentVolumeClaim to a new
+// one matching the configuration. An empty source copies the database from the running
+// database server. Returns true until the component can switch to the new volume.
+func (r *Reconciler) migrateVolume(ctx context.Context, cr *model.CryostatInstance, vol *volumeOptions, source string,
+	config *operatorv1beta2.PersistentVolumeClaimConfig, reason string, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, fsGroup int64) (bool, error) {
+	target := volumeMigrationClaimName(cr, vol.component, source, config)
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target,
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	err := r.createOrUpdatePVC(ctx, pvc, cr.Object, config)
+	if err != nil {
+		if kerrors.IsInvalid(err) {
+			r.EventRecorder.Event(cr.Object, corev1.EventTypeWarning, eventPersistentVolumeClaimInvalidType, err.Error())
+		}
+		return false, err
+	}
+
+	status := setVolumeStatus(cr, vol.component)
+	status.ClaimName = source
+	status.MigrationTarget = target
+	from := source
+	if len(from) == 0 {
+		from = "emptyDir"
+	}
+	setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumeMigrating,
+		fmt.Sprintf("Copying data from %s to PersistentVolumeClaim %s, because %s.", from, target, reason))
+
+	desired := resources.NewJobForVolumeMigration(cr, vol.component, imageTags, tls, r.IsOpenShift, fsGroup, source, target)
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, job)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return false, err
+		}
+		// Stop writes to the data, and release the source volume for the Job. The number of
+		// replicas is recorded, so that they can be restored after the copy.
+		previous, stopped, err := r.scaleDeployment(ctx, cr, volumeWriterDeployment(cr, vol.component, source), 0)
+		if err != nil {
+			return true, err
+		}
+		if status.StoppedReplicas == nil {
+			status.StoppedReplicas = &previous
+		}
+		if !stopped {
+			return true, nil
+		}
+		if err := controllerutil.SetControllerReference(cr.Object, desired, r.Scheme); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return false, err
+		}
+		r.Log.Info("Job created", "name", desired.Name, "namespace", desired.Namespace)
+		r.EventRecorder.Eventf(cr.Object, corev1.EventTypeNormal, eventPersistentVolumeClaimMigrationType,
+			"Copying the %s volume from %s to PersistentVolumeClaim %s, because %s", vol.component, from, target, reason)
+		return true, nil
+	}
+
+	// The Job is immutable, so replace it when the configuration changes during a migration
+	if job.Annotations[resources.VolumeMigrationSourceAnnotation] != source ||
+		job.Annotations[resources.VolumeMigrationTargetAnnotation] != target {
+		return true, r.cleanUpVolumeMigration(ctx, cr, vol, source)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			if len(source) == 0 {
+				// The database was copied from the running server, so Cryostat can resume writing
+				// once the database switches to the new volume. Otherwise the component's
+				// Deployment is scaled up along with that switch.
+				if err := r.restoreStoppedReplicas(ctx, cr, vol, source, status); err != nil {
+					return false, err
+				}
+			}
+			// Switch the component to the new volume
+			status.ClaimName = target
+			status.MigrationTarget = ""
+			if len(source) > 0 {
+				r.EventRecorder.Eventf(cr.Object, corev1.EventTypeNormal, eventPersistentVolumeClaimMigratedType,
+					"Copied the %s volume to PersistentVolumeClaim %s. PersistentVolumeClaim %s was retained and may be deleted once the data is verified.",
+					vol.component, target, source)
+			} else {
+				r.EventRecorder.Eventf(cr.Object, corev1.EventTypeNormal, eventPersistentVolumeClaimMigratedType,
+					"Copied the %s volume to PersistentVolumeClaim %s", vol.component, target)
+			}
+			err := r.cleanUpVolumeMigration(ctx, cr, vol, target)
+			if err != nil {
+				return false, err
+			}
+			return false, r.reconcileActivePVC(ctx, cr, vol, target, config)
+		case batchv1.JobFailed:
+			// Resume using the source volume until the user intervenes
+			if err := r.restoreStoppedReplicas(ctx, cr, vol, source, status); err != nil {
+				return false, err
+			}
+			migrationErr := &volumeMigrationError{jobName: job.Name, message: condition.Message}
+			setCondition(cr, vol.condition, metav1.ConditionFalse, reasonVolumeCopyFailed,
+				fmt.Sprintf("%s. Delete the Job to try again, or revert the configuration.", migrationErr.Error()))
+			return false, migrationErr
+		}
+	}
+	return true, nil
+}
+
+// cleanUpVolumeMigration deletes any migration Job for the component. A PersistentVolumeClaim
+// that was created for an unfinished migration is also deleted, since its contents are incomplete.
+func (r *Reconciler) cleanUpVolumeMigration(ctx context.Context, cr *model.CryostatInstance, vol *volumeOptions, active string) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-%s-migration", cr.Name, vol.component),
+		Namespace: cr.InstallNamespace}, job)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	err = r.deleteJob(ctx, job)
+	if err != nil {
+		return err
+	}
+	target := job.Annotations[resources.VolumeMigrationTargetAnnotation]
+	if len(target) == 0 || target == active || target == resources.GetVolumeClaimName(cr, vol.component) ||
+		hasJobCondition(job, batchv1.JobComplete) {
 		return nil
 	}
-	return r.reconcilePVC(ctx, cr, cfg, *resource.NewQuantity(DefaultStoragePVCSize, resource.BinarySI), &name)
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target,
+			Namespace: cr.InstallNamespace,
+		},
+	}
+	err = r.Delete(ctx, pvc)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return err
+	}
+	r.Log.Info("Persistent Volume Claim deleted", "name", pvc.Name, "namespace", pvc.Namespace)
+	return nil
+}
+
+// getDeploymentVolume returns the data volume of the component's Deployment, if it exists
+func (r *Reconciler) getDeploymentVolume(ctx context.Context, cr *model.CryostatInstance, component string) (*corev1.Volume, error) {
+	deploy := &appsv1.Deployment{}
+	name := fmt.Sprintf("%s-%s", cr.Name, component)
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.InstallNamespace}, deploy)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for i, volume := range deploy.Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			return &deploy.Spec.Template.Spec.Volumes[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// restoreStoppedReplicas scales the Deployment that was stopped for a migration back to its recorded replicas
+func (r *Reconciler) restoreStoppedReplicas(ctx context.Context, cr *model.CryostatInstance, vol *volumeOptions,
+	source string, status *operatorv1beta2.VolumeStatus) error {
+	replicas := int32(1)
+	if status.StoppedReplicas != nil {
+		replicas = *status.StoppedReplicas
+	}
+	_, _, err := r.scaleDeployment(ctx, cr, volumeWriterDeployment(cr, vol.component, source), replicas)
+	return err
+}
+
+// volumeWriterDeployment returns the name of the Deployment that must be stopped while the component's
+// data is copied. Without a source volume, the database is copied from the running database server,
+// so Cryostat is stopped instead.
+func volumeWriterDeployment(cr *model.CryostatInstance, component string, source string) string {
+	if len(source) == 0 {
+		return cr.Name
+	}
+	return fmt.Sprintf("%s-%s", cr.Name, component)
+}
+
+// scaleDeployment sets the replicas of a Deployment, and returns the previous number
+// of replicas and whether its pods have all stopped
+func (r *Reconciler) scaleDeployment(ctx context.Context, cr *model.CryostatInstance, name string,
+	replicas int32) (int32, bool, error) {
+	deploy := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.InstallNamespace}, deploy)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			// Nothing is running
+			return replicas, true, nil
+		}
+		return 0, false, err
+	}
+	// Unset replicas default to 1
+	previous := int32(1)
+	if deploy.Spec.Replicas != nil {
+		previous = *deploy.Spec.Replicas
+	}
+	if previous != replicas {
+		deploy.Spec.Replicas = &replicas
+		err = r.Update(ctx, deploy)
+		if err != nil {
+			return 0, false, err
+		}
+		r.Log.Info("Deployment scaled", "name", deploy.Name, "namespace", deploy.Namespace, "replicas", replicas)
+	}
+	return previous, deploy.Status.Replicas == 0, nil
+}
+
+// volumeMigrationClaimName returns a name for the PersistentVolumeClaim that the component's data
+// is copied to, which is unique for the source and configuration
+func volumeMigrationClaimName(cr *model.CryostatInstance, component string, source string,
+	config *operatorv1beta2.PersistentVolumeClaimConfig) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(source))
+	spec, _ := json.Marshal(config.Spec)
+	hash.Write(spec)
+	return fmt.Sprintf("%s-%s-%x", cr.Name, component, hash.Sum32())
+}
+
+func setVolumeStatus(cr *model.CryostatInstance, component string) *operatorv1beta2.VolumeStatus {
+	if status := resources.GetVolumeStatus(cr, component); status != nil {
+		return status
+	}
+	cr.Status.Volumes = append(cr.Status.Volumes, operatorv1beta2.VolumeStatus{Component: component})
+	return &cr.Status.Volumes[len(cr.Status.Volumes)-1]
+}
+
+func removeVolumeStatus(cr *model.CryostatInstance, component string) {
+	for i, status := range cr.Status.Volumes {
+		if status.Component == component {
+			cr.Status.Volumes = append(cr.Status.Volumes[:i], cr.Status.Volumes[i+1:]...)
+			return
+		}
+	}
+}
+
+func hasPVCCondition(pvc *corev1.PersistentVolumeClaim, condType corev1.PersistentVolumeClaimConditionType) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == condType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJobCondition(job *batchv1.Job, condType batchv1.JobConditionType) bool {
+	return findJobCondition(job, condType) != nil
+}
+
+func findJobCondition(job *batchv1.Job, condType batchv1.JobConditionType) *batchv1.JobCondition {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == condType && condition.Status == corev1.ConditionTrue {
+			return &condition
+		}
+	}
+	return nil
+}
+
+func sameAccessModes(a []corev1.PersistentVolumeAccessMode, b []corev1.PersistentVolumeAccessMode) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, mode := range a {
+		if !slices.Contains(b, mode) {
+			return false
+		}
+	}
+	return true
 }
 
 func (r *Reconciler) createOrUpdatePVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim,
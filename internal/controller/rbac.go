@@ -75,6 +75,12 @@ func (r *Reconciler) reconcileServiceAccount(ctx context.Context, cr *model.Cryo
 		"app": cr.Name,
 	}
 	annotations := map[string]string{}
+	// Workload identity integrations may require annotations to associate the service account with a cloud identity
+	if cr.Spec.ObjectStorageOptions != nil && cr.Spec.ObjectStorageOptions.Credentials != nil {
+		for key, value := range cr.Spec.ObjectStorageOptions.Credentials.ServiceAccountAnnotations {
+			annotations[key] = value
+		}
+	}
 	// If running on OpenShift, set the route reference as an annotation.
 	// This will tell OpenShift's OAuth to redirect to the route when
 	// this Service Account is used as an OAuth client.
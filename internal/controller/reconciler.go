@@ -28,12 +28,15 @@ import (
 	common "github.com/cryostatio/cryostat-operator/internal/controller/common"
 	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
+	configv1 "github.com/openshift/api/config/v1"
 	openshiftv1 "github.com/openshift/api/route/v1"
 	securityv1 "github.com/openshift/api/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -45,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -65,6 +69,9 @@ type ReconcilerConfig struct {
 	InsightsProxy          *url.URL // Only defined if Insights is enabled
 	FIPSEnabled            bool
 	NewControllerBuilder   func(ctrl.Manager) common.ControllerBuilder
+	// Cache of the pods labelled to use a Cryostat, kept separate from the manager's cache
+	// so that other pods can still be read through the client
+	AgentPodCache cache.Cache
 	common.ReconcilerTLS
 	common.OSUtils
 }
@@ -123,9 +130,15 @@ const apiServerName = "cluster"
 // Reasons for Cryostat Conditions
 const (
 	reasonWaitingForCert         = "WaitingForCertificate"
+	reasonCertificateTimeout     = "CertificateReadyTimeout"
 	reasonAllCertsReady          = "AllCertificatesReady"
 	reasonCertManagerUnavailable = "CertManagerUnavailable"
 	reasonCertManagerDisabled    = "CertManagerDisabled"
+	reasonReconciled             = "Reconciled"
+	reasonReconcileFailed        = "ReconcileFailed"
+	reasonIngressNotReady        = "IngressNotReady"
+	reasonWaitingForCondition    = "WaitingForCondition"
+	reasonAllComponentsReady     = "AllComponentsReady"
 )
 
 // Map Cryostat conditions to deployment conditions
@@ -152,6 +165,30 @@ var reportsDeploymentConditions = deploymentConditionTypeMap{
 	operatorv1beta2.ConditionTypeReportsDeploymentReplicaFailure: appsv1.DeploymentReplicaFailure,
 }
 
+// Conditions that must be true for the Cryostat to be considered ready, in the order they are checked
+var requiredReadyConditions = []operatorv1beta2.CryostatConditionType{
+	operatorv1beta2.ConditionTypeTargetNamespacesReady,
+	operatorv1beta2.ConditionTypeLockReady,
+	operatorv1beta2.ConditionTypeSecretsReady,
+	operatorv1beta2.ConditionTypeRBACReady,
+	operatorv1beta2.ConditionTypeTLSSetupComplete,
+	operatorv1beta2.ConditionTypeNetworkReady,
+	operatorv1beta2.ConditionTypeAgentGatewayReady,
+	operatorv1beta2.ConditionTypeStorageReady,
+	operatorv1beta2.ConditionTypeReportsReady,
+	operatorv1beta2.ConditionTypeCoreReady,
+	operatorv1beta2.ConditionTypeMainDeploymentAvailable,
+}
+
+// Conditions that must be true for the Cryostat to be considered ready, if present
+var optionalReadyConditions = []operatorv1beta2.CryostatConditionType{
+	operatorv1beta2.ConditionTypeDatabaseDeploymentAvailable,
+	operatorv1beta2.ConditionTypeStorageDeploymentAvailable,
+	operatorv1beta2.ConditionTypeReportsDeploymentAvailable,
+	operatorv1beta2.ConditionTypeDatabaseBackupReady,
+	operatorv1beta2.ConditionTypeStorageRetentionReady,
+}
+
 func newReconciler(config *ReconcilerConfig, objType client.Object, isNamespaced bool) (*Reconciler, error) {
 	gvk, err := apiutil.GVKForObject(objType, config.Scheme)
 	if err != nil {
@@ -170,6 +207,15 @@ func newReconciler(config *ReconcilerConfig, objType client.Object, isNamespaced
 
 func (r *Reconciler) reconcileCryostat(ctx context.Context, cr *model.CryostatInstance) (ctrl.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", cr.InstallNamespace, "Request.Name", cr.Name)
+	stages := metrics.NewStageTimer(cr.InstallNamespace, cr.Name)
+
+	// Add any namespaces matching the target namespace selector
+	err := r.resolveTargetNamespaces(ctx, cr)
+	stages.Observe("target_namespaces")
+	if err != nil {
+		reqLogger.Error(err, "Failed to resolve target namespaces")
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeTargetNamespacesReady, err)
+	}
 
 	// Check if this Cryostat is being deleted
 	if cr.Object.GetDeletionTimestamp() != nil {
@@ -191,98 +237,141 @@ func (r *Reconciler) reconcileCryostat(ctx context.Context, cr *model.CryostatIn
 		}
 	}
 
+	// Adding the finalizer replaces the in-memory status, so record the resolved namespaces afterwards
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeTargetNamespacesReady, "The target namespaces have been resolved.")
+
 	// Create lock config map or fail if owned by another CR
-	err := r.reconcileLockConfigMap(ctx, cr)
+	err = r.reconcileLockConfigMap(ctx, cr)
+	stages.Observe("lock_config_map")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeLockReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeLockReady, "This Cryostat holds its lock ConfigMap.")
 
 	err = r.reconcileSecrets(ctx, cr)
+	stages.Observe("secrets")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeSecretsReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeSecretsReady, "All secrets for Cryostat components are ready.")
 
 	// Reconcile RBAC resources for Cryostat
 	err = r.reconcileRBAC(ctx, cr)
+	stages.Observe("rbac")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeRBACReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeRBACReady, "RBAC for Cryostat is ready in all target namespaces.")
 
 	// Set up TLS using cert-manager, if available
 	tlsConfig, err := r.configureTLS(ctx, cr)
+	stages.Observe("tls")
 	if err != nil {
-		if err == common.ErrCertNotReady {
+		var notReady *certsNotReadyError
+		if errors.As(err, &notReady) {
 			// Not an error condition, just retry
-			return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+			return reconcile.Result{RequeueAfter: notReady.retryAfter}, nil
 		}
 		reqLogger.Error(err, "Failed to set up TLS for Cryostat")
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, err)
 	}
 
 	err = r.reconcileOAuth2ProxyConfig(ctx, cr, tlsConfig)
+	stages.Observe("oauth2_proxy_config")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeNetworkReady, err)
 	}
 	err = r.reconcileAgentProxyConfig(ctx, cr, tlsConfig)
+	stages.Observe("agent_proxy_config")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeAgentGatewayReady, err)
 	}
 
 	serviceSpecs := &resources.ServiceSpecs{
 		InsightsURL: r.InsightsProxy,
 	}
 	err = r.reconcileCoreService(ctx, cr, tlsConfig, serviceSpecs)
+	stages.Observe("core_service")
 	if err != nil {
-		return requeueIfIngressNotReady(err)
+		return requeueIfIngressNotReady(r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeNetworkReady, err))
 	}
 	err = r.reconcileCoreNetworkPolicy(ctx, cr)
+	stages.Observe("core_network_policy")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeNetworkReady, err)
 	}
 	err = r.reconcileAgentGatewayService(ctx, cr)
+	stages.Observe("agent_gateway_service")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeAgentGatewayReady, err)
 	}
 	err = r.reconcileAgentCallbackServices(ctx, cr)
+	stages.Observe("agent_callback_services")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeAgentGatewayReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeAgentGatewayReady,
+		"The agent gateway and agent callback services are ready.")
 
 	imageTags := r.getImageTags()
 	fsGroup, err := r.getFSGroup(ctx, cr.InstallNamespace)
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeStorageReady, err)
 	}
 
 	err = r.reconcileDatabase(ctx, reqLogger, cr, tlsConfig, imageTags, serviceSpecs, *fsGroup)
+	stages.Observe("database")
 	if err != nil {
-		return reconcile.Result{}, err
+		return requeueIfStorageInProgress(r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeStorageReady, err))
 	}
 
 	err = r.reconcileStorage(ctx, reqLogger, cr, tlsConfig, imageTags, serviceSpecs, *fsGroup)
+	stages.Observe("storage")
 	if err != nil {
-		return reconcile.Result{}, err
+		return requeueIfStorageInProgress(r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeStorageReady, err))
+	}
+
+	// Restore the database before Cryostat starts using it
+	err = r.reconcileDatabaseBackup(ctx, cr, tlsConfig, imageTags, serviceSpecs, *fsGroup)
+	stages.Observe("database_backup")
+	if err != nil {
+		return requeueIfStorageInProgress(r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeStorageReady, err))
 	}
 
+	err = r.reconcileStorageRetention(ctx, cr, tlsConfig, imageTags, serviceSpecs, *fsGroup)
+	stages.Observe("storage_retention")
+	if err != nil {
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeStorageReady, err)
+	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeStorageReady, "The database and object storage are ready.")
+
 	err = r.reconcileReports(ctx, reqLogger, cr, tlsConfig, imageTags, serviceSpecs)
+	stages.Observe("reports")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeReportsReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeReportsReady, "The reports generator is ready.")
 
 	deployment, err := resources.NewDeploymentForCR(cr, serviceSpecs, imageTags, tlsConfig, *fsGroup, r.IsOpenShift)
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeCoreReady, err)
 	}
 	err = r.createOrUpdateDeployment(ctx, deployment, cr.Object)
+	stages.Observe("main_deployment")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeCoreReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeCoreReady, "The main Cryostat deployment is up to date.")
 
 	// Update CR Status
 	if serviceSpecs.CoreURL != nil {
 		cr.Status.ApplicationURL = serviceSpecs.CoreURL.String()
 	}
 	*cr.TargetNamespaceStatus = cr.TargetNamespaces
+	err = r.restartAgentWorkloads(ctx, cr)
+	if err != nil {
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, err)
+	}
 	err = r.Status().Update(ctx, cr.Object)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -290,9 +379,12 @@ func (r *Reconciler) reconcileCryostat(ctx context.Context, cr *model.CryostatIn
 
 	// OpenShift-specific
 	err = r.reconcileOpenShift(ctx, cr)
+	stages.Observe("openshift")
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, r.reportFailure(ctx, cr, operatorv1beta2.ConditionTypeNetworkReady, err)
 	}
+	r.setStageReady(cr, operatorv1beta2.ConditionTypeNetworkReady,
+		"The services and network policies for Cryostat are ready.")
 
 	// Check deployment status and update conditions
 	err = r.updateConditionsFromDeployment(ctx, cr, types.NamespacedName{Name: deployment.Name, Namespace: deployment.Namespace},
@@ -302,7 +394,7 @@ func (r *Reconciler) reconcileCryostat(ctx context.Context, cr *model.CryostatIn
 	}
 
 	reqLogger.Info("Successfully reconciled Cryostat")
-	return reconcile.Result{}, nil
+	return requeueForTLS(tlsConfig), nil
 }
 
 func (r *Reconciler) setupWithManager(c common.ControllerBuilder, impl reconcile.Reconciler) error {
@@ -310,7 +402,8 @@ func (r *Reconciler) setupWithManager(c common.ControllerBuilder, impl reconcile
 
 	// Watch for changes to secondary resources and requeue the owner Cryostat
 	objTypes := []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ConfigMap{}, &corev1.Secret{},
-		&corev1.PersistentVolumeClaim{}, &corev1.ServiceAccount{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}, &netv1.Ingress{}}
+		&corev1.PersistentVolumeClaim{}, &corev1.ServiceAccount{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}, &netv1.Ingress{},
+		&batchv1.CronJob{}, &batchv1.Job{}}
 	if r.IsOpenShift {
 		objTypes = append(objTypes, &openshiftv1.Route{})
 	}
@@ -329,6 +422,17 @@ func (r *Reconciler) setupWithManager(c common.ControllerBuilder, impl reconcile
 		return err
 	}
 
+	// Watch the API server config to keep the TLS security profile up to date
+	if r.IsOpenShift {
+		c = c.Watches(&configv1.APIServer{}, c.EnqueueRequestsFromMapFunc(r.mapFromAPIServer()))
+	}
+
+	// Watch user-provided certificates for the external host and object storage credentials, since we don't own them
+	c = c.Watches(&corev1.Secret{}, c.EnqueueRequestsFromMapFunc(r.mapFromUserSecret()))
+
+	// Watch namespaces to keep the targets of a namespace selector up to date
+	c = c.Watches(&corev1.Namespace{}, c.EnqueueRequestsFromMapFunc(r.mapFromNamespace()))
+
 	return c.Complete(impl)
 }
 
@@ -352,7 +456,7 @@ func (r *Reconciler) finalizeCryostat(ctx context.Context, cr *model.CryostatIns
 	}
 
 	// Finalizer for certificates and associated secrets
-	if r.IsCertManagerEnabled(cr) {
+	if r.IsTLSEnabled(cr) {
 		err = r.finalizeTLS(ctx, cr)
 		if err != nil {
 			return err
@@ -363,45 +467,55 @@ func (r *Reconciler) finalizeCryostat(ctx context.Context, cr *model.CryostatIns
 	if err != nil {
 		return err
 	}
+
+	// Stop reporting metrics for this Cryostat
+	metrics.DeleteCryostat(cr.InstallNamespace, cr.Name)
 	return nil
 }
 
 func (r *Reconciler) configureTLS(ctx context.Context, cr *model.CryostatInstance) (*resources.TLSConfig, error) {
 	var tlsConfig *resources.TLSConfig
 	var err error
-	if r.IsCertManagerEnabled(cr) {
+	if common.IsOperatorCertificateProvider(cr) {
+		tlsConfig, err = r.setupOperatorTLS(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.setTLSSetupComplete(ctx, cr)
+		if err != nil {
+			return nil, err
+		}
+	} else if r.IsCertManagerEnabled(cr) {
 		tlsConfig, err = r.setupTLS(ctx, cr)
 		if err != nil {
 			if err == common.ErrCertNotReady {
-				condErr := r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
-					reasonWaitingForCert, "Waiting for certificates to become ready.")
-				if condErr != nil {
-					return nil, err
-				}
-
-			}
-			if err == errCertManagerMissing {
-				condErr := r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
-					reasonCertManagerUnavailable, eventCertManagerUnavailableMsg)
-				if condErr != nil {
-					return nil, condErr
-				}
+				// Describe which certificates are pending in the TLSSetupComplete condition
+				return nil, r.reportPendingCertificates(ctx, cr)
 			}
 			return nil, err
 		}
 
-		err = r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-			reasonAllCertsReady, "All certificates for Cryostat components are ready.")
+		err = r.setTLSSetupComplete(ctx, cr)
 		if err != nil {
 			return nil, err
 		}
 	} else {
+		metrics.DeleteAllCertificateExpiry(cr.InstallNamespace, cr.Name)
+		meta.RemoveStatusCondition(&cr.Status.Conditions, string(operatorv1beta2.ConditionTypeCARotationComplete))
 		err = r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
 			reasonCertManagerDisabled, "TLS setup has been disabled.")
 		if err != nil {
 			return nil, err
 		}
 	}
+
+	if tlsConfig != nil {
+		tlsConfig.Profile, err = common.GetTLSProfile(ctx, r.Client, cr, r.IsOpenShift)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return tlsConfig, err
 }
 
@@ -457,7 +571,7 @@ func (r *Reconciler) reconcileReports(ctx context.Context, reqLogger logr.Logger
 func (r *Reconciler) reconcileDatabase(ctx context.Context, reqLogger logr.Logger, cr *model.CryostatInstance, tls *resources.TLSConfig, imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
 	reqLogger.Info("Spec", "Database", cr.Spec.DatabaseOptions)
 
-	err := r.reconcileDatabasePVC(ctx, cr)
+	migrating, err := r.reconcileDatabasePVC(ctx, cr, tls, imageTags, fsGroup)
 	if err != nil {
 		return err
 	}
@@ -469,7 +583,21 @@ func (r *Reconciler) reconcileDatabase(ctx context.Context, reqLogger logr.Logge
 	if err != nil {
 		return err
 	}
+	if migrating {
+		// Keep the deployment on its current volume until the data is copied
+		return ErrVolumeMigrationInProgress
+	}
 	deployment := resources.NewDeploymentForDatabase(cr, imageTags, tls, r.IsOpenShift, fsGroup)
+	if !resources.DeployManagedDatabase(cr) {
+		if err := r.Delete(ctx, deployment); err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+
+		removeConditionIfPresent(cr, operatorv1beta2.ConditionTypeDatabaseDeploymentAvailable,
+			operatorv1beta2.ConditionTypeDatabaseDeploymentProgressing,
+			operatorv1beta2.ConditionTypeDatabaseDeploymentReplicaFailure)
+		return r.Status().Update(ctx, cr.Object)
+	}
 
 	err = r.createOrUpdateDeployment(ctx, deployment, cr.Object)
 	if err != nil {
@@ -489,7 +617,7 @@ func (r *Reconciler) reconcileStorage(ctx context.Context, reqLogger logr.Logger
 	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
 	reqLogger.Info("Spec", "Storage", cr.Spec.StorageOptions)
 
-	err := r.reconcileStoragePVC(ctx, cr)
+	migrating, err := r.reconcileStoragePVC(ctx, cr, tls, imageTags, fsGroup)
 	if err != nil {
 		return err
 	}
@@ -502,6 +630,10 @@ func (r *Reconciler) reconcileStorage(ctx context.Context, reqLogger logr.Logger
 	if err != nil {
 		return err
 	}
+	if migrating {
+		// Keep the deployment on its current volume until the data is copied
+		return ErrVolumeMigrationInProgress
+	}
 
 	deployment := resources.NewDeploymentForStorage(cr, imageTags, tls, r.IsOpenShift, fsGroup)
 	deployManagedStorage := resources.DeployManagedStorage(cr)
@@ -590,12 +722,8 @@ func parseSupGroups(supGroups string) (*int64, error) {
 func (r *Reconciler) updateCondition(ctx context.Context, cr *model.CryostatInstance,
 	condType operatorv1beta2.CryostatConditionType, status metav1.ConditionStatus, reason string, message string) error { // nolint:unparam
 	reqLogger := r.Log.WithValues("Request.Namespace", cr.InstallNamespace, "Request.Name", cr.Name)
-	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
-		Type:    string(condType),
-		Status:  status,
-		Reason:  reason,
-		Message: message,
-	})
+	setCondition(cr, condType, status, reason, message)
+	setReadyCondition(cr)
 	err := r.Status().Update(ctx, cr.Object)
 	if err != nil {
 		reqLogger.Error(err, "failed to update condition", "type", condType)
@@ -620,14 +748,10 @@ func (r *Reconciler) updateConditionsFromDeployment(ctx context.Context, cr *mod
 		if condition == nil {
 			removeConditionIfPresent(cr, condType)
 		} else {
-			meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
-				Type:    string(condType),
-				Status:  metav1.ConditionStatus(condition.Status),
-				Reason:  condition.Reason,
-				Message: condition.Message,
-			})
+			setCondition(cr, condType, metav1.ConditionStatus(condition.Status), condition.Reason, condition.Message)
 		}
 	}
+	setReadyCondition(cr)
 	err = r.Status().Update(ctx, cr.Object)
 	if err != nil {
 		reqLogger.Error(err, "failed to update conditions for deployment", "deployment", deploy.Name)
@@ -635,6 +759,104 @@ func (r *Reconciler) updateConditionsFromDeployment(ctx context.Context, cr *mod
 	return err
 }
 
+// setStageReady marks the condition for a reconcile stage as true. The condition
+// is persisted with the next status update.
+func (r *Reconciler) setStageReady(cr *model.CryostatInstance, condType operatorv1beta2.CryostatConditionType, message string) {
+	setCondition(cr, condType, metav1.ConditionTrue, reasonReconciled, message)
+}
+
+// reportFailure marks the condition for a failed reconcile stage as false using the
+// error that caused the failure, updates the Ready condition, and persists the status.
+// The original error is returned.
+func (r *Reconciler) reportFailure(ctx context.Context, cr *model.CryostatInstance,
+	condType operatorv1beta2.CryostatConditionType, err error) error {
+	reqLogger := r.Log.WithValues("Request.Namespace", cr.InstallNamespace, "Request.Name", cr.Name)
+	setCondition(cr, condType, metav1.ConditionFalse, conditionReasonForError(err), err.Error())
+	if condType != operatorv1beta2.ConditionTypeReady {
+		setReadyCondition(cr)
+	}
+	cr.Status.ObservedGeneration = cr.Object.GetGeneration()
+	statusErr := r.Status().Update(ctx, cr.Object)
+	if statusErr != nil {
+		reqLogger.Error(statusErr, "failed to update condition", "type", condType)
+	}
+	return err
+}
+
+// setReadyCondition updates the Ready condition to summarize the other conditions.
+// The first condition that is not true determines the reason and message.
+func setReadyCondition(cr *model.CryostatInstance) {
+	cr.Status.ObservedGeneration = cr.Object.GetGeneration()
+	for _, condType := range requiredReadyConditions {
+		condition := meta.FindStatusCondition(cr.Status.Conditions, string(condType))
+		if condition == nil {
+			setCondition(cr, operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse, reasonWaitingForCondition,
+				fmt.Sprintf("Waiting for condition %s.", condType))
+			return
+		}
+		if condition.Status != metav1.ConditionTrue {
+			setCondition(cr, operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse, condition.Reason,
+				fmt.Sprintf("%s: %s", condType, condition.Message))
+			return
+		}
+	}
+	for _, condType := range optionalReadyConditions {
+		condition := meta.FindStatusCondition(cr.Status.Conditions, string(condType))
+		if condition != nil && condition.Status != metav1.ConditionTrue {
+			setCondition(cr, operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse, condition.Reason,
+				fmt.Sprintf("%s: %s", condType, condition.Message))
+			return
+		}
+	}
+	setCondition(cr, operatorv1beta2.ConditionTypeReady, metav1.ConditionTrue, reasonAllComponentsReady,
+		"All Cryostat components are ready.")
+}
+
+func setCondition(cr *model.CryostatInstance, condType operatorv1beta2.CryostatConditionType,
+	status metav1.ConditionStatus, reason string, message string) {
+	meta.SetStatusCondition(&cr.Status.Conditions, metav1.Condition{
+		Type:               string(condType),
+		Status:             status,
+		ObservedGeneration: cr.Object.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func conditionReasonForError(err error) string {
+	if err == ErrIngressNotReady {
+		return reasonIngressNotReady
+	}
+	if err == errCertManagerMissing {
+		return reasonCertManagerUnavailable
+	}
+	if isExternalTLSError(err) {
+		return reasonInvalidExternalCertificate
+	}
+	if err == ErrDatabaseRestoreInProgress {
+		return reasonDatabaseRestoreInProgress
+	}
+	if isDatabaseRestoreError(err) {
+		return reasonDatabaseRestoreFailed
+	}
+	if isObjectStorageCredentialsError(err) {
+		return reasonObjectStorageCredentialsUnsupported
+	}
+	if err == ErrVolumeMigrationInProgress {
+		return reasonVolumeMigrationInProgress
+	}
+	if isVolumeMigrationError(err) {
+		return reasonVolumeMigrationFailed
+	}
+	if isVolumeDataLossError(err) {
+		return reasonVolumeDataLossNotConfirmed
+	}
+	if reason := kerrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return reasonReconcileFailed
+}
+
 var errSelectorModified error = errors.New("deployment selector has been modified")
 
 func (r *Reconciler) createOrUpdateDeployment(ctx context.Context, deploy *appsv1.Deployment, owner metav1.Object) error {
@@ -758,6 +980,14 @@ func (r *Reconciler) mapFromTargetNamespace() func(ctx context.Context, obj clie
 	}
 }
 
+// requeueForTLS schedules the next reconcile at the time requested by the TLS configuration, if any
+func requeueForTLS(tlsConfig *resources.TLSConfig) reconcile.Result {
+	if tlsConfig == nil || tlsConfig.RequeueAt.IsZero() {
+		return reconcile.Result{}
+	}
+	return reconcile.Result{RequeueAfter: max(time.Until(tlsConfig.RequeueAt), time.Second)}
+}
+
 func requeueIfIngressNotReady(err error) (reconcile.Result, error) {
 	if err == ErrIngressNotReady {
 		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
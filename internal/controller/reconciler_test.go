@@ -16,12 +16,20 @@ package controller_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
@@ -29,6 +37,7 @@ import (
 	consolev1 "github.com/openshift/api/console/v1"
 	openshiftv1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	netv1 "k8s.io/api/networking/v1"
@@ -37,6 +46,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -54,6 +64,7 @@ import (
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common/pki"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/cryostatio/cryostat-operator/internal/test"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -197,6 +208,28 @@ func expectSuccessful(t **cryostatTestInput) {
 		(*t).checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
 			"AllCertificatesReady")
 	})
+	It("should set reconcile stage conditions", func() {
+		for _, condType := range []operatorv1beta2.CryostatConditionType{
+			operatorv1beta2.ConditionTypeTargetNamespacesReady,
+			operatorv1beta2.ConditionTypeLockReady,
+			operatorv1beta2.ConditionTypeSecretsReady,
+			operatorv1beta2.ConditionTypeRBACReady,
+			operatorv1beta2.ConditionTypeNetworkReady,
+			operatorv1beta2.ConditionTypeAgentGatewayReady,
+			operatorv1beta2.ConditionTypeStorageReady,
+			operatorv1beta2.ConditionTypeReportsReady,
+			operatorv1beta2.ConditionTypeCoreReady,
+		} {
+			(*t).checkConditionPresent(condType, metav1.ConditionTrue, "Reconciled")
+		}
+	})
+	It("should wait for the main deployment before setting Ready condition", func() {
+		(*t).checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse,
+			"WaitingForCondition")
+	})
+	It("should set ObservedGeneration in CR Status", func() {
+		(*t).expectStatusObservedGeneration()
+	})
 	Context("deployment is progressing", func() {
 		JustBeforeEach(func() {
 			(*t).makeDeploymentProgress((*t).Name)
@@ -207,6 +240,8 @@ func expectSuccessful(t **cryostatTestInput) {
 			(*t).checkConditionPresent(operatorv1beta2.ConditionTypeMainDeploymentProgressing, metav1.ConditionTrue,
 				"TestProgressing")
 			(*t).checkConditionAbsent(operatorv1beta2.ConditionTypeMainDeploymentReplicaFailure)
+			(*t).checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse,
+				"TestAvailable")
 		})
 		Context("then becomes available", func() {
 			JustBeforeEach(func() {
@@ -218,6 +253,8 @@ func expectSuccessful(t **cryostatTestInput) {
 				(*t).checkConditionPresent(operatorv1beta2.ConditionTypeMainDeploymentProgressing, metav1.ConditionTrue,
 					"TestProgressing")
 				(*t).checkConditionAbsent(operatorv1beta2.ConditionTypeMainDeploymentReplicaFailure)
+				(*t).checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionTrue,
+					"AllComponentsReady")
 			})
 		})
 		Context("then fails to roll out", func() {
@@ -261,6 +298,81 @@ func (c *controllerTest) commonTests() {
 				t.expectWaitingForCertificate()
 			})
 
+			Context("with a certificate that is not ready", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCACertNotReady(time.Now().Add(-2*time.Minute)))
+				})
+
+				It("should describe the pending certificate", func() {
+					_, err := t.reconcile()
+					Expect(err).ToNot(HaveOccurred())
+
+					cr := t.getCryostatInstance()
+					condition := meta.FindStatusCondition(cr.Status.Conditions, string(operatorv1beta2.ConditionTypeTLSSetupComplete))
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+					Expect(condition.Reason).To(Equal("WaitingForCertificate"))
+					Expect(condition.Message).To(ContainSubstring(t.NewCACert().Name + " (pending for 2m"))
+					Expect(condition.Message).To(ContainSubstring("DoesNotExist: Issuing certificate as Secret does not exist"))
+					Expect(condition.Message).To(ContainSubstring(t.NewCryostatCert().Name + " (not yet processed by cert-manager)"))
+				})
+
+				It("should back off while the certificate is pending", func() {
+					result, err := t.reconcile()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeNumerically("~", 2*time.Minute, 5*time.Second))
+				})
+
+				It("should not emit a CertificateNotReady Event", func() {
+					_, err := t.reconcile()
+					Expect(err).ToNot(HaveOccurred())
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					Expect(recorder.Events).ToNot(Receive())
+				})
+
+				Context("for longer than the timeout", func() {
+					BeforeEach(func() {
+						t.objs = []ctrlclient.Object{
+							t.NewNamespace(),
+							t.NewApiServer(),
+							t.NewCryostatWithCertificateReadyTimeout(time.Minute).Object,
+							t.NewCACertNotReady(time.Now().Add(-2 * time.Minute)),
+						}
+					})
+
+					It("should emit a CertificateNotReady Event", func() {
+						_, err := t.reconcile()
+						Expect(err).ToNot(HaveOccurred())
+						recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+						var eventMsg string
+						Expect(recorder.Events).To(Receive(&eventMsg))
+						Expect(eventMsg).To(ContainSubstring("CertificateNotReady"))
+						Expect(eventMsg).To(ContainSubstring(t.NewCACert().Name))
+					})
+
+					It("should set the TLSSetupComplete reason", func() {
+						_, err := t.reconcile()
+						Expect(err).ToNot(HaveOccurred())
+						cr := t.getCryostatInstance()
+						condition := meta.FindStatusCondition(cr.Status.Conditions, string(operatorv1beta2.ConditionTypeTLSSetupComplete))
+						Expect(condition).ToNot(BeNil())
+						Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+						Expect(condition.Reason).To(Equal("CertificateReadyTimeout"))
+					})
+
+					It("should not emit the Event again when retrying", func() {
+						_, err := t.reconcile()
+						Expect(err).ToNot(HaveOccurred())
+						recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+						Expect(recorder.Events).To(Receive())
+
+						_, err = t.reconcile()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(recorder.Events).ToNot(Receive())
+					})
+				})
+			})
+
 			Context("successfully creates required resources", func() {
 				JustBeforeEach(func() {
 					t.reconcileCryostatFully()
@@ -1071,6 +1183,104 @@ func (c *controllerTest) commonTests() {
 				t.checkDeploymentHasTemplates()
 			})
 		})
+		Context("with web identity object storage credentials", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithS3WebIdentity().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should configure the S3 client to assume the role", func() {
+				t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
+					{
+						Name:  "QUARKUS_S3_AWS_CREDENTIALS_TYPE",
+						Value: "default",
+					},
+					{
+						Name:  "AWS_ROLE_ARN",
+						Value: "arn:aws:iam::123456789012:role/cryostat",
+					},
+					{
+						Name:  "AWS_WEB_IDENTITY_TOKEN_FILE",
+						Value: "/var/run/secrets/operator.cryostat.io/object-storage-token/token",
+					},
+					{
+						Name:  "AWS_ROLE_SESSION_NAME",
+						Value: t.Name,
+					},
+				})
+				t.checkCoreDoesNotHaveEnvironmentVariable("QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID")
+				t.checkCoreDoesNotHaveEnvironmentVariable("AWS_SECRET_ACCESS_KEY")
+			})
+			It("should project a service account token", func() {
+				template := t.getDeployment(t.Name).Spec.Template
+				expirationSeconds := int64(86400)
+				Expect(template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "object-storage-token",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: []corev1.VolumeProjection{
+								{
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience:          "sts.amazonaws.com",
+										ExpirationSeconds: &expirationSeconds,
+										Path:              "token",
+									},
+								},
+							},
+							DefaultMode: &[]int32{0440}[0],
+						},
+					},
+				}))
+				Expect(template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "object-storage-token",
+					MountPath: "/var/run/secrets/operator.cryostat.io/object-storage-token",
+					ReadOnly:  true,
+				}))
+			})
+		})
+		Context("with web identity credentials and database backups to object storage", func() {
+			BeforeEach(func() {
+				cr := t.NewCryostatWithS3WebIdentity()
+				cr.Spec.DatabaseOptions = t.NewCryostatWithDatabaseBackupToObjectStorage().Spec.DatabaseOptions
+				t.objs = append(t.objs, cr.Object)
+			})
+			It("should report that static credentials are required without blocking the reconcile", func() {
+				t.reconcileCryostatFully()
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseBackupReady, metav1.ConditionFalse,
+					"ObjectStorageCredentialsUnsupported")
+				condition := meta.FindStatusCondition(t.getCryostatInstance().Status.Conditions,
+					string(operatorv1beta2.ConditionTypeDatabaseBackupReady))
+				Expect(condition.Message).To(ContainSubstring("require Static object storage credentials"))
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
+				t.expectNoDatabaseBackupCronJob()
+				t.getDeployment(t.Name)
+			})
+		})
+		Context("with default chain object storage credentials", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithS3DefaultChain().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should use the default credentials provider chain", func() {
+				t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
+					{
+						Name:  "QUARKUS_S3_AWS_CREDENTIALS_TYPE",
+						Value: "default",
+					},
+				})
+				t.checkCoreDoesNotHaveEnvironmentVariable("QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID")
+				t.checkCoreDoesNotHaveEnvironmentVariable("AWS_ROLE_ARN")
+			})
+			It("should annotate the service account", func() {
+				sa := &corev1.ServiceAccount{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, sa)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sa.Annotations).To(HaveKeyWithValue("iam.gke.io/gcp-service-account", "cryostat@example.iam.gserviceaccount.com"))
+			})
+		})
 		Context("with external S3 object storage configuration", func() {
 			BeforeEach(func() {
 				secretName := "external-s3-creds"
@@ -1144,1293 +1354,3200 @@ func (c *controllerTest) commonTests() {
 				})
 			})
 		})
-		Context("with S3 storage bucket names configuration", func() {
+		Context("with an external database", func() {
 			BeforeEach(func() {
-				secretName := "external-s3-creds"
-				t.StorageSecret = t.NewExternalStorageSecret(secretName)
-				t.objs = append(t.objs, t.NewCryostatWithCustomizedStorageBucketNames().Object, t.StorageSecret)
+				t.ExternalDatabase = true
+				t.objs = append(t.objs, t.NewCryostatWithExternalDatabase().Object,
+					t.NewExternalDatabaseCredentialsSecret(), t.NewExternalDatabaseCAConfigMap())
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("Should update the core deployment", func() {
-				t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
-					{
-						Name:  "STORAGE_BUCKETS_ARCHIVES_NAME",
-						Value: "a",
-					},
-					{
-						Name:  "CRYOSTAT_SERVICES_REPORTS_STORAGE_CACHE_NAME",
-						Value: "b",
-					},
-					{
-						Name:  "STORAGE_BUCKETS_EVENT_TEMPLATES_NAME",
-						Value: "c",
-					},
-					{
-						Name:  "STORAGE_BUCKETS_PROBE_TEMPLATES_NAME",
-						Value: "d",
-					},
-					{
-						Name:  "STORAGE_BUCKETS_HEAP_DUMPS_NAME",
-						Value: "e",
-					},
-					{
-						Name:  "STORAGE_BUCKETS_THREAD_DUMPS_NAME",
-						Value: "f",
-					},
-					{
-						Name:  "STORAGE_BUCKETS_METADATA_NAME",
-						Value: "z",
-					},
-				})
+			It("should connect to the external database", func() {
+				t.expectMainDeployment()
 			})
-		})
-		Context("with custom PVC spec overriding all defaults", func() {
-			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithPVCSpec().Object)
+			It("should not deploy a database", func() {
+				t.expectNoDatabase()
 			})
-			JustBeforeEach(func() {
-				t.reconcileCryostatFully()
+			It("should set StorageReady condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
 			})
-			It("should create the PVC with requested spec", func() {
-				t.expectPVC(t.NewCustomDatabasePVC())
-				t.expectPVC(t.NewCustomStoragePVC())
+			Context("after using the bundled database", func() {
+				BeforeEach(func() {
+					t.ExternalDatabase = false
+					t.objs = []ctrlclient.Object{t.NewNamespace(), t.NewApiServer(), t.NewCryostat().Object,
+						t.NewExternalDatabaseCredentialsSecret(), t.NewExternalDatabaseCAConfigMap()}
+				})
+				JustBeforeEach(func() {
+					t.expectDatabaseDeployment()
+					cr := t.getCryostatInstance()
+					cr.Spec.DatabaseOptions = t.NewCryostatWithExternalDatabase().Spec.DatabaseOptions
+					t.updateCryostatInstance(cr)
+					t.ExternalDatabase = true
+					t.reconcileCryostatFully()
+				})
+				It("should remove the bundled database", func() {
+					t.expectNoDatabase()
+					t.checkConditionAbsent(operatorv1beta2.ConditionTypeDatabaseDeploymentAvailable)
+				})
+				It("should retain the database's PVC", func() {
+					pvc := &corev1.PersistentVolumeClaim{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database", Namespace: t.Namespace}, pvc)
+					Expect(err).ToNot(HaveOccurred())
+				})
+				It("should connect to the external database", func() {
+					t.expectMainDeployment()
+				})
 			})
 		})
-		Context("with custom PVC spec overriding some defaults", func() {
+		Context("with database backups to a PVC", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithPVCSpecSomeDefault().Object)
+				t.objs = append(t.objs, t.NewCryostatWithDatabaseBackup().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create the PVC with requested spec", func() {
-				t.expectPVC(t.NewCustomDatabasePVCSomeDefault())
-				t.expectPVC(t.NewCustomStoragePVCSomeDefault())
-			})
-		})
-		Context("with custom PVC config with no spec", func() {
-			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithPVCLabelsOnly().Object)
+			It("should create a backup CronJob", func() {
+				cronJob := t.getDatabaseBackupCronJob()
+				Expect(metav1.IsControlledBy(cronJob, t.getCryostatInstance().Object)).To(BeTrue())
+				Expect(cronJob.Labels).To(Equal(t.NewDatabaseBackupPodLabels()))
+				Expect(cronJob.Spec.Schedule).To(Equal("0 2 * * *"))
+				Expect(cronJob.Spec.ConcurrencyPolicy).To(Equal(batchv1.ForbidConcurrent))
+				Expect(cronJob.Spec.JobTemplate.Spec.PodFailurePolicy).To(Equal(t.NewNonRetryablePodFailurePolicy("cryostat-db-client")))
+
+				template := cronJob.Spec.JobTemplate.Spec.Template
+				Expect(template.Labels).To(Equal(t.NewDatabaseBackupPodLabels()))
+				Expect(template.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+				Expect(template.Spec.SecurityContext).To(Equal(t.NewPodSecurityContext(t.NewCryostat())))
+				Expect(template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "backups",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "cryostat-db-backups",
+						},
+					},
+				}))
+				Expect(template.Spec.Containers).To(HaveLen(1))
+				container := template.Spec.Containers[0]
+				Expect(container.Image).To(HavePrefix("quay.io/cryostat/cryostat-db:"))
+				Expect(container.Command).To(HaveLen(3))
+				Expect(container.Command[2]).To(ContainSubstring("pg_dump --format=custom"))
+				Expect(container.SecurityContext).To(Equal(t.NewDatabaseSecurityContext(t.NewCryostat())))
+				Expect(container.Env).To(ContainElements(t.NewDatabaseClientEnvironmentVariables()))
+				Expect(container.Env).To(ContainElements(t.NewDatabaseClientTLSEnvironmentVariables()))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "RETENTION", Value: "3"}))
+			})
+			It("should allow backups to connect to the database", func() {
+				policy := &netv1.NetworkPolicy{}
+				expected := t.NewDatabaseIngressNetworkPolicy()
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policy.Spec.Ingress[0].From).To(ContainElement(t.NewDatabaseBackupNetworkPolicyPeer()))
 			})
-			JustBeforeEach(func() {
-				t.reconcileCryostatFully()
+			It("should not restore the database", func() {
+				t.expectNoDatabaseRestoreJob()
+				t.expectMainDeployment()
 			})
-			It("should create the PVC with requested label", func() {
-				t.expectPVC(t.NewDefaultDatabasePVCWithLabel())
-				t.expectPVC(t.NewDefaultStoragePVCWithLabel())
+			It("should report that backups are scheduled", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseBackupReady, metav1.ConditionTrue, "Reconciled")
 			})
-		})
-		Context("with a legacy PVC spec", func() {
-			Context("with custom PVC spec overriding all defaults", func() {
+			Context("with cert-manager disabled", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithPVCSpecLegacy().Object)
-				})
-				JustBeforeEach(func() {
-					t.reconcileCryostatFully()
+					cr := t.NewCryostatWithDatabaseBackup()
+					disable := false
+					cr.Spec.EnableCertManager = &disable
+					t.objs = []ctrlclient.Object{t.NewNamespace(), t.NewApiServer(), cr.Object}
+					t.TLS = false
 				})
-				It("should create the PVC with requested spec", func() {
-					t.expectPVC(t.NewCustomDatabasePVCLegacy())
-					t.expectPVC(t.NewCustomStoragePVCLegacy())
+				It("should connect to the database without TLS", func() {
+					container := t.getDatabaseBackupCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).To(ContainElements(t.NewDatabaseClientEnvironmentVariables()))
+					for _, env := range t.NewDatabaseClientTLSEnvironmentVariables() {
+						Expect(container.Env).ToNot(ContainElement(HaveField("Name", env.Name)))
+					}
 				})
 			})
-			Context("with custom PVC spec overriding some defaults", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithPVCSpecSomeDefaultLegacy().Object)
-				})
+			Context("when backups are disabled", func() {
 				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					cr.Spec.DatabaseOptions = nil
+					t.updateCryostatInstance(cr)
 					t.reconcileCryostatFully()
 				})
-				It("should create the PVC with requested spec", func() {
-					t.expectPVC(t.NewCustomDatabasePVCSomeDefaultLegacy())
-					t.expectPVC(t.NewCustomStoragePVCSomeDefaultLegacy())
+				It("should delete the backup CronJob", func() {
+					t.expectNoDatabaseBackupCronJob()
+					t.checkConditionAbsent(operatorv1beta2.ConditionTypeDatabaseBackupReady)
+				})
+				It("should not allow other pods to connect to the database", func() {
+					t.checkNetworkPolicy(t.NewDatabaseIngressNetworkPolicy())
 				})
 			})
-			Context("with custom PVC config with no spec", func() {
+			Context("with an external database", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithPVCLabelsOnlyLegacy().Object)
-				})
-				JustBeforeEach(func() {
-					t.reconcileCryostatFully()
+					t.ExternalDatabase = true
+					cr := t.NewCryostatWithExternalDatabase()
+					cr.Spec.DatabaseOptions.Backup = t.NewCryostatWithDatabaseBackup().Spec.DatabaseOptions.Backup
+					t.objs = []ctrlclient.Object{t.NewNamespace(), t.NewApiServer(), cr.Object,
+						t.NewExternalDatabaseCredentialsSecret(), t.NewExternalDatabaseCAConfigMap()}
 				})
-				It("should create the PVC with requested label", func() {
-					t.expectPVC(t.NewDefaultDatabasePVCWithLabelLegacy())
-					t.expectPVC(t.NewDefaultStoragePVCWithLabelLegacy())
+				It("should not back up the database", func() {
+					t.expectNoDatabaseBackupCronJob()
 				})
 			})
 		})
-		Context("with both custom PVC specs overriding all defaults", func() {
+		Context("with database backups to object storage", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithPVCSpecBoth().Object)
+				t.objs = append(t.objs, t.NewCryostatWithDatabaseBackupToObjectStorage().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create the PVC with requested spec", func() {
-				t.expectPVC(t.NewCustomDatabasePVC())
-				t.expectPVC(t.NewCustomStoragePVC())
+			It("should upload backups to the storage bucket", func() {
+				template := t.getDatabaseBackupCronJob().Spec.JobTemplate.Spec.Template
+				Expect(template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "backups",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				}))
+				container := template.Spec.Containers[0]
+				Expect(container.Command[2]).To(ContainSubstring("--aws-sigv4"))
+				Expect(container.Env).To(ContainElements(
+					corev1.EnvVar{
+						Name:  "BUCKET_URL",
+						Value: fmt.Sprintf("https://%s-storage.%s.svc.cluster.local:8333/database-backups", t.Name, t.Namespace),
+					},
+					corev1.EnvVar{
+						Name:  "S3_REGION",
+						Value: "us-east-1",
+					},
+					corev1.EnvVar{
+						Name:  "S3_CURL_OPTS",
+						Value: fmt.Sprintf("--cacert /var/run/secrets/operator.cryostat.io/%s-storage-tls/ca.crt", t.Name),
+					},
+					corev1.EnvVar{
+						Name:  "RETENTION",
+						Value: "7",
+					},
+				))
+				Expect(container.Env).To(ContainElement(HaveField("Name", "AWS_ACCESS_KEY_ID")))
+				Expect(container.Env).To(ContainElement(HaveField("Name", "AWS_SECRET_ACCESS_KEY")))
+			})
+			It("should sign uploads if curl supports SigV4", func() {
+				container := t.getDatabaseBackupCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+				out, code := runContainerScript(&container, map[string]string{
+					"curl":    fakeCurl("--aws-sigv4 <provider1[:provider2[:region[:service]]]> Use AWS V4 signature authentication"),
+					"pg_dump": `for arg; do case "${arg}" in --file=*) : > "${arg#--file=}" ;; esac; done`,
+				})
+				Expect(code).To(Equal(0), out)
+				Expect(out).To(ContainSubstring("--aws-sigv4 aws:amz:us-east-1:s3 --user access:secret --fail --upload-file"))
+			})
+			It("should fail without retrying if curl does not support SigV4", func() {
+				container := t.getDatabaseBackupCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+				out, code := runContainerScript(&container, map[string]string{
+					"curl":    fakeCurl("--aws <provider> Not a supported option"),
+					"pg_dump": "exit 1",
+				})
+				Expect(code).To(Equal(3), out)
+				Expect(out).To(ContainSubstring("requires curl 7.75 or later: curl 7.61.1"))
+			})
+			It("should allow backups to connect to the storage", func() {
+				policy := &netv1.NetworkPolicy{}
+				expected := t.NewStorageIngressNetworkPolicy()
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policy.Spec.Ingress[0].From).To(ContainElement(t.NewDatabaseBackupNetworkPolicyPeer()))
 			})
 		})
-		Context("with an existing DB PVC", func() {
-			var oldPVC *corev1.PersistentVolumeClaim
+		Context("with a database restore", func() {
 			BeforeEach(func() {
-				oldPVC = t.NewDefaultPVC()
-				t.objs = append(t.objs, t.NewCryostatWithPVCSpec().Object, oldPVC)
+				t.objs = append(t.objs, t.NewCryostatWithDatabaseRestore().Object)
 			})
-			Context("that successfully updates", func() {
-				BeforeEach(func() {
-					// Add some labels and annotations to test merging
-					metav1.SetMetaDataLabel(&oldPVC.ObjectMeta, "my", "other-label")
-					metav1.SetMetaDataLabel(&oldPVC.ObjectMeta, "another", "label")
-					metav1.SetMetaDataAnnotation(&oldPVC.ObjectMeta, "my/custom", "other-annotation")
-					metav1.SetMetaDataAnnotation(&oldPVC.ObjectMeta, "another/custom", "annotation")
+			JustBeforeEach(func() {
+				t.reconcileUntilDatabaseRestoring()
+			})
+			It("should create a restore Job", func() {
+				job := t.getDatabaseRestoreJob()
+				Expect(metav1.IsControlledBy(job, t.getCryostatInstance().Object)).To(BeTrue())
+				Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/database-backup", "cryostat-20240102030405.dump"))
+				Expect(job.Spec.PodFailurePolicy).To(Equal(t.NewNonRetryablePodFailurePolicy("cryostat-db-client")))
+				container := job.Spec.Template.Spec.Containers[0]
+				Expect(container.Command[2]).To(ContainSubstring("pg_restore"))
+				Expect(container.Env).To(ContainElements(t.NewDatabaseClientEnvironmentVariables()))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "BACKUP_NAME", Value: "cryostat-20240102030405.dump"}))
+				Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "cryostat-db-backups")))
+			})
+			It("should deploy the database", func() {
+				t.expectDatabaseDeployment()
+			})
+			It("should refuse to restore into a database with tables", func() {
+				container := t.getDatabaseRestoreJob().Spec.Template.Spec.Containers[0]
+				out, code := runContainerScript(&container, map[string]string{
+					"pg_isready": "exit 0",
+					"psql":       "echo 12",
+					"pg_restore": "exit 0",
+				})
+				Expect(code).To(Equal(3), out)
+				Expect(out).To(ContainSubstring("The database already contains 12 tables"))
+				Expect(out).ToNot(ContainSubstring("Restored backup"))
+			})
+			It("should restore into an empty database", func() {
+				container := t.getDatabaseRestoreJob().Spec.Template.Spec.Containers[0]
+				out, code := runContainerScript(&container, map[string]string{
+					"pg_isready": "exit 0",
+					"psql":       "echo 0",
+					"pg_restore": "exit 0",
 				})
+				Expect(code).To(Equal(0), out)
+				Expect(out).To(ContainSubstring("Restored backup cryostat-20240102030405.dump"))
+			})
+			It("should not deploy Cryostat until the restore completes", func() {
+				t.expectNoMainDeployment()
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionFalse, "DatabaseRestoreInProgress")
+			})
+			Context("when the restore completes", func() {
 				JustBeforeEach(func() {
+					t.setDatabaseRestoreJobCondition(batchv1.JobComplete, "")
 					t.reconcileCryostatFully()
 				})
-				It("should update metadata and resource requests", func() {
-					expected := t.NewDefaultPVC()
-					metav1.SetMetaDataLabel(&expected.ObjectMeta, "my", "database")
-					metav1.SetMetaDataLabel(&expected.ObjectMeta, "another", "label")
-					metav1.SetMetaDataLabel(&expected.ObjectMeta, "app", t.Name)
-					metav1.SetMetaDataAnnotation(&expected.ObjectMeta, "my/custom", "database")
-					metav1.SetMetaDataAnnotation(&expected.ObjectMeta, "another/custom", "annotation")
-					expected.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("5Gi")
-					t.expectPVC(expected)
+				It("should deploy Cryostat", func() {
+					t.expectMainDeployment()
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseBackupReady, metav1.ConditionTrue, "Reconciled")
 				})
-			})
-			Context("that fails to update", func() {
-				JustBeforeEach(func() {
-					// Replace client with one that fails to update the PVC
-					invalidErr := kerrors.NewInvalid(schema.ParseGroupKind("PersistentVolumeClaim"), oldPVC.Name, field.ErrorList{
-						field.Forbidden(field.NewPath("spec"), "test error"),
+				It("should keep the restore Job", func() {
+					t.getDatabaseRestoreJob()
+				})
+				Context("and restore is removed", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.DatabaseOptions.Restore = nil
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
 					})
-					origClient := t.reconciler.GetConfig().Client
-					t.reconciler.GetConfig().Client = test.NewClientWithUpdateError(origClient, invalidErr, func(obj ctrlclient.Object) bool {
-						return test.FailOnMatch(obj, oldPVC, t.Client.Scheme())
+					It("should delete the restore Job", func() {
+						t.expectNoDatabaseRestoreJob()
 					})
-
-					// Expect an Invalid status error after reconciling
-					Eventually(func() bool {
-						_, err := t.reconcile()
-						if err != nil {
-							return kerrors.IsInvalid(err)
-						}
-						return false
-					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(BeTrue())
 				})
-				It("should emit a PersistentVolumeClaimInvalid event", func() {
-					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
-					var eventMsg string
-					Expect(recorder.Events).To(Receive(&eventMsg))
-					Expect(eventMsg).To(ContainSubstring("PersistentVolumeClaimInvalid"))
+			})
+			Context("when the restore fails", func() {
+				JustBeforeEach(func() {
+					t.setDatabaseRestoreJobCondition(batchv1.JobFailed, "BackoffLimitExceeded")
+				})
+				It("should report the failure and not deploy Cryostat", func() {
+					_, err := t.reconcile()
+					Expect(err).To(MatchError(ContainSubstring("failed to restore the database")))
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionFalse, "DatabaseRestoreFailed")
+					t.expectNoMainDeployment()
+				})
+				It("should keep the failed restore Job", func() {
+					_, err := t.reconcile()
+					Expect(err).To(HaveOccurred())
+					Expect(t.getDatabaseRestoreJob().Status.Conditions).To(ContainElement(HaveField("Type", batchv1.JobFailed)))
+				})
+				Context("and the Job is deleted", func() {
+					JustBeforeEach(func() {
+						err := t.Client.Delete(context.Background(), t.getDatabaseRestoreJob())
+						Expect(err).ToNot(HaveOccurred())
+						t.reconcileUntilDatabaseRestoring()
+					})
+					It("should restore the backup again", func() {
+						Expect(t.getDatabaseRestoreJob().Status.Conditions).To(BeEmpty())
+						t.expectNoMainDeployment()
+					})
+				})
+				Context("and restore is removed", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.DatabaseOptions.Restore = nil
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
+					})
+					It("should deploy Cryostat without the backup", func() {
+						t.expectNoDatabaseRestoreJob()
+						t.expectMainDeployment()
+						t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
+					})
+				})
+			})
+			Context("when a different backup is selected", func() {
+				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					cr.Spec.DatabaseOptions.Restore.BackupName = "cryostat-20240203040506.dump"
+					t.updateCryostatInstance(cr)
+					t.reconcileUntilDatabaseRestoring()
+				})
+				It("should replace the restore Job", func() {
+					t.reconcileUntilDatabaseRestoring()
+					job := t.getDatabaseRestoreJob()
+					Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/database-backup", "cryostat-20240203040506.dump"))
 				})
 			})
 		})
-		Context("with custom EmptyDir config", func() {
+		Context("with object storage retention", func() {
+			var storageURL string
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithDefaultEmptyDir().Object)
+				t.objs = append(t.objs, t.NewCryostatWithStorageRetention().Object)
+				storageURL = fmt.Sprintf("https://%s-storage.%s.svc.cluster.local:8333", t.Name, t.Namespace)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
+				t.makeDeploymentAvailable(t.Name + "-storage")
+				t.reconcileCryostatFully()
 			})
-			It("should create the EmptyDir with default specs", func() {
-				t.expectDatabaseEmptyDir(t.NewDefaultEmptyDir())
-				t.expectStorageEmptyDir(t.NewDefaultEmptyDir())
+			It("should apply age limits as lifecycle configuration", func() {
+				job := t.getStorageLifecycleJob()
+				Expect(metav1.IsControlledBy(job, t.getCryostatInstance().Object)).To(BeTrue())
+				Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/lifecycle-rules", "archivedrecordings=30"))
+				Expect(job.Annotations).To(HaveKey("operator.cryostat.io/storage-instance"))
+				Expect(*job.Spec.BackoffLimit).To(Equal(int32(6)))
+				Expect(job.Spec.PodFailurePolicy).To(Equal(t.NewNonRetryablePodFailurePolicy("cryostat-storage-client")))
+				container := job.Spec.Template.Spec.Containers[0]
+				Expect(container.Command[2]).To(ContainSubstring("?lifecycle"))
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name: "LIFECYCLE_RULES",
+					Value: storageURL + "/archivedrecordings 30\n" +
+						storageURL + "/archivedreports 0\n" +
+						storageURL + "/heapdumps 0\n" +
+						storageURL + "/threaddumps 0",
+				}))
+				Expect(container.Env).To(ContainElement(HaveField("Name", "AWS_ACCESS_KEY_ID")))
+			})
+			It("should prune the buckets", func() {
+				cronJob := t.getStorageRetentionCronJob()
+				Expect(cronJob.Spec.Schedule).To(Equal("0 * * * *"))
+				container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+				Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name: "PRUNE_RULES",
+					Value: storageURL + "/archivedrecordings 30 10737418240\n" +
+						storageURL + "/heapdumps 0 5368709120",
+				}))
+			})
+			It("should report the retention policies", func() {
+				status := t.getCryostatInstance().Status.StorageRetention
+				Expect(status).To(HaveLen(2))
+				Expect(status[0].Bucket).To(Equal("archivedrecordings"))
+				Expect(status[0].MaxAgeDays).To(Equal(&[]int32{30}[0]))
+				Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				Expect(status[0].MaxTotalSize.String()).To(Equal("10Gi"))
+				Expect(status[0].MaxTotalSizeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				Expect(status[1].Bucket).To(Equal("heapdumps"))
+				Expect(status[1].MaxAgeDays).To(BeNil())
+				Expect(status[1].MaxAgeEnforcedBy).To(BeEmpty())
+				Expect(status[1].MaxTotalSize.String()).To(Equal("5Gi"))
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageRetentionReady, metav1.ConditionTrue, "Reconciled")
+			})
+			It("should add its rule to the existing lifecycle configuration", func() {
+				container := t.getStorageLifecycleJob().Spec.Template.Spec.Containers[0]
+				dir, requests := t.runStorageLifecycleScript(&container, map[string]string{
+					"archivedrecordings": "<LifecycleConfiguration><Rule><ID>other</ID><Expiration><Days>90</Days></Expiration></Rule></LifecycleConfiguration>",
+					"heapdumps":          "<LifecycleConfiguration><Rule><ID>other</ID></Rule></LifecycleConfiguration>",
+				}, 0)
+				config, err := os.ReadFile(filepath.Join(dir, "archivedrecordings.xml"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(config)).To(Equal("<LifecycleConfiguration><Rule><ID>other</ID><Expiration><Days>90</Days></Expiration></Rule>" +
+					"<Rule><ID>cryostat-retention</ID><Filter><Prefix></Prefix></Filter><Status>Enabled</Status>" +
+					"<Expiration><Days>30</Days></Expiration></Rule></LifecycleConfiguration>"))
+				// Buckets without an age limit and without the operator's rule are left alone
+				Expect(requests).To(ContainElement(ContainSubstring("GET " + storageURL + "/heapdumps?lifecycle")))
+				Expect(requests).ToNot(ContainElement(MatchRegexp("(PUT|DELETE) .*/(archivedreports|heapdumps|threaddumps)")))
+			})
+			It("should fail without retrying if lifecycle configuration is not supported", func() {
+				container := t.getStorageLifecycleJob().Spec.Template.Spec.Containers[0]
+				t.runStorageLifecycleScript(&container, map[string]string{"archivedrecordings": "unsupported"}, 3)
+			})
+			It("should fail without retrying if the lifecycle configuration is ignored", func() {
+				container := t.getStorageLifecycleJob().Spec.Template.Spec.Containers[0]
+				t.runStorageLifecycleScript(&container, map[string]string{"archivedrecordings": "ignored"}, 3)
+			})
+			It("should allow retention pods to connect to the storage", func() {
+				policy := &netv1.NetworkPolicy{}
+				expected := t.NewStorageIngressNetworkPolicy()
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, policy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policy.Spec.Ingress[0].From).To(ContainElement(t.NewStorageRetentionNetworkPolicyPeer()))
+			})
+			Context("when lifecycle configuration is applied", func() {
+				JustBeforeEach(func() {
+					t.setStorageLifecycleJobCondition(batchv1.JobComplete, "CompletionsReached")
+					t.reconcileCryostatFully()
+				})
+				It("should only prune by size", func() {
+					container := t.getStorageRetentionCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).To(ContainElement(corev1.EnvVar{
+						Name: "PRUNE_RULES",
+						Value: storageURL + "/archivedrecordings 0 10737418240\n" +
+							storageURL + "/heapdumps 0 5368709120",
+					}))
+				})
+				It("should report that age limits are enforced by lifecycle configuration", func() {
+					status := t.getCryostatInstance().Status.StorageRetention
+					Expect(status).To(HaveLen(2))
+					Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByLifecycle))
+					Expect(status[0].MaxTotalSizeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				})
+				Context("and the age limit changes", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.ObjectStorageOptions.Retention.ArchivedRecordings.MaxAgeDays = &[]int32{14}[0]
+						t.updateCryostatInstance(cr)
+						// Replace the Job, then create it again
+						t.reconcileCryostatFully()
+						t.reconcileCryostatFully()
+					})
+					It("should replace the lifecycle Job", func() {
+						job := t.getStorageLifecycleJob()
+						Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/lifecycle-rules", "archivedrecordings=14"))
+						Expect(job.Status.Conditions).To(BeEmpty())
+					})
+					It("should prune by age until the lifecycle configuration is applied", func() {
+						status := t.getCryostatInstance().Status.StorageRetention
+						Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+					})
+				})
+				Context("and retention is removed", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.ObjectStorageOptions.Retention = nil
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
+						t.reconcileCryostatFully()
+					})
+					It("should delete the pruning CronJob", func() {
+						t.expectNoStorageRetentionCronJob()
+					})
+					It("should remove the lifecycle configuration", func() {
+						job := t.getStorageLifecycleJob()
+						Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/lifecycle-rules", ""))
+						Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+							Name: "LIFECYCLE_RULES",
+							Value: storageURL + "/archivedrecordings 0\n" +
+								storageURL + "/archivedreports 0\n" +
+								storageURL + "/heapdumps 0\n" +
+								storageURL + "/threaddumps 0",
+						}))
+						Expect(t.getCryostatInstance().Status.StorageRetention).To(HaveLen(2))
+					})
+					It("should only remove the operator's lifecycle rule", func() {
+						container := t.getStorageLifecycleJob().Spec.Template.Spec.Containers[0]
+						dir, _ := t.runStorageLifecycleScript(&container, map[string]string{
+							"archivedrecordings": "<LifecycleConfiguration><Rule><ID>cryostat-retention</ID></Rule></LifecycleConfiguration>",
+							"heapdumps": "<LifecycleConfiguration><Rule><ID>other</ID></Rule>" +
+								"<Rule><ID>cryostat-retention</ID></Rule></LifecycleConfiguration>",
+							"threaddumps": "unsupported",
+						}, 0)
+						Expect(filepath.Join(dir, "archivedrecordings.xml")).ToNot(BeAnExistingFile())
+						config, err := os.ReadFile(filepath.Join(dir, "heapdumps.xml"))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(config)).To(Equal("<LifecycleConfiguration><Rule><ID>other</ID></Rule></LifecycleConfiguration>"))
+					})
+					Context("once the lifecycle configuration is removed", func() {
+						JustBeforeEach(func() {
+							t.setStorageLifecycleJobCondition(batchv1.JobComplete, "CompletionsReached")
+							t.reconcileCryostatFully()
+						})
+						It("should delete the lifecycle Job", func() {
+							t.expectNoStorageLifecycleJob()
+						})
+						It("should clear the retention status", func() {
+							Expect(t.getCryostatInstance().Status.StorageRetention).To(BeEmpty())
+						})
+					})
+				})
+			})
+			Context("when lifecycle configuration is not supported", func() {
+				JustBeforeEach(func() {
+					t.setStorageLifecycleJobCondition(batchv1.JobFailed, batchv1.JobReasonPodFailurePolicy)
+					t.reconcileCryostatFully()
+				})
+				It("should prune by age", func() {
+					container := t.getStorageRetentionCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).To(ContainElement(HaveField("Value", ContainSubstring("/archivedrecordings 30 10737418240"))))
+					status := t.getCryostatInstance().Status.StorageRetention
+					Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				})
+				It("should keep the lifecycle Job", func() {
+					Expect(t.getStorageLifecycleJob().Spec.TTLSecondsAfterFinished).To(BeNil())
+				})
+			})
+			Context("when the lifecycle Job fails with a transient error", func() {
+				JustBeforeEach(func() {
+					t.setStorageLifecycleJobCondition(batchv1.JobFailed, batchv1.JobReasonBackoffLimitExceeded)
+					t.reconcileCryostatFully()
+				})
+				It("should prune by age", func() {
+					container := t.getStorageRetentionCronJob().Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+					Expect(container.Env).To(ContainElement(HaveField("Value", ContainSubstring("/archivedrecordings 30 10737418240"))))
+					status := t.getCryostatInstance().Status.StorageRetention
+					Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				})
+				It("should delete the lifecycle Job after a delay", func() {
+					Expect(t.getStorageLifecycleJob().Spec.TTLSecondsAfterFinished).To(Equal(&[]int32{600}[0]))
+				})
+				Context("once the lifecycle Job is deleted", func() {
+					JustBeforeEach(func() {
+						err := t.Client.Delete(context.Background(), t.getStorageLifecycleJob())
+						Expect(err).ToNot(HaveOccurred())
+						t.reconcileCryostatFully()
+					})
+					It("should run the lifecycle Job again", func() {
+						job := t.getStorageLifecycleJob()
+						Expect(job.Status.Conditions).To(BeEmpty())
+						Expect(job.Spec.TTLSecondsAfterFinished).To(BeNil())
+					})
+				})
+			})
+			Context("when the object storage restarts after lifecycle configuration is applied", func() {
+				JustBeforeEach(func() {
+					t.setStorageLifecycleJobCondition(batchv1.JobComplete, "CompletionsReached")
+					t.reconcileCryostatFully()
+					deploy := t.getDeployment(t.Name + "-storage")
+					deploy.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now())
+					err := t.Client.Status().Update(context.Background(), deploy)
+					Expect(err).ToNot(HaveOccurred())
+					// Replace the Job, then create it again
+					t.reconcileCryostatFully()
+					t.reconcileCryostatFully()
+				})
+				It("should apply the lifecycle configuration again", func() {
+					job := t.getStorageLifecycleJob()
+					Expect(job.Status.Conditions).To(BeEmpty())
+					status := t.getCryostatInstance().Status.StorageRetention
+					Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
+				})
 			})
 		})
-		Context("with custom EmptyDir config with requested spec", func() {
+		Context("with object storage retention before the object storage is available", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpec().Object)
+				t.objs = append(t.objs, t.NewCryostatWithStorageRetention().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create the EmptyDir with requested specs", func() {
-				t.expectDatabaseEmptyDir(t.NewCustomDatabaseEmptyDir())
-				t.expectStorageEmptyDir(t.NewCustomStorageEmptyDir())
+			It("should wait to apply the lifecycle configuration", func() {
+				t.expectNoStorageLifecycleJob()
+			})
+			It("should prune by age in the meantime", func() {
+				t.getStorageRetentionCronJob()
+				status := t.getCryostatInstance().Status.StorageRetention
+				Expect(status[0].MaxAgeEnforcedBy).To(Equal(operatorv1beta2.RetentionEnforcedByPruning))
 			})
 		})
-		Context("with legacy custom EmptyDir config with requested spec", func() {
+		Context("with object storage retention by age only", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpecLegacy().Object)
+				t.objs = append(t.objs, t.NewCryostatWithStorageRetentionByAge().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
+				t.makeDeploymentAvailable(t.Name + "-storage")
+				t.reconcileCryostatFully()
 			})
-			It("should create the EmptyDir with requested specs", func() {
-				t.expectDatabaseEmptyDir(t.NewCustomEmptyDirLegacy())
-				t.expectStorageEmptyDir(t.NewCustomEmptyDirLegacy())
+			It("should prune by age until the lifecycle configuration is applied", func() {
+				t.getStorageRetentionCronJob()
+			})
+			Context("when lifecycle configuration is applied", func() {
+				JustBeforeEach(func() {
+					t.setStorageLifecycleJobCondition(batchv1.JobComplete, "CompletionsReached")
+					t.reconcileCryostatFully()
+				})
+				It("should delete the pruning CronJob", func() {
+					t.expectNoStorageRetentionCronJob()
+				})
 			})
 		})
-		Context("with both custom EmptyDir configs with requested spec", func() {
+		Context("without object storage retention", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpecBoth().Object)
+				t.objs = append(t.objs, t.NewCryostat().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create the EmptyDir with requested specs", func() {
-				t.expectDatabaseEmptyDir(t.NewCustomDatabaseEmptyDir())
-				t.expectStorageEmptyDir(t.NewCustomStorageEmptyDir())
+			It("should not manage retention", func() {
+				t.expectNoStorageLifecycleJob()
+				t.expectNoStorageRetentionCronJob()
+				Expect(t.getCryostatInstance().Status.StorageRetention).To(BeEmpty())
+				t.checkConditionAbsent(operatorv1beta2.ConditionTypeStorageRetentionReady)
 			})
 		})
-		Context("with overridden image tags", func() {
-			var mainDeploy, databaseDeploy, storageDeploy, reportsDeploy *appsv1.Deployment
+		Context("with object storage retention and custom bucket names", func() {
 			BeforeEach(func() {
-				t.ReportReplicas = 1
-				t.objs = append(t.objs, t.NewCryostatWithReportsSvc().Object)
+				t.StorageSecret = t.NewExternalStorageSecret("external-s3-creds")
+				cr := t.NewCryostatWithCustomizedStorageBucketNames()
+				cr.Spec.ObjectStorageOptions.Retention = t.NewCryostatWithStorageRetention().Spec.ObjectStorageOptions.Retention
+				t.objs = append(t.objs, cr.Object, t.StorageSecret)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
-				mainDeploy = &appsv1.Deployment{}
-				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, mainDeploy)
-				Expect(err).ToNot(HaveOccurred())
-				databaseDeploy = &appsv1.Deployment{}
-				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database", Namespace: t.Namespace}, databaseDeploy)
-				Expect(err).ToNot(HaveOccurred())
-				storageDeploy = &appsv1.Deployment{}
-				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage", Namespace: t.Namespace}, storageDeploy)
-				Expect(err).ToNot(HaveOccurred())
-				reportsDeploy = &appsv1.Deployment{}
-				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-reports", Namespace: t.Namespace}, reportsDeploy)
-				Expect(err).ToNot(HaveOccurred())
 			})
-			Context("for development", func() {
-				BeforeEach(func() {
-					coreImg := "my/core-image:1.0.0-SNAPSHOT"
-					datasourceImg := "my/datasource-image:1.0.0-BETA25"
-					grafanaImg := "my/grafana-image:1.0.0-dev"
-					reportsImg := "my/reports-image:1.0.0-SNAPSHOT"
-					storageImg := "my/storage-image:1.0.0-dev"
-					databaseImg := "my/database-image:1.0.0-dev"
-					oauth2ProxyImg := "my/auth-proxy:1.0.0-dev"
-					openshiftAuthProxyImg := "my/openshift-auth-proxy:1.0.0-dev"
-					agentProxyImg := "my/agent-proxy:1.0.0-dev"
-					t.EnvCoreImageTag = &coreImg
-					t.EnvDatasourceImageTag = &datasourceImg
-					t.EnvGrafanaImageTag = &grafanaImg
-					t.EnvReportsImageTag = &reportsImg
-					t.EnvDatabaseImageTag = &databaseImg
-					t.EnvStorageImageTag = &storageImg
-					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
-					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
-					t.EnvAgentProxyImageTag = &agentProxyImg
-				})
-				It("should create deployment with the expected tags", func() {
-					t.expectMainDeployment()
-					t.expectDatabaseDeployment()
-					t.expectStorageDeployment()
-					t.checkReportsDeployment()
-				})
-				It("should set ImagePullPolicy to Always", func() {
-					containers := mainDeploy.Spec.Template.Spec.Containers
-					Expect(containers).To(HaveLen(5))
-					for _, container := range containers {
-						Expect(container.ImagePullPolicy).To(Equal(corev1.PullAlways))
-					}
-					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
-					Expect(databaseContainers).To(HaveLen(1))
-					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-					storageContainers := storageDeploy.Spec.Template.Spec.Containers
-					Expect(storageContainers).To(HaveLen(1))
-					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
-					Expect(reportContainers).To(HaveLen(1))
-					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-				})
+			It("should apply the rules to the external buckets", func() {
+				job := t.getStorageLifecycleJob()
+				Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/lifecycle-rules", "a=30"))
+				Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+					corev1.EnvVar{
+						Name:  "LIFECYCLE_RULES",
+						Value: "https://example.com:1234/a 30\nhttps://example.com:1234/b 0\nhttps://example.com:1234/e 0\nhttps://example.com:1234/f 0",
+					},
+					corev1.EnvVar{
+						Name:  "S3_REGION",
+						Value: "region-east-1",
+					},
+				))
+				status := t.getCryostatInstance().Status.StorageRetention
+				Expect(status).To(HaveLen(2))
+				Expect(status[0].Bucket).To(Equal("a"))
+				Expect(status[1].Bucket).To(Equal("e"))
 			})
-			Context("for release", func() {
-				BeforeEach(func() {
-					coreImg := "my/core-image:1.0.0"
-					datasourceImg := "my/datasource-image:1.0.0"
-					grafanaImg := "my/grafana-image:1.0.0"
-					reportsImg := "my/reports-image:1.0.0"
-					storageImg := "my/storage-image:1.0.0"
-					databaseImg := "my/database-image:1.0.0"
-					oauth2ProxyImg := "my/authproxy-image:1.0.0"
-					openshiftAuthProxyImg := "my/openshift-authproxy-image:1.0.0"
-					agentProxyImg := "my/agent-proxy:1.0.0"
-					t.EnvCoreImageTag = &coreImg
-					t.EnvDatasourceImageTag = &datasourceImg
-					t.EnvGrafanaImageTag = &grafanaImg
-					t.EnvReportsImageTag = &reportsImg
-					t.EnvDatabaseImageTag = &databaseImg
-					t.EnvStorageImageTag = &storageImg
-					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
-					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
-					t.EnvAgentProxyImageTag = &agentProxyImg
-				})
-				JustBeforeEach(func() {
-					t.reconcileCryostatFully()
-				})
-				It("should create deployment with the expected tags", func() {
-					t.expectMainDeployment()
-					t.checkReportsDeployment()
-				})
-				It("should set ImagePullPolicy to IfNotPresent", func() {
-					containers := mainDeploy.Spec.Template.Spec.Containers
-					Expect(containers).To(HaveLen(5))
-					for _, container := range containers {
-						fmt.Println(container.Image)
-						Expect(container.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					}
-					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
-					Expect(databaseContainers).To(HaveLen(1))
-					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					storageContainers := storageDeploy.Spec.Template.Spec.Containers
-					Expect(storageContainers).To(HaveLen(1))
-					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
-					Expect(reportContainers).To(HaveLen(1))
-					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-				})
+		})
+		Context("with web identity credentials and object storage retention", func() {
+			BeforeEach(func() {
+				cr := t.NewCryostatWithS3WebIdentity()
+				cr.Spec.ObjectStorageOptions.Retention = t.NewCryostatWithStorageRetention().Spec.ObjectStorageOptions.Retention
+				t.objs = append(t.objs, cr.Object)
 			})
-			Context("by digest", func() {
-				BeforeEach(func() {
-					coreImg := "my/core-image@sha256:99b57e9b8880bc5d4d799b508603628c37c3e6a0d4bdd0988e9dc3ad8e04c495"
-					datasourceImg := "my/datasource-image@sha256:59ded87392077c2371b26e021aade0409855b597383fa78e549eefafab8fc90c"
-					grafanaImg := "my/grafana-image@sha256:e5bc16c2c5b69cd6fd8fdf1381d0a8b6cc9e01d92b9e1bb0a61ed89196563c72"
-					reportsImg := "my/reports-image@sha256:8a23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
-					storageImg := "my/storage-image@sha256:8b23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
-					databaseImg := "my/database-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
-					oauth2ProxyImage := "my/authproxy-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
-					openshiftAuthProxyImage := "my/openshift-authproxy-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
-					agentProxyImg := "my/agent-proxy@sha256:2da2edd513ce134e1b99ea61e84b794c2dece7bd24b9949cc267a1c29020f26a"
-					t.EnvCoreImageTag = &coreImg
-					t.EnvDatasourceImageTag = &datasourceImg
-					t.EnvGrafanaImageTag = &grafanaImg
-					t.EnvReportsImageTag = &reportsImg
-					t.EnvDatabaseImageTag = &databaseImg
-					t.EnvStorageImageTag = &storageImg
-					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImage
-					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImage
-					t.EnvAgentProxyImageTag = &agentProxyImg
-				})
-				It("should create deployment with the expected tags", func() {
-					t.expectMainDeployment()
-					t.checkReportsDeployment()
-				})
-				It("should set ImagePullPolicy to IfNotPresent", func() {
-					containers := mainDeploy.Spec.Template.Spec.Containers
-					Expect(containers).To(HaveLen(5))
-					for _, container := range containers {
-						Expect(container.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					}
-					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
-					Expect(databaseContainers).To(HaveLen(1))
-					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					storageContainers := storageDeploy.Spec.Template.Spec.Containers
-					Expect(storageContainers).To(HaveLen(1))
-					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
-					Expect(reportContainers).To(HaveLen(1))
-					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
-				})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
 			})
-			Context("with latest", func() {
-				BeforeEach(func() {
-					coreImg := "my/core-image:latest"
-					datasourceImg := "my/datasource-image:latest"
-					grafanaImg := "my/grafana-image:latest"
-					reportsImg := "my/reports-image:latest"
-					oauth2ProxyImg := "my/authproxy-image:latest"
-					openshiftAuthProxyImg := "my/openshift-authproxy-image:latest"
-					dbImg := "my/db-image:latest"
-					storageImg := "my/storage-image:latest"
-					agentProxyImg := "my/agent-proxy:latest"
-					t.EnvCoreImageTag = &coreImg
-					t.EnvDatasourceImageTag = &datasourceImg
-					t.EnvGrafanaImageTag = &grafanaImg
-					t.EnvReportsImageTag = &reportsImg
-					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
-					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
-					t.EnvDatabaseImageTag = &dbImg
-					t.EnvStorageImageTag = &storageImg
-					t.EnvAgentProxyImageTag = &agentProxyImg
-				})
-				It("should create deployment with the expected tags", func() {
-					t.expectMainDeployment()
-					t.checkReportsDeployment()
-				})
-				It("should set ImagePullPolicy to Always", func() {
-					containers := mainDeploy.Spec.Template.Spec.Containers
-					Expect(containers).To(HaveLen(5))
-					for _, container := range containers {
-						Expect(container.ImagePullPolicy).To(Equal(corev1.PullAlways), "Container %s", container.Image)
-					}
-					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
-					Expect(databaseContainers).To(HaveLen(1))
-					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-					storageContainers := storageDeploy.Spec.Template.Spec.Containers
-					Expect(storageContainers).To(HaveLen(1))
-					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
-					Expect(reportContainers).To(HaveLen(1))
-					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
-				})
+			It("should report that static credentials are required", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageRetentionReady, metav1.ConditionFalse, "ObjectStorageCredentialsUnsupported")
+				Expect(t.getCryostatInstance().Status.StorageRetention).To(BeEmpty())
+			})
+			It("should not run the retention Jobs", func() {
+				t.expectNoStorageLifecycleJob()
+				t.expectNoStorageRetentionCronJob()
+			})
+			It("should still deploy Cryostat", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
+				t.getDeployment(t.Name)
 			})
 		})
-		Context("when deleted", func() {
+		Context("with S3 storage bucket names configuration", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostat().Object)
+				secretName := "external-s3-creds"
+				t.StorageSecret = t.NewExternalStorageSecret(secretName)
+				t.objs = append(t.objs, t.NewCryostatWithCustomizedStorageBucketNames().Object, t.StorageSecret)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("ClusterRoleBinding exists", func() {
-				JustBeforeEach(func() {
-					t.reconcileDeletedCryostat()
-				})
-				It("should delete the ClusterRoleBinding", func() {
-					t.checkClusterRoleBindingDeleted()
-				})
-				It("should delete Cryostat", func() {
-					t.expectNoCryostat()
+			It("Should update the core deployment", func() {
+				t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
+					{
+						Name:  "STORAGE_BUCKETS_ARCHIVES_NAME",
+						Value: "a",
+					},
+					{
+						Name:  "CRYOSTAT_SERVICES_REPORTS_STORAGE_CACHE_NAME",
+						Value: "b",
+					},
+					{
+						Name:  "STORAGE_BUCKETS_EVENT_TEMPLATES_NAME",
+						Value: "c",
+					},
+					{
+						Name:  "STORAGE_BUCKETS_PROBE_TEMPLATES_NAME",
+						Value: "d",
+					},
+					{
+						Name:  "STORAGE_BUCKETS_HEAP_DUMPS_NAME",
+						Value: "e",
+					},
+					{
+						Name:  "STORAGE_BUCKETS_THREAD_DUMPS_NAME",
+						Value: "f",
+					},
+					{
+						Name:  "STORAGE_BUCKETS_METADATA_NAME",
+						Value: "z",
+					},
 				})
 			})
-			Context("ClusterRoleBinding does not exist", func() {
+		})
+		Context("with custom PVC spec overriding all defaults", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithPVCSpec().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create the PVC with requested spec", func() {
+				t.expectPVC(t.NewCustomDatabasePVC())
+				t.expectPVC(t.NewCustomStoragePVC())
+			})
+		})
+		Context("with custom PVC spec overriding some defaults", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithPVCSpecSomeDefault().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create the PVC with requested spec", func() {
+				t.expectPVC(t.NewCustomDatabasePVCSomeDefault())
+				t.expectPVC(t.NewCustomStoragePVCSomeDefault())
+			})
+		})
+		Context("with custom PVC config with no spec", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithPVCLabelsOnly().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create the PVC with requested label", func() {
+				t.expectPVC(t.NewDefaultDatabasePVCWithLabel())
+				t.expectPVC(t.NewDefaultStoragePVCWithLabel())
+			})
+		})
+		Context("with a legacy PVC spec", func() {
+			Context("with custom PVC spec overriding all defaults", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithPVCSpecLegacy().Object)
+				})
 				JustBeforeEach(func() {
-					err := t.Client.Delete(context.Background(), t.NewClusterRoleBinding())
-					Expect(err).ToNot(HaveOccurred())
-					t.reconcileDeletedCryostat()
+					t.reconcileCryostatFully()
 				})
-				It("should delete Cryostat", func() {
-					t.expectNoCryostat()
+				It("should create the PVC with requested spec", func() {
+					t.expectPVC(t.NewCustomDatabasePVCLegacy())
+					t.expectPVC(t.NewCustomStoragePVCLegacy())
 				})
 			})
-			Context("RoleBinding exists", func() {
-				JustBeforeEach(func() {
-					t.reconcileDeletedCryostat()
+			Context("with custom PVC spec overriding some defaults", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithPVCSpecSomeDefaultLegacy().Object)
 				})
-				It("should delete the RoleBinding", func() {
-					t.checkRoleBindingsDeleted()
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
 				})
-				It("should delete Cryostat", func() {
-					t.expectNoCryostat()
+				It("should create the PVC with requested spec", func() {
+					t.expectPVC(t.NewCustomDatabasePVCSomeDefaultLegacy())
+					t.expectPVC(t.NewCustomStoragePVCSomeDefaultLegacy())
 				})
 			})
-			Context("RoleBinding does not exist", func() {
+			Context("with custom PVC config with no spec", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithPVCLabelsOnlyLegacy().Object)
+				})
 				JustBeforeEach(func() {
-					err := t.Client.Delete(context.Background(), t.NewRoleBinding(t.Namespace))
-					Expect(err).ToNot(HaveOccurred())
-					t.reconcileDeletedCryostat()
+					t.reconcileCryostatFully()
 				})
-				It("should delete Cryostat", func() {
-					t.expectNoCryostat()
+				It("should create the PVC with requested label", func() {
+					t.expectPVC(t.NewDefaultDatabasePVCWithLabelLegacy())
+					t.expectPVC(t.NewDefaultStoragePVCWithLabelLegacy())
 				})
 			})
 		})
-		Context("on OpenShift", func() {
+		Context("with both custom PVC specs overriding all defaults", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostat().Object)
+				t.objs = append(t.objs, t.NewCryostatWithPVCSpecBoth().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create ConsoleLink", func() {
-				t.expectConsoleLink()
+			It("should create the PVC with requested spec", func() {
+				t.expectPVC(t.NewCustomDatabasePVC())
+				t.expectPVC(t.NewCustomStoragePVC())
 			})
-			Context("with an existing ConsoleLink", func() {
-				var oldLink *consolev1.ConsoleLink
-				BeforeEach(func() {
-					oldLink = t.OtherConsoleLink()
-					t.objs = append(t.objs, oldLink)
-				})
-				It("should update the ConsoleLink", func() {
-					link := &consolev1.ConsoleLink{}
-					expectedLink := t.NewConsoleLink()
-					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedLink.Name}, link)
-					Expect(err).ToNot(HaveOccurred())
-					// Existing labels and annotations should remain
-					Expect(link.Labels).To(Equal(oldLink.Labels))
-					Expect(link.Annotations).To(Equal(oldLink.Annotations))
-
-					// Check managed spec fields
-					Expect(link.Spec.Link).To(Equal(expectedLink.Spec.Link))
-					Expect(link.Spec.Location).To(Equal(expectedLink.Spec.Location))
-					Expect(link.Spec.NamespaceDashboard).To(Equal(expectedLink.Spec.NamespaceDashboard))
-				})
+		})
+		Context("with an existing DB PVC", func() {
+			var oldPVC *corev1.PersistentVolumeClaim
+			BeforeEach(func() {
+				oldPVC = t.NewDefaultPVC()
+				t.objs = append(t.objs, t.NewCryostatWithPVCSpec().Object, oldPVC)
 			})
-			Context("with an existing application url in APIServer AdditionalCORSAllowedOrigins", func() {
+			Context("that successfully updates", func() {
 				BeforeEach(func() {
-					t.objs = []ctrlclient.Object{
-						t.NewApiServerWithApplicationURL(),
-						t.NewNamespace(),
-					}
+					// Add some labels and annotations to test merging
+					metav1.SetMetaDataLabel(&oldPVC.ObjectMeta, "my", "other-label")
+					metav1.SetMetaDataLabel(&oldPVC.ObjectMeta, "another", "label")
+					metav1.SetMetaDataAnnotation(&oldPVC.ObjectMeta, "my/custom", "other-annotation")
+					metav1.SetMetaDataAnnotation(&oldPVC.ObjectMeta, "another/custom", "annotation")
 				})
-				It("should remove the application url", func() {
-					apiServer := &configv1.APIServer{}
-					err := t.Client.Get(context.Background(), types.NamespacedName{Name: "cluster"}, apiServer)
-					Expect(err).ToNot(HaveOccurred())
-					Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).ToNot(ContainElement(fmt.Sprintf("https://%s\\.example\\.com", t.Name)))
-					Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).To(ContainElement("https://an-existing-user-specified\\.allowed\\.origin\\.com"))
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should update metadata and resource requests", func() {
+					expected := t.NewDefaultPVC()
+					metav1.SetMetaDataLabel(&expected.ObjectMeta, "my", "database")
+					metav1.SetMetaDataLabel(&expected.ObjectMeta, "another", "label")
+					metav1.SetMetaDataLabel(&expected.ObjectMeta, "app", t.Name)
+					metav1.SetMetaDataAnnotation(&expected.ObjectMeta, "my/custom", "database")
+					metav1.SetMetaDataAnnotation(&expected.ObjectMeta, "another/custom", "annotation")
+					expected.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("5Gi")
+					t.expectPVC(expected)
 				})
 			})
-			It("should add the finalizer", func() {
-				t.expectCryostatFinalizerPresent()
+			Context("that fails to update", func() {
+				JustBeforeEach(func() {
+					// Replace client with one that fails to update the PVC
+					invalidErr := kerrors.NewInvalid(schema.ParseGroupKind("PersistentVolumeClaim"), oldPVC.Name, field.ErrorList{
+						field.Forbidden(field.NewPath("spec"), "test error"),
+					})
+					origClient := t.reconciler.GetConfig().Client
+					t.reconciler.GetConfig().Client = test.NewClientWithUpdateError(origClient, invalidErr, func(obj ctrlclient.Object) bool {
+						return test.FailOnMatch(obj, oldPVC, t.Client.Scheme())
+					})
+
+					// Expect an Invalid status error after reconciling
+					Eventually(func() bool {
+						_, err := t.reconcile()
+						if err != nil {
+							return kerrors.IsInvalid(err)
+						}
+						return false
+					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(BeTrue())
+				})
+				It("should emit a PersistentVolumeClaimInvalid event", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					var eventMsg string
+					Expect(recorder.Events).To(Receive(&eventMsg))
+					Expect(eventMsg).To(ContainSubstring("PersistentVolumeClaimInvalid"))
+				})
 			})
-			Context("with restricted SCC", func() {
-				BeforeEach(func() {
-					t.objs = []ctrlclient.Object{
-						t.NewCryostat().Object, t.NewNamespaceWithSCCSupGroups(), t.NewApiServer(),
-					}
+		})
+		Context("with a PVC backed by an expandable storage class", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithPVCStorageClass("expandable").Object,
+					t.NewStorageClass("expandable", true))
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+				t.bindPVC(t.Name+"-database", "500Mi")
+
+				cr := t.getCryostatInstance()
+				cr.Spec.StorageOptions.Database.PVC.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
+				t.updateCryostatInstance(cr)
+				t.reconcileCryostatFully()
+			})
+			It("should expand the PVC in place", func() {
+				pvc := t.getPVC(t.Name + "-database")
+				Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+				Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(t.Name + "-database"))
+				t.expectNoVolumeMigrationJob("database")
+			})
+			It("should report the resize", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseVolumeReady, metav1.ConditionFalse, "Resizing")
+				requested := resource.MustParse("1Gi")
+				capacity := resource.MustParse("500Mi")
+				Expect(t.getCryostatInstance().Status.Volumes).To(ContainElement(operatorv1beta2.VolumeStatus{
+					Component: "database",
+					ClaimName: t.Name + "-database",
+					Requested: &requested,
+					Capacity:  &capacity,
+				}))
+			})
+			Context("when the resize completes", func() {
+				JustBeforeEach(func() {
+					t.bindPVC(t.Name+"-database", "1Gi")
+					t.reconcileCryostatFully()
 				})
-				It("should set fsGroup to value derived from namespace", func() {
-					deploy := &appsv1.Deployment{}
-					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deploy)
-					Expect(err).ToNot(HaveOccurred())
-					sc := deploy.Spec.Template.Spec.SecurityContext
-					Expect(sc).ToNot(BeNil())
-					Expect(sc.FSGroup).ToNot(BeNil())
-					Expect(*sc.FSGroup).To(Equal(int64(1000130000)))
+				It("should report the volume is ready", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseVolumeReady, metav1.ConditionTrue, "Reconciled")
 				})
 			})
-			Context("when deleted", func() {
-				Context("ConsoleLink exists", func() {
-					JustBeforeEach(func() {
-						t.reconcileDeletedCryostat()
-					})
-					It("should delete the ConsoleLink", func() {
-						link := &consolev1.ConsoleLink{}
-						expectedLink := t.NewConsoleLink()
-						err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedLink.Name}, link)
-						Expect(kerrors.IsNotFound(err)).To(BeTrue())
-					})
-					It("should remove the application url from APIServer AdditionalCORSAllowedOrigins", func() {
-						apiServer := &configv1.APIServer{}
-						err := t.Client.Get(context.Background(), types.NamespacedName{Name: "cluster"}, apiServer)
-						Expect(err).ToNot(HaveOccurred())
-						Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).ToNot(ContainElement(fmt.Sprintf("https://%s\\.example\\.com", t.Name)))
-						Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).To(ContainElement("https://an-existing-user-specified\\.allowed\\.origin\\.com"))
-					})
-					It("should delete Cryostat", func() {
-						t.expectNoCryostat()
-					})
+			Context("when the storage class changes", func() {
+				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					cr.Spec.StorageOptions.ObjectStorage.PVC.Spec.StorageClassName = &[]string{"other"}[0]
+					t.updateCryostatInstance(cr)
+					t.reconcileUntilVolumeMigrating()
 				})
-				Context("ConsoleLink does not exist", func() {
-					JustBeforeEach(func() {
-						err := t.Client.Delete(context.Background(), t.NewConsoleLink())
-						Expect(err).ToNot(HaveOccurred())
-						t.reconcileDeletedCryostat()
-					})
-					It("should delete Cryostat", func() {
-						t.expectNoCryostat()
-					})
+				It("should copy the storage volume to a new PVC", func() {
+					job := t.getVolumeMigrationJob("storage")
+					target := job.Annotations["operator.cryostat.io/migration-target"]
+					Expect(target).To(HavePrefix(t.Name + "-storage-"))
+					Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/migration-source", t.Name+"-storage"))
+					Expect(t.getPVC(target).Spec.StorageClassName).To(Equal(&[]string{"other"}[0]))
+				})
+				It("should stop the storage deployment", func() {
+					deployment := t.getDeployment(t.Name + "-storage")
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(0))
+					Expect(t.getDeploymentClaimName(t.Name + "-storage")).To(Equal(t.Name + "-storage"))
 				})
 			})
-			Context("with Insights enabled", func() {
+		})
+		Context("with a PVC backed by the default storage class", func() {
+			BeforeEach(func() {
+				cr := t.NewCryostatWithPVCStorageClass("")
+				cr.Spec.StorageOptions.Database.PVC.Spec.StorageClassName = nil
+				t.objs = append(t.objs, cr.Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+				Expect(t.getPVC(t.Name + "-database").Spec.StorageClassName).To(BeNil())
+
+				cr := t.getCryostatInstance()
+				cr.Spec.StorageOptions.Database.PVC.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
+				t.updateCryostatInstance(cr)
+			})
+			Context("that allows expansion", func() {
 				BeforeEach(func() {
-					t.InsightsURL = "http://insights-proxy.foo.svc.cluster.local"
+					t.objs = append(t.objs, t.NewDefaultStorageClass("standard", true), t.NewStorageClass("other", false))
 				})
-				JustBeforeEach(func() {
+				It("should expand the PVC in place", func() {
 					t.reconcileCryostatFully()
+					pvc := t.getPVC(t.Name + "-database")
+					Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+					t.expectNoVolumeMigrationJob("database")
 				})
-				It("should create deployment", func() {
-					t.expectMainDeployment()
+			})
+			Context("that does not allow expansion", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewDefaultStorageClass("standard", false), t.NewStorageClass("other", true))
+				})
+				It("should copy the data to a new PVC", func() {
+					t.reconcileUntilVolumeMigrating()
+					job := t.getVolumeMigrationJob("database")
+					Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/migration-source", t.Name+"-database"))
 				})
 			})
 		})
-		Context("with cert-manager disabled in CR", func() {
+		Context("with a PVC backed by a storage class that does not allow expansion", func() {
 			BeforeEach(func() {
-				t.TLS = false
-				t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
+				t.objs = append(t.objs, t.NewCryostatWithPVCStorageClass("fixed").Object,
+					t.NewStorageClass("fixed", false))
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
+
+				cr := t.getCryostatInstance()
+				cr.Spec.StorageOptions.Database.PVC.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Gi")
+				t.updateCryostatInstance(cr)
+				t.reconcileUntilVolumeMigrating()
+			})
+			It("should copy the data to a new PVC", func() {
+				job := t.getVolumeMigrationJob("database")
+				Expect(metav1.IsControlledBy(job, t.getCryostatInstance().Object)).To(BeTrue())
+				Expect(job.Labels).To(Equal(t.NewVolumeMigrationPodLabels("database")))
+				Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/migration-source", t.Name+"-database"))
+				target := job.Annotations["operator.cryostat.io/migration-target"]
+				Expect(target).To(HavePrefix(t.Name + "-database-"))
+
+				container := job.Spec.Template.Spec.Containers[0]
+				Expect(container.Command[2]).To(ContainSubstring("cp -a"))
+				Expect(job.Spec.Template.Spec.Volumes).To(ConsistOf(
+					HaveField("VolumeSource.PersistentVolumeClaim", Equal(&corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: t.Name + "-database",
+						ReadOnly:  true,
+					})),
+					HaveField("VolumeSource.PersistentVolumeClaim", Equal(&corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: target,
+					})),
+				))
+
+				pvc := t.getPVC(target)
+				Expect(metav1.IsControlledBy(pvc, t.getCryostatInstance().Object)).To(BeTrue())
+				Expect(pvc.Spec.StorageClassName).To(Equal(&[]string{"fixed"}[0]))
+				Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("1Gi")))
+			})
+			It("should leave the original PVC unchanged", func() {
+				pvc := t.getPVC(t.Name + "-database")
+				Expect(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).To(Equal(resource.MustParse("500Mi")))
+			})
+			It("should stop the database during the copy", func() {
+				deployment := t.getDeployment(t.Name + "-database")
+				Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(0))
+				Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(t.Name + "-database"))
+			})
+			It("should report the migration", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseVolumeReady, metav1.ConditionFalse, "Migrating")
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionFalse, "VolumeMigrationInProgress")
+				Expect(t.getCryostatInstance().Status.Volumes).To(ContainElement(And(
+					HaveField("Component", "database"),
+					HaveField("ClaimName", t.Name+"-database"),
+					HaveField("MigrationTarget", HavePrefix(t.Name+"-database-")),
+					HaveField("StoppedReplicas", Equal(&[]int32{1}[0])),
+				)))
+			})
+			Context("when the copy completes", func() {
+				var target string
+				JustBeforeEach(func() {
+					target = t.getVolumeMigrationJob("database").Annotations["operator.cryostat.io/migration-target"]
+					t.setVolumeMigrationJobCondition("database", batchv1.JobComplete)
+					t.reconcileCryostatFully()
+				})
+				It("should switch the database to the new PVC", func() {
+					deployment := t.getDeployment(t.Name + "-database")
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+					Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(target))
+					Expect(t.getCryostatInstance().Status.Volumes).To(ContainElement(And(
+						HaveField("Component", "database"),
+						HaveField("ClaimName", target),
+						HaveField("MigrationTarget", BeEmpty()),
+						HaveField("StoppedReplicas", BeNil()),
+					)))
+				})
+				It("should retain the original PVC", func() {
+					t.getPVC(t.Name + "-database")
+				})
+				It("should delete the Job", func() {
+					t.expectNoVolumeMigrationJob("database")
+				})
+				It("should emit a PersistentVolumeClaimMigrated event", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					Eventually(recorder.Events).Should(Receive(ContainSubstring("PersistentVolumeClaimMigrated")))
+				})
+				It("should not migrate again", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionTrue, "Reconciled")
+				})
 			})
-			It("should create deployment and set owner", func() {
-				t.expectMainDeployment()
-			})
-			It("should not create certificates", func() {
-				certs := &certv1.CertificateList{}
-				err := t.Client.List(context.Background(), certs, &ctrlclient.ListOptions{
-					Namespace: t.Namespace,
+			Context("when the copy fails", func() {
+				JustBeforeEach(func() {
+					t.setVolumeMigrationJobCondition("database", batchv1.JobFailed)
+					_, err := t.reconcile()
+					Expect(err).To(HaveOccurred())
+				})
+				It("should restart the database with the original PVC", func() {
+					deployment := t.getDeployment(t.Name + "-database")
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+					Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(t.Name + "-database"))
+				})
+				It("should report the failure", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseVolumeReady, metav1.ConditionFalse, "MigrationFailed")
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionFalse, "VolumeMigrationFailed")
 				})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(certs.Items).To(BeEmpty())
 			})
-			It("should create routes with edge TLS termination", func() {
-				t.expectRoutes()
+			Context("when the copy fails after the database was stopped with other replicas", func() {
+				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					for i := range cr.Status.Volumes {
+						if cr.Status.Volumes[i].Component == "database" {
+							cr.Status.Volumes[i].StoppedReplicas = &[]int32{2}[0]
+						}
+					}
+					Expect(t.Client.Status().Update(context.Background(), cr.Object)).To(Succeed())
+					t.setVolumeMigrationJobCondition("database", batchv1.JobFailed)
+					_, err := t.reconcile()
+					Expect(err).To(HaveOccurred())
+				})
+				It("should restore the recorded replicas", func() {
+					deployment := t.getDeployment(t.Name + "-database")
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(2))
+				})
 			})
-			It("should create the agent proxy config map", func() {
-				t.expectAgentProxyConfigMap()
+			Context("when the request is reverted", func() {
+				var target string
+				JustBeforeEach(func() {
+					target = t.getVolumeMigrationJob("database").Annotations["operator.cryostat.io/migration-target"]
+					cr := t.getCryostatInstance()
+					cr.Spec.StorageOptions.Database.PVC.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("500Mi")
+					t.updateCryostatInstance(cr)
+					t.reconcileCryostatFully()
+				})
+				It("should abandon the migration", func() {
+					t.expectNoVolumeMigrationJob("database")
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: target, Namespace: t.Namespace},
+						&corev1.PersistentVolumeClaim{})
+					Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(t.Name + "-database"))
+				})
 			})
 		})
-		Context("with cert-manager not configured in CR", func() {
+		Context("when switching from emptyDir to a PVC", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatCertManagerUndefined().Object)
+				t.objs = append(t.objs, t.NewCryostatWithDefaultEmptyDir().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create deployment and set owner", func() {
-				t.expectMainDeployment()
+			Context("for the database", func() {
+				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					cr.Spec.StorageOptions.Database.EmptyDir = nil
+					t.updateCryostatInstance(cr)
+					t.reconcileUntilVolumeMigrating()
+				})
+				It("should copy the database from the running server", func() {
+					job := t.getVolumeMigrationJob("database")
+					Expect(job.Annotations).To(HaveKeyWithValue("operator.cryostat.io/migration-source", ""))
+					target := job.Annotations["operator.cryostat.io/migration-target"]
+					Expect(target).To(HavePrefix(t.Name + "-database-"))
+					t.getPVC(target)
+
+					container := job.Spec.Template.Spec.Containers[0]
+					Expect(container.Command[2]).To(ContainSubstring("pg_dump"))
+					Expect(container.Env).To(ContainElement(corev1.EnvVar{
+						Name:  "SOURCE_HOST",
+						Value: fmt.Sprintf("%s-database.%s.svc", t.Name, t.Namespace),
+					}))
+					Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+						Name:      "target",
+						MountPath: "/var/lib/pgsql",
+					}))
+				})
+				It("should keep the database running", func() {
+					deployment := t.getDeployment(t.Name + "-database")
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+					t.expectDatabaseEmptyDir(t.NewDefaultEmptyDir())
+				})
+				It("should stop Cryostat during the copy", func() {
+					deployment := t.getDeployment(t.Name)
+					Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(0))
+					Expect(t.getCryostatInstance().Status.Volumes).To(ContainElement(And(
+						HaveField("Component", "database"),
+						HaveField("StoppedReplicas", Equal(&[]int32{1}[0])),
+					)))
+				})
+				Context("when the copy completes", func() {
+					var target string
+					JustBeforeEach(func() {
+						target = t.getVolumeMigrationJob("database").Annotations["operator.cryostat.io/migration-target"]
+						t.setVolumeMigrationJobCondition("database", batchv1.JobComplete)
+						t.reconcileCryostatFully()
+					})
+					It("should switch the database to the new PVC", func() {
+						Expect(t.getDeploymentClaimName(t.Name + "-database")).To(Equal(target))
+						t.expectNoVolumeMigrationJob("database")
+					})
+					It("should restart Cryostat", func() {
+						deployment := t.getDeployment(t.Name)
+						Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(1))
+						Expect(t.getCryostatInstance().Status.Volumes).To(ContainElement(And(
+							HaveField("Component", "database"),
+							HaveField("StoppedReplicas", BeNil()),
+						)))
+					})
+				})
+				Context("when the copy fails", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						for i := range cr.Status.Volumes {
+							if cr.Status.Volumes[i].Component == "database" {
+								cr.Status.Volumes[i].StoppedReplicas = &[]int32{2}[0]
+							}
+						}
+						Expect(t.Client.Status().Update(context.Background(), cr.Object)).To(Succeed())
+						t.setVolumeMigrationJobCondition("database", batchv1.JobFailed)
+						_, err := t.reconcile()
+						Expect(err).To(HaveOccurred())
+					})
+					It("should restart Cryostat with the recorded replicas", func() {
+						deployment := t.getDeployment(t.Name)
+						Expect(*deployment.Spec.Replicas).To(BeEquivalentTo(2))
+						t.expectDatabaseEmptyDir(t.NewDefaultEmptyDir())
+					})
+					It("should report the failure", func() {
+						t.checkConditionPresent(operatorv1beta2.ConditionTypeDatabaseVolumeReady, metav1.ConditionFalse, "MigrationFailed")
+					})
+				})
+				It("should allow the Job to connect to the database", func() {
+					policy := &netv1.NetworkPolicy{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-db-internal-ingress", Namespace: t.Namespace}, policy)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(policy.Spec.Ingress[0].From).To(ContainElement(HaveField("PodSelector.MatchLabels",
+						t.NewVolumeMigrationPodLabels("database"))))
+				})
 			})
-			It("should create certificates", func() {
-				t.expectCertificates()
+			Context("for object storage", func() {
+				JustBeforeEach(func() {
+					cr := t.getCryostatInstance()
+					cr.Spec.StorageOptions.ObjectStorage.EmptyDir = nil
+					t.updateCryostatInstance(cr)
+					Eventually(func() error {
+						_, err := t.reconcile()
+						return err
+					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(MatchError(ContainSubstring("operator.cryostat.io/discard-emptydir-data=true")))
+				})
+				It("should keep using the emptyDir volume", func() {
+					deployment := t.getDeployment(t.Name + "-storage")
+					Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(And(
+						HaveField("Name", t.Name+"-storage"),
+						HaveField("VolumeSource.EmptyDir", Not(BeNil())),
+					)))
+				})
+				It("should report that the data loss must be confirmed", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageVolumeReady, metav1.ConditionFalse, "DataLossNotConfirmed")
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeStorageReady, metav1.ConditionFalse, "DataLossNotConfirmed")
+				})
+				Context("when the data loss is confirmed", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Object.SetAnnotations(map[string]string{"operator.cryostat.io/discard-emptydir-data": "true"})
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
+					})
+					It("should switch to the PVC", func() {
+						Expect(t.getDeploymentClaimName(t.Name + "-storage")).To(Equal(t.Name + "-storage"))
+						t.expectNoVolumeMigrationJob("storage")
+					})
+					It("should warn that the contents were not copied", func() {
+						recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+						Eventually(recorder.Events).Should(Receive(ContainSubstring("not copied")))
+					})
+				})
 			})
-			It("should create routes with re-encrypt TLS termination", func() {
-				t.expectRoutes()
+		})
+		Context("with custom EmptyDir config", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithDefaultEmptyDir().Object)
 			})
-			It("should set TLSSetupComplete condition", func() {
+			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
-				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-					"AllCertificatesReady")
 			})
-			It("should create the agent proxy config map", func() {
-				t.expectAgentProxyConfigMap()
+			It("should create the EmptyDir with default specs", func() {
+				t.expectDatabaseEmptyDir(t.NewDefaultEmptyDir())
+				t.expectStorageEmptyDir(t.NewDefaultEmptyDir())
 			})
 		})
-		Context("with DISABLE_SERVICE_TLS=true", func() {
+		Context("with custom EmptyDir config with requested spec", func() {
 			BeforeEach(func() {
-				disableTLS := true
-				t.EnvDisableTLS = &disableTLS
-				t.TLS = false
-				t.objs = append(t.objs, t.NewCryostatCertManagerUndefined().Object)
+				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpec().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should create deployment and set owner", func() {
-				t.expectMainDeployment()
-			})
-			It("should not create certificates", func() {
-				certs := &certv1.CertificateList{}
-				err := t.Client.List(context.Background(), certs, &ctrlclient.ListOptions{
-					Namespace: t.Namespace,
-				})
-				Expect(err).ToNot(HaveOccurred())
-				Expect(certs.Items).To(BeEmpty())
+			It("should create the EmptyDir with requested specs", func() {
+				t.expectDatabaseEmptyDir(t.NewCustomDatabaseEmptyDir())
+				t.expectStorageEmptyDir(t.NewCustomStorageEmptyDir())
 			})
-			It("should create routes with edge TLS termination", func() {
-				t.expectRoutes()
+		})
+		Context("with legacy custom EmptyDir config with requested spec", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpecLegacy().Object)
 			})
-			It("should set TLSSetupComplete Condition", func() {
+			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
-				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-					"CertManagerDisabled")
 			})
-			It("should create the agent proxy config map", func() {
-				t.expectAgentProxyConfigMap()
+			It("should create the EmptyDir with requested specs", func() {
+				t.expectDatabaseEmptyDir(t.NewCustomEmptyDirLegacy())
+				t.expectStorageEmptyDir(t.NewCustomEmptyDirLegacy())
 			})
 		})
-		Context("Disable cert-manager after being enabled", func() {
+		Context("with both custom EmptyDir configs with requested spec", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostat().Object)
+				t.objs = append(t.objs, t.NewCryostatWithEmptyDirSpecBoth().Object)
 			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
-
-				cryostat := t.getCryostatInstance()
-
-				t.TLS = false
+			})
+			It("should create the EmptyDir with requested specs", func() {
+				t.expectDatabaseEmptyDir(t.NewCustomDatabaseEmptyDir())
+				t.expectStorageEmptyDir(t.NewCustomStorageEmptyDir())
+			})
+		})
+		Context("with overridden image tags", func() {
+			var mainDeploy, databaseDeploy, storageDeploy, reportsDeploy *appsv1.Deployment
+			BeforeEach(func() {
+				t.ReportReplicas = 1
+				t.objs = append(t.objs, t.NewCryostatWithReportsSvc().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+				mainDeploy = &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, mainDeploy)
+				Expect(err).ToNot(HaveOccurred())
+				databaseDeploy = &appsv1.Deployment{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database", Namespace: t.Namespace}, databaseDeploy)
+				Expect(err).ToNot(HaveOccurred())
+				storageDeploy = &appsv1.Deployment{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage", Namespace: t.Namespace}, storageDeploy)
+				Expect(err).ToNot(HaveOccurred())
+				reportsDeploy = &appsv1.Deployment{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-reports", Namespace: t.Namespace}, reportsDeploy)
+				Expect(err).ToNot(HaveOccurred())
+			})
+			Context("for development", func() {
+				BeforeEach(func() {
+					coreImg := "my/core-image:1.0.0-SNAPSHOT"
+					datasourceImg := "my/datasource-image:1.0.0-BETA25"
+					grafanaImg := "my/grafana-image:1.0.0-dev"
+					reportsImg := "my/reports-image:1.0.0-SNAPSHOT"
+					storageImg := "my/storage-image:1.0.0-dev"
+					databaseImg := "my/database-image:1.0.0-dev"
+					oauth2ProxyImg := "my/auth-proxy:1.0.0-dev"
+					openshiftAuthProxyImg := "my/openshift-auth-proxy:1.0.0-dev"
+					agentProxyImg := "my/agent-proxy:1.0.0-dev"
+					t.EnvCoreImageTag = &coreImg
+					t.EnvDatasourceImageTag = &datasourceImg
+					t.EnvGrafanaImageTag = &grafanaImg
+					t.EnvReportsImageTag = &reportsImg
+					t.EnvDatabaseImageTag = &databaseImg
+					t.EnvStorageImageTag = &storageImg
+					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
+					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
+					t.EnvAgentProxyImageTag = &agentProxyImg
+				})
+				It("should create deployment with the expected tags", func() {
+					t.expectMainDeployment()
+					t.expectDatabaseDeployment()
+					t.expectStorageDeployment()
+					t.checkReportsDeployment()
+				})
+				It("should set ImagePullPolicy to Always", func() {
+					containers := mainDeploy.Spec.Template.Spec.Containers
+					Expect(containers).To(HaveLen(5))
+					for _, container := range containers {
+						Expect(container.ImagePullPolicy).To(Equal(corev1.PullAlways))
+					}
+					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
+					Expect(databaseContainers).To(HaveLen(1))
+					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+					storageContainers := storageDeploy.Spec.Template.Spec.Containers
+					Expect(storageContainers).To(HaveLen(1))
+					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
+					Expect(reportContainers).To(HaveLen(1))
+					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+				})
+			})
+			Context("for release", func() {
+				BeforeEach(func() {
+					coreImg := "my/core-image:1.0.0"
+					datasourceImg := "my/datasource-image:1.0.0"
+					grafanaImg := "my/grafana-image:1.0.0"
+					reportsImg := "my/reports-image:1.0.0"
+					storageImg := "my/storage-image:1.0.0"
+					databaseImg := "my/database-image:1.0.0"
+					oauth2ProxyImg := "my/authproxy-image:1.0.0"
+					openshiftAuthProxyImg := "my/openshift-authproxy-image:1.0.0"
+					agentProxyImg := "my/agent-proxy:1.0.0"
+					t.EnvCoreImageTag = &coreImg
+					t.EnvDatasourceImageTag = &datasourceImg
+					t.EnvGrafanaImageTag = &grafanaImg
+					t.EnvReportsImageTag = &reportsImg
+					t.EnvDatabaseImageTag = &databaseImg
+					t.EnvStorageImageTag = &storageImg
+					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
+					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
+					t.EnvAgentProxyImageTag = &agentProxyImg
+				})
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should create deployment with the expected tags", func() {
+					t.expectMainDeployment()
+					t.checkReportsDeployment()
+				})
+				It("should set ImagePullPolicy to IfNotPresent", func() {
+					containers := mainDeploy.Spec.Template.Spec.Containers
+					Expect(containers).To(HaveLen(5))
+					for _, container := range containers {
+						fmt.Println(container.Image)
+						Expect(container.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					}
+					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
+					Expect(databaseContainers).To(HaveLen(1))
+					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					storageContainers := storageDeploy.Spec.Template.Spec.Containers
+					Expect(storageContainers).To(HaveLen(1))
+					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
+					Expect(reportContainers).To(HaveLen(1))
+					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+				})
+			})
+			Context("by digest", func() {
+				BeforeEach(func() {
+					coreImg := "my/core-image@sha256:99b57e9b8880bc5d4d799b508603628c37c3e6a0d4bdd0988e9dc3ad8e04c495"
+					datasourceImg := "my/datasource-image@sha256:59ded87392077c2371b26e021aade0409855b597383fa78e549eefafab8fc90c"
+					grafanaImg := "my/grafana-image@sha256:e5bc16c2c5b69cd6fd8fdf1381d0a8b6cc9e01d92b9e1bb0a61ed89196563c72"
+					reportsImg := "my/reports-image@sha256:8a23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
+					storageImg := "my/storage-image@sha256:8b23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
+					databaseImg := "my/database-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
+					oauth2ProxyImage := "my/authproxy-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
+					openshiftAuthProxyImage := "my/openshift-authproxy-image@sha256:8c23ca5e8c8a343789b8c14558a44a49d35ecd130c18e62edf0d1ad9ce88d37d"
+					agentProxyImg := "my/agent-proxy@sha256:2da2edd513ce134e1b99ea61e84b794c2dece7bd24b9949cc267a1c29020f26a"
+					t.EnvCoreImageTag = &coreImg
+					t.EnvDatasourceImageTag = &datasourceImg
+					t.EnvGrafanaImageTag = &grafanaImg
+					t.EnvReportsImageTag = &reportsImg
+					t.EnvDatabaseImageTag = &databaseImg
+					t.EnvStorageImageTag = &storageImg
+					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImage
+					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImage
+					t.EnvAgentProxyImageTag = &agentProxyImg
+				})
+				It("should create deployment with the expected tags", func() {
+					t.expectMainDeployment()
+					t.checkReportsDeployment()
+				})
+				It("should set ImagePullPolicy to IfNotPresent", func() {
+					containers := mainDeploy.Spec.Template.Spec.Containers
+					Expect(containers).To(HaveLen(5))
+					for _, container := range containers {
+						Expect(container.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					}
+					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
+					Expect(databaseContainers).To(HaveLen(1))
+					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					storageContainers := storageDeploy.Spec.Template.Spec.Containers
+					Expect(storageContainers).To(HaveLen(1))
+					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
+					Expect(reportContainers).To(HaveLen(1))
+					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+				})
+			})
+			Context("with latest", func() {
+				BeforeEach(func() {
+					coreImg := "my/core-image:latest"
+					datasourceImg := "my/datasource-image:latest"
+					grafanaImg := "my/grafana-image:latest"
+					reportsImg := "my/reports-image:latest"
+					oauth2ProxyImg := "my/authproxy-image:latest"
+					openshiftAuthProxyImg := "my/openshift-authproxy-image:latest"
+					dbImg := "my/db-image:latest"
+					storageImg := "my/storage-image:latest"
+					agentProxyImg := "my/agent-proxy:latest"
+					t.EnvCoreImageTag = &coreImg
+					t.EnvDatasourceImageTag = &datasourceImg
+					t.EnvGrafanaImageTag = &grafanaImg
+					t.EnvReportsImageTag = &reportsImg
+					t.EnvOAuth2ProxyImageTag = &oauth2ProxyImg
+					t.EnvOpenShiftOAuthProxyImageTag = &openshiftAuthProxyImg
+					t.EnvDatabaseImageTag = &dbImg
+					t.EnvStorageImageTag = &storageImg
+					t.EnvAgentProxyImageTag = &agentProxyImg
+				})
+				It("should create deployment with the expected tags", func() {
+					t.expectMainDeployment()
+					t.checkReportsDeployment()
+				})
+				It("should set ImagePullPolicy to Always", func() {
+					containers := mainDeploy.Spec.Template.Spec.Containers
+					Expect(containers).To(HaveLen(5))
+					for _, container := range containers {
+						Expect(container.ImagePullPolicy).To(Equal(corev1.PullAlways), "Container %s", container.Image)
+					}
+					databaseContainers := databaseDeploy.Spec.Template.Spec.Containers
+					Expect(databaseContainers).To(HaveLen(1))
+					Expect(databaseContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+					storageContainers := storageDeploy.Spec.Template.Spec.Containers
+					Expect(storageContainers).To(HaveLen(1))
+					Expect(storageContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+					reportContainers := reportsDeploy.Spec.Template.Spec.Containers
+					Expect(reportContainers).To(HaveLen(1))
+					Expect(reportContainers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+				})
+			})
+		})
+		Context("when deleted", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			Context("ClusterRoleBinding exists", func() {
+				JustBeforeEach(func() {
+					t.reconcileDeletedCryostat()
+				})
+				It("should delete the ClusterRoleBinding", func() {
+					t.checkClusterRoleBindingDeleted()
+				})
+				It("should delete Cryostat", func() {
+					t.expectNoCryostat()
+				})
+			})
+			Context("ClusterRoleBinding does not exist", func() {
+				JustBeforeEach(func() {
+					err := t.Client.Delete(context.Background(), t.NewClusterRoleBinding())
+					Expect(err).ToNot(HaveOccurred())
+					t.reconcileDeletedCryostat()
+				})
+				It("should delete Cryostat", func() {
+					t.expectNoCryostat()
+				})
+			})
+			Context("RoleBinding exists", func() {
+				JustBeforeEach(func() {
+					t.reconcileDeletedCryostat()
+				})
+				It("should delete the RoleBinding", func() {
+					t.checkRoleBindingsDeleted()
+				})
+				It("should delete Cryostat", func() {
+					t.expectNoCryostat()
+				})
+			})
+			Context("RoleBinding does not exist", func() {
+				JustBeforeEach(func() {
+					err := t.Client.Delete(context.Background(), t.NewRoleBinding(t.Namespace))
+					Expect(err).ToNot(HaveOccurred())
+					t.reconcileDeletedCryostat()
+				})
+				It("should delete Cryostat", func() {
+					t.expectNoCryostat()
+				})
+			})
+		})
+		Context("on OpenShift", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create ConsoleLink", func() {
+				t.expectConsoleLink()
+			})
+			Context("with an existing ConsoleLink", func() {
+				var oldLink *consolev1.ConsoleLink
+				BeforeEach(func() {
+					oldLink = t.OtherConsoleLink()
+					t.objs = append(t.objs, oldLink)
+				})
+				It("should update the ConsoleLink", func() {
+					link := &consolev1.ConsoleLink{}
+					expectedLink := t.NewConsoleLink()
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedLink.Name}, link)
+					Expect(err).ToNot(HaveOccurred())
+					// Existing labels and annotations should remain
+					Expect(link.Labels).To(Equal(oldLink.Labels))
+					Expect(link.Annotations).To(Equal(oldLink.Annotations))
+
+					// Check managed spec fields
+					Expect(link.Spec.Link).To(Equal(expectedLink.Spec.Link))
+					Expect(link.Spec.Location).To(Equal(expectedLink.Spec.Location))
+					Expect(link.Spec.NamespaceDashboard).To(Equal(expectedLink.Spec.NamespaceDashboard))
+				})
+			})
+			Context("with an existing application url in APIServer AdditionalCORSAllowedOrigins", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewApiServerWithApplicationURL(),
+						t.NewNamespace(),
+					}
+				})
+				It("should remove the application url", func() {
+					apiServer := &configv1.APIServer{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: "cluster"}, apiServer)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).ToNot(ContainElement(fmt.Sprintf("https://%s\\.example\\.com", t.Name)))
+					Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).To(ContainElement("https://an-existing-user-specified\\.allowed\\.origin\\.com"))
+				})
+			})
+			It("should add the finalizer", func() {
+				t.expectCryostatFinalizerPresent()
+			})
+			Context("with restricted SCC", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewCryostat().Object, t.NewNamespaceWithSCCSupGroups(), t.NewApiServer(),
+					}
+				})
+				It("should set fsGroup to value derived from namespace", func() {
+					deploy := &appsv1.Deployment{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deploy)
+					Expect(err).ToNot(HaveOccurred())
+					sc := deploy.Spec.Template.Spec.SecurityContext
+					Expect(sc).ToNot(BeNil())
+					Expect(sc.FSGroup).ToNot(BeNil())
+					Expect(*sc.FSGroup).To(Equal(int64(1000130000)))
+				})
+			})
+			Context("when deleted", func() {
+				Context("ConsoleLink exists", func() {
+					JustBeforeEach(func() {
+						t.reconcileDeletedCryostat()
+					})
+					It("should delete the ConsoleLink", func() {
+						link := &consolev1.ConsoleLink{}
+						expectedLink := t.NewConsoleLink()
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedLink.Name}, link)
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					})
+					It("should remove the application url from APIServer AdditionalCORSAllowedOrigins", func() {
+						apiServer := &configv1.APIServer{}
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: "cluster"}, apiServer)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).ToNot(ContainElement(fmt.Sprintf("https://%s\\.example\\.com", t.Name)))
+						Expect(apiServer.Spec.AdditionalCORSAllowedOrigins).To(ContainElement("https://an-existing-user-specified\\.allowed\\.origin\\.com"))
+					})
+					It("should delete Cryostat", func() {
+						t.expectNoCryostat()
+					})
+				})
+				Context("ConsoleLink does not exist", func() {
+					JustBeforeEach(func() {
+						err := t.Client.Delete(context.Background(), t.NewConsoleLink())
+						Expect(err).ToNot(HaveOccurred())
+						t.reconcileDeletedCryostat()
+					})
+					It("should delete Cryostat", func() {
+						t.expectNoCryostat()
+					})
+				})
+			})
+			Context("with Insights enabled", func() {
+				BeforeEach(func() {
+					t.InsightsURL = "http://insights-proxy.foo.svc.cluster.local"
+				})
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should create deployment", func() {
+					t.expectMainDeployment()
+				})
+			})
+		})
+		Context("with cert-manager disabled in CR", func() {
+			BeforeEach(func() {
+				t.TLS = false
+				t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create deployment and set owner", func() {
+				t.expectMainDeployment()
+			})
+			It("should not create certificates", func() {
+				certs := &certv1.CertificateList{}
+				err := t.Client.List(context.Background(), certs, &ctrlclient.ListOptions{
+					Namespace: t.Namespace,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs.Items).To(BeEmpty())
+			})
+			It("should create routes with edge TLS termination", func() {
+				t.expectRoutes()
+			})
+			It("should create the agent proxy config map", func() {
+				t.expectAgentProxyConfigMap()
+			})
+		})
+		Context("with certificates issued by the operator", func() {
+			BeforeEach(func() {
+				t.TargetNamespaces = []string{t.Namespace, "operator-certs-other"}
+				t.objs = append(t.objs, t.NewOtherNamespace("operator-certs-other"),
+					t.NewCryostatWithOperatorCertificates().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should mount the certificates in the deployment", func() {
+				deployment := &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deployment)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ConsistOf(t.NewVolumes()))
+			})
+			It("should not create certificates", func() {
+				certs := &certv1.CertificateList{}
+				err := t.Client.List(context.Background(), certs, &ctrlclient.ListOptions{
+					Namespace: t.Namespace,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs.Items).To(BeEmpty())
+				t.expectNoSelfSignedCA()
+			})
+			It("should issue certificates", func() {
+				t.expectOperatorCertificates()
+			})
+			It("should set TLSSetupComplete condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+					"AllCertificatesReady")
+			})
+			It("should create the agent proxy config map", func() {
+				ca := t.getOperatorKeyPair(t.NewCACert().Spec.SecretName)
+				expected := t.NewAgentProxyConfigMap()
+				expected.Data["ca-bundle.crt"] = string(ca.CertificatePEM())
+				cm := &corev1.ConfigMap{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, cm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cm.Data).To(Equal(expected.Data))
+			})
+			It("should requeue before the first certificate needs renewal", func() {
+				result, err := t.reconcile()
+				Expect(err).ToNot(HaveOccurred())
+
+				renewAt := time.Time{}
+				for _, cert := range []*certv1.Certificate{t.NewCACert(), t.NewCryostatCert(), t.NewAgentProxyCert()} {
+					keyPair := t.getOperatorKeyPair(cert.Spec.SecretName)
+					lifetime := keyPair.Certificate.NotAfter.Sub(keyPair.Certificate.NotBefore)
+					certRenewAt := keyPair.RenewalTime(lifetime / 3)
+					if renewAt.IsZero() || certRenewAt.Before(renewAt) {
+						renewAt = certRenewAt
+					}
+				}
+				Expect(result.RequeueAfter).To(BeNumerically("~", time.Until(renewAt), time.Minute))
+			})
+			It("should not reissue certificates", func() {
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-tls", Namespace: t.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+
+				t.reconcileCryostatFully()
+				updated := &corev1.Secret{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-tls", Namespace: t.Namespace}, updated)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated.Data).To(Equal(secret.Data))
+			})
+			It("should roll out the deployment when a certificate is reissued", func() {
+				deployment := &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deployment)
+				Expect(err).ToNot(HaveOccurred())
+				hash := deployment.Spec.Template.Annotations["io.cryostat/secret-hash"]
+				Expect(hash).ToNot(BeEmpty())
+
+				secret := &corev1.Secret{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-tls", Namespace: t.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				err = t.Client.Delete(context.Background(), secret)
+				Expect(err).ToNot(HaveOccurred())
+
+				t.reconcileCryostatFully()
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deployment)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deployment.Spec.Template.Annotations["io.cryostat/secret-hash"]).ToNot(Equal(hash))
+			})
+			It("should trust both CA certificates after the CA certificate changes", func() {
+				caSecret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.NewCACert().Spec.SecretName, Namespace: t.Namespace}, caSecret)
+				Expect(err).ToNot(HaveOccurred())
+				oldCA := t.getOperatorKeyPair(caSecret.Name)
+				err = t.Client.Delete(context.Background(), caSecret)
+				Expect(err).ToNot(HaveOccurred())
+
+				t.reconcileCryostatFully()
+				newCA := t.getOperatorKeyPair(caSecret.Name)
+				Expect(newCA.Certificate.Equal(oldCA.Certificate)).To(BeFalse())
+				bundle := append(newCA.CertificatePEM(), oldCA.CertificatePEM()...)
+
+				secret := &corev1.Secret{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-ca-bundle", Namespace: t.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", bundle))
+
+				cryostatCert := t.getOperatorKeyPair(t.Name + "-tls")
+				Expect(cryostatCert.Certificate.CheckSignatureFrom(newCA.Certificate)).To(Succeed())
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-tls", Namespace: t.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", bundle))
+
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: caSecret.Name, Namespace: "operator-certs-other"}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", bundle))
+
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionFalse,
+					"PreviousCATrusted")
+			})
+			Context("after using cert-manager", func() {
+				BeforeEach(func() {
+					caCert := t.NewCACert()
+					cert := t.NewCryostatCert()
+					t.objs = append(t.objs, t.NewSelfSignedIssuer(), t.NewCryostatCAIssuer(), caCert, cert,
+						t.NewCertSecret(caCert), t.NewCertSecret(cert))
+				})
+				It("should delete the cert-manager resources", func() {
+					t.expectNoSelfSignedCA()
+					cert := t.NewCryostatCert()
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, &certv1.Certificate{})
+					Expect(kerrors.IsNotFound(err)).To(BeTrue())
+				})
+				It("should issue certificates", func() {
+					t.expectOperatorCertificates()
+				})
+			})
+			Context("with sidecar TLS enabled", func() {
+				BeforeEach(func() {
+					t.SidecarTLS = true
+					cr := t.NewCryostatWithOperatorCertificates()
+					cr.Spec.TLSOptions.SidecarTLS = true
+					t.objs = []ctrlclient.Object{t.NewNamespace(), t.NewApiServer(), t.NewOtherNamespace("operator-certs-other"), cr.Object}
+				})
+				It("should issue certificates for the sidecars", func() {
+					t.expectOperatorCertificates()
+				})
+				It("should mount the sidecar certificates in the deployment", func() {
+					deployment := &appsv1.Deployment{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, deployment)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(deployment.Spec.Template.Spec.Volumes).To(ConsistOf(t.NewVolumes()))
+				})
+			})
+		})
+		Context("with agent workload restarts enabled", func() {
+			var oldDeploy, newDeploy *appsv1.Deployment
+			BeforeEach(func() {
+				oldDeploy = t.NewAgentDeployment(t.Namespace, "old-app")
+				newDeploy = t.NewAgentDeployment(t.Namespace, "new-app")
+				t.objs = append(t.objs, t.NewCryostatWithAgentRestarts().Object, oldDeploy, newDeploy,
+					t.NewAgentDeploymentPod(oldDeploy))
+			})
+			JustBeforeEach(func() {
+				// Objects created before the test start with a fixed creation timestamp in the past
+				newPod := t.NewAgentDeploymentPod(newDeploy)
+				newPod.CreationTimestamp = metav1.NewTime(time.Now().Add(time.Hour))
+				err := t.Client.Create(context.Background(), newPod)
+				Expect(err).ToNot(HaveOccurred())
+				t.reconcileCryostatFully()
+			})
+			It("should restart workloads with pods created before the agent certificate", func() {
+				agentCert := t.getOperatorKeyPair(t.NewAgentCert(t.Namespace).Spec.SecretName)
+				expected := fmt.Sprintf("%x", sha256.Sum256(agentCert.Certificate.Raw))
+				deploy := &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: oldDeploy.Name, Namespace: oldDeploy.Namespace}, deploy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deploy.Spec.Template.Annotations).To(HaveKeyWithValue("operator.cryostat.io/agent-certificate-hash", expected))
+			})
+			It("should not restart workloads with pods created after the agent certificate", func() {
+				deploy := &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: newDeploy.Name, Namespace: newDeploy.Namespace}, deploy)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deploy.Spec.Template.Annotations).ToNot(HaveKey("operator.cryostat.io/agent-certificate-hash"))
+			})
+			It("should record an event on the restarted workload", func() {
+				recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+				var eventMsg string
+				Expect(recorder.Events).To(Receive(&eventMsg))
+				Expect(eventMsg).To(ContainSubstring("AgentCertificateRotated"))
+			})
+			It("should not restart workloads again for the same certificate", func() {
+				deploy := &appsv1.Deployment{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: oldDeploy.Name, Namespace: oldDeploy.Namespace}, deploy)
+				Expect(err).ToNot(HaveOccurred())
+
+				t.reconcileCryostatFully()
+				updated := &appsv1.Deployment{}
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: oldDeploy.Name, Namespace: oldDeploy.Namespace}, updated)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated.ResourceVersion).To(Equal(deploy.ResourceVersion))
+			})
+		})
+		Context("with cert-manager not configured in CR", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatCertManagerUndefined().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create deployment and set owner", func() {
+				t.expectMainDeployment()
+			})
+			It("should create certificates", func() {
+				t.expectCertificates()
+			})
+			It("should create routes with re-encrypt TLS termination", func() {
+				t.expectRoutes()
+			})
+			It("should set TLSSetupComplete condition", func() {
+				t.reconcileCryostatFully()
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+					"AllCertificatesReady")
+			})
+			It("should create the agent proxy config map", func() {
+				t.expectAgentProxyConfigMap()
+			})
+		})
+		Context("with DISABLE_SERVICE_TLS=true", func() {
+			BeforeEach(func() {
+				disableTLS := true
+				t.EnvDisableTLS = &disableTLS
+				t.TLS = false
+				t.objs = append(t.objs, t.NewCryostatCertManagerUndefined().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should create deployment and set owner", func() {
+				t.expectMainDeployment()
+			})
+			It("should not create certificates", func() {
+				certs := &certv1.CertificateList{}
+				err := t.Client.List(context.Background(), certs, &ctrlclient.ListOptions{
+					Namespace: t.Namespace,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(certs.Items).To(BeEmpty())
+			})
+			It("should create routes with edge TLS termination", func() {
+				t.expectRoutes()
+			})
+			It("should set TLSSetupComplete Condition", func() {
+				t.reconcileCryostatFully()
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+					"CertManagerDisabled")
+			})
+			It("should create the agent proxy config map", func() {
+				t.expectAgentProxyConfigMap()
+			})
+		})
+		Context("Disable cert-manager after being enabled", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+
+				cryostat := t.getCryostatInstance()
+
+				t.TLS = false
 				certManager := false
 				cryostat.Spec.EnableCertManager = &certManager
 				t.updateCryostatInstance(cryostat)
 
 				t.reconcileCryostatFully()
 			})
-			It("should update the deployment", func() {
-				t.expectMainDeployment()
+			It("should update the deployment", func() {
+				t.expectMainDeployment()
+			})
+			It("should create routes with edge TLS termination", func() {
+				t.expectRoutes()
+			})
+			It("should set TLSSetupComplete Condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+					"CertManagerDisabled")
+			})
+			It("should create the agent proxy config map", func() {
+				t.expectAgentProxyConfigMap()
+			})
+		})
+		Context("Enable cert-manager after being disabled", func() {
+			BeforeEach(func() {
+				t.TLS = false
+				t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+
+				cryostat := t.getCryostatInstance()
+
+				t.TLS = true
+				certManager := true
+				cryostat.Spec.EnableCertManager = &certManager
+				t.updateCryostatInstance(cryostat)
+
+				t.reconcileCryostatFully()
+			})
+			It("should update the deployment", func() {
+				t.expectMainDeployment()
+			})
+			It("should create certificates", func() {
+				t.expectCertificates()
+			})
+			It("should create routes with re-encrypt TLS termination", func() {
+				t.expectRoutes()
+			})
+			It("should set TLSSetupComplete condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+					"AllCertificatesReady")
+			})
+			It("should create the agent proxy config map", func() {
+				t.expectAgentProxyConfigMap()
+			})
+		})
+		Context("with a lock ConfigMap owned by another controller", func() {
+			BeforeEach(func() {
+				lock := t.NewLockConfigMap()
+				isController := true
+				lock.OwnerReferences = []metav1.OwnerReference{
+					{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       "other",
+						UID:        "other-uid",
+						Controller: &isController,
+					},
+				}
+				t.objs = append(t.objs, t.NewCryostat().Object, lock)
+			})
+			JustBeforeEach(func() {
+				_, err := t.reconcile()
+				Expect(err).To(HaveOccurred())
+			})
+			It("should set LockReady condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeLockReady, metav1.ConditionFalse,
+					"ReconcileFailed")
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse,
+					"ReconcileFailed")
+			})
+		})
+		Context("cert-manager missing", func() {
+			JustBeforeEach(func() {
+				// Replace with an empty RESTMapper
+				t.reconciler.GetConfig().RESTMapper = meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+			})
+			Context("and enabled", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+				JustBeforeEach(func() {
+					_, err := t.reconcile()
+					Expect(err).To(HaveOccurred())
+				})
+				It("should emit a CertManagerUnavailable Event", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					var eventMsg string
+					Expect(recorder.Events).To(Receive(&eventMsg))
+					Expect(eventMsg).To(ContainSubstring("CertManagerUnavailable"))
+				})
+				It("should set TLSSetupComplete Condition", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
+						"CertManagerUnavailable")
+				})
+				It("should set Ready Condition", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse,
+						"CertManagerUnavailable")
+				})
+			})
+			Context("and disabled", func() {
+				BeforeEach(func() {
+					t.TLS = false
+					t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
+				})
+				JustBeforeEach(func() {
+					_, err := t.reconcile()
+					Expect(err).ToNot(HaveOccurred())
+				})
+				It("should not emit a CertManagerUnavailable Event", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					Expect(recorder.Events).ToNot(Receive())
+				})
+				It("should set TLSSetupComplete Condition", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+						"CertManagerDisabled")
+				})
+			})
+		})
+		Context("with service options", func() {
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			Context("containing core config", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithCoreSvc().Object)
+				})
+				It("should create the service as described", func() {
+					t.checkService(t.NewCustomizedCoreService())
+				})
+			})
+			Context("containing reports config", func() {
+				BeforeEach(func() {
+					t.ReportReplicas = 1
+					t.objs = append(t.objs, t.NewCryostatWithReportsSvc().Object)
+				})
+				It("should create the service as described", func() {
+					t.checkService(t.NewCustomizedReportsService())
+				})
+			})
+			Context("containing agent gateway config", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithAgentGatewaySvc().Object)
+				})
+				It("should create the service as described", func() {
+					t.checkService(t.NewCustomizedAgentGatewayService())
+				})
+			})
+			Context("containing agent callback config", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithAgentCallbackSvc().Object)
+				})
+				It("should create the service as described", func() {
+					t.checkServiceNoOwner(t.NewCustomizedAgentCallbackService(t.Namespace))
+				})
+			})
+			Context("and existing services", func() {
+				var cr *model.CryostatInstance
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+				JustBeforeEach(func() {
+					// Fetch the current Cryostat CR
+					current := t.getCryostatInstance()
+
+					// Customize it with service options from the test specs
+					*current.Spec = *cr.Spec
+					t.updateCryostatInstance(current)
+
+					t.reconcileCryostatFully()
+				})
+				Context("containing core config", func() {
+					BeforeEach(func() {
+						cr = t.NewCryostatWithCoreSvc()
+					})
+					It("should create the service as described", func() {
+						t.checkService(t.NewCustomizedCoreService())
+					})
+				})
+				Context("containing reports config", func() {
+					BeforeEach(func() {
+						t.ReportReplicas = 1
+						cr = t.NewCryostatWithReportsSvc()
+					})
+					It("should create the service as described", func() {
+						t.checkService(t.NewCustomizedReportsService())
+					})
+				})
+				Context("containing agent gateway config", func() {
+					BeforeEach(func() {
+						cr = t.NewCryostatWithAgentGatewaySvc()
+					})
+					It("should create the service as described", func() {
+						t.checkService(t.NewCustomizedAgentGatewayService())
+					})
+				})
+				Context("containing agent callback config", func() {
+					BeforeEach(func() {
+						cr = t.NewCryostatWithAgentCallbackSvc()
+					})
+					It("should create the service as described", func() {
+						t.checkServiceNoOwner(t.NewCustomizedAgentCallbackService(t.Namespace))
+					})
+				})
+			})
+		})
+		Context("configuring environment variables with non-default spec values", func() {
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			Context("containing EnableAudit=false", func() {
+				BeforeEach(func() {
+					auditDisabled := false
+					t.EnableAudit = &auditDisabled
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+				It("should disable audit logging", func() {
+					t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
+						{
+							Name:  "CRYOSTAT_AUDIT_ENABLED",
+							Value: "false",
+						},
+					})
+				})
+			})
+			Context("without EnableAudit configured", func() {
+				BeforeEach(func() {
+					t.EnableAudit = nil
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+				It("should leave audit logging unset", func() {
+					t.checkCoreDoesNotHaveEnvironmentVariable("CRYOSTAT_AUDIT_ENABLED")
+				})
+			})
+			Context("containing JmxCacheOptions", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithJmxCacheOptionsSpec().Object)
+				})
+				It("should set JMX cache options", func() {
+					t.checkCoreHasEnvironmentVariables(t.NewJmxCacheOptionsEnv())
+				})
+			})
+		})
+		Context("with resource requirements", func() {
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
 			})
-			It("should create routes with edge TLS termination", func() {
-				t.expectRoutes()
+			Context("fully specified", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithResources().Object)
+				})
+				It("should create expected deployment", func() {
+					t.expectMainDeployment()
+				})
 			})
-			It("should set TLSSetupComplete Condition", func() {
-				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-					"CertManagerDisabled")
+			Context("with low limits", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithLowResourceLimit().Object)
+				})
+				It("should create expected deployment", func() {
+					t.expectMainDeployment()
+				})
 			})
-			It("should create the agent proxy config map", func() {
-				t.expectAgentProxyConfigMap()
+			Context("with no limits", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithResourcesNoLimit().Object)
+				})
+				It("should create expected deployment", func() {
+					t.expectMainDeployment()
+				})
 			})
 		})
-		Context("Enable cert-manager after being disabled", func() {
-			BeforeEach(func() {
-				t.TLS = false
-				t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
-			})
+		Context("with network options", func() {
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
-
-				cryostat := t.getCryostatInstance()
-
-				t.TLS = true
-				certManager := true
-				cryostat.Spec.EnableCertManager = &certManager
-				t.updateCryostatInstance(cryostat)
-
-				t.reconcileCryostatFully()
 			})
-			It("should update the deployment", func() {
-				t.expectMainDeployment()
+			Context("containing core config", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithCoreNetworkOptions().Object)
+				})
+				It("should create the route as described", func() {
+					t.checkRoute(t.NewCustomCoreRoute())
+				})
 			})
-			It("should create certificates", func() {
-				t.expectCertificates()
+			Context("containing core route host", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithCoreRouteHost().Object)
+				})
+				It("should create the route as described", func() {
+					t.checkRoute(t.NewCustomHostCoreRoute())
+				})
+				Context("changing host after creation", func() {
+					JustBeforeEach(func() {
+						// Remove custom route host from CR
+						cr := t.getCryostatInstance()
+						cr.Spec.NetworkOptions = t.NewCryostat().Spec.NetworkOptions
+						t.updateCryostatInstance(cr)
+
+						// Reconcile again
+						t.reconcileCryostatFully()
+					})
+					It("should leave the route as-is", func() {
+						t.checkRoute(t.NewCustomHostCoreRoute())
+					})
+				})
 			})
-			It("should create routes with re-encrypt TLS termination", func() {
-				t.expectRoutes()
+		})
+		Context("with an external TLS secret", func() {
+			Context("for the route host", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithExternalTLS().Object, t.NewExternalTLSSecret())
+				})
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should present the certificate using a re-encrypt route", func() {
+					t.checkRoute(t.NewExternalTLSCoreRoute())
+				})
+				It("should not copy the private key into the route", func() {
+					route := &openshiftv1.Route{}
+					expected := t.NewExternalTLSCoreRoute()
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, route)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(route.Spec.TLS.Key).To(BeEmpty())
+					Expect(route.Spec.TLS.Certificate).To(BeEmpty())
+				})
+				It("should allow the router to read the secret", func() {
+					t.expectRouterSecretAccess()
+				})
+				It("should set NetworkReady condition", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeNetworkReady, metav1.ConditionTrue, "Reconciled")
+				})
+				Context("that is no longer used", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.NetworkOptions.CoreConfig.TLS = nil
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
+					})
+					It("should remove the router's access to the secret", func() {
+						t.expectNoRouterSecretAccess()
+					})
+				})
+				Context("that is replaced with a certificate for another host", func() {
+					var err error
+					JustBeforeEach(func() {
+						secret := t.NewExternalTLSSecretOtherHost()
+						Expect(t.Client.Update(context.Background(), secret)).To(Succeed())
+						_, err = t.reconcile()
+					})
+					It("should return an error", func() {
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("not valid for host cryostat.example.com"))
+					})
+					It("should set NetworkReady condition", func() {
+						t.checkConditionPresent(operatorv1beta2.ConditionTypeNetworkReady, metav1.ConditionFalse,
+							"InvalidExternalCertificate")
+					})
+					It("should leave the route as-is", func() {
+						t.checkRoute(t.NewExternalTLSCoreRoute())
+					})
+				})
 			})
-			It("should set TLSSetupComplete condition", func() {
-				t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-					"AllCertificatesReady")
+			Context("for another host", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithExternalTLS().Object, t.NewExternalTLSSecretOtherHost())
+				})
+				It("should fail to reconcile", func() {
+					Eventually(func() error {
+						_, err := t.reconcile()
+						return err
+					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(MatchError(
+						ContainSubstring("not valid for host cryostat.example.com")))
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeNetworkReady, metav1.ConditionFalse,
+						"InvalidExternalCertificate")
+				})
 			})
-			It("should create the agent proxy config map", func() {
-				t.expectAgentProxyConfigMap()
+			Context("that does not exist", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithExternalTLS().Object)
+				})
+				It("should fail to reconcile", func() {
+					Eventually(func() error {
+						_, err := t.reconcile()
+						return err
+					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(MatchError(
+						ContainSubstring("does not exist")))
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeNetworkReady, metav1.ConditionFalse,
+						"InvalidExternalCertificate")
+				})
+			})
+			Context("with edge termination", func() {
+				Context("and cert-manager disabled", func() {
+					BeforeEach(func() {
+						t.TLS = false
+						cr := t.NewCryostatWithExternalTLSTermination("Edge")
+						certManager := false
+						cr.Spec.EnableCertManager = &certManager
+						t.objs = append(t.objs, cr.Object, t.NewExternalTLSSecret())
+					})
+					JustBeforeEach(func() {
+						t.reconcileCryostatFully()
+					})
+					It("should present the certificate using an edge route", func() {
+						t.checkRoute(t.NewExternalTLSCoreRoute())
+					})
+				})
+				Context("and cert-manager enabled", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithExternalTLSTermination("Edge").Object, t.NewExternalTLSSecret())
+					})
+					It("should fail to reconcile", func() {
+						Eventually(func() error {
+							_, err := t.reconcile()
+							return err
+						}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(MatchError(
+							ContainSubstring("requires TLS to be disabled within the cluster")))
+					})
+				})
 			})
 		})
-		Context("cert-manager missing", func() {
+		Context("with security options", func() {
 			JustBeforeEach(func() {
-				// Replace with an empty RESTMapper
-				t.reconciler.GetConfig().RESTMapper = meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+				t.reconcileCryostatFully()
 			})
-			Context("and enabled", func() {
+			Context("containing Cryostat security options", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostat().Object)
+					t.objs = append(t.objs, t.NewCryostatWithSecurityOptions().Object)
 				})
-				JustBeforeEach(func() {
-					_, err := t.reconcile()
-					Expect(err).To(HaveOccurred())
+				It("should add security context as described", func() {
+					t.expectMainDeployment()
 				})
-				It("should emit a CertManagerUnavailable Event", func() {
-					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
-					var eventMsg string
-					Expect(recorder.Events).To(Receive(&eventMsg))
-					Expect(eventMsg).To(ContainSubstring("CertManagerUnavailable"))
+			})
+			Context("containing Report security options", func() {
+				Context("with 0 report replica", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithReportSecurityOptions().Object)
+					})
+					It("should add security context as described", func() {
+						t.expectNoReportsDeployment()
+					})
 				})
-				It("should set TLSSetupComplete Condition", func() {
-					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
-						"CertManagerUnavailable")
+				Context("with 1 report replicas", func() {
+					BeforeEach(func() {
+						t.ReportReplicas = 1
+						cr := t.NewCryostatWithReportSecurityOptions()
+						t.objs = append(t.objs, cr.Object)
+					})
+					It("should add security context as described", func() {
+						t.checkReportsDeployment()
+					})
 				})
+
 			})
-			Context("and disabled", func() {
+		})
+		Context("with Scheduling options", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithScheduling().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should configure deployment appropriately", func() {
+				t.expectMainDeployment()
+			})
+
+		})
+		Context("with target discovery options provided", func() {
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			Context("with built-in target discovery mechanism disabled", func() {
 				BeforeEach(func() {
-					t.TLS = false
-					t.objs = append(t.objs, t.NewCryostatCertManagerDisabled().Object)
+					t.objs = append(t.objs, t.NewCryostatWithBuiltInDiscoveryDisabled().Object)
 				})
-				JustBeforeEach(func() {
-					_, err := t.reconcile()
-					Expect(err).ToNot(HaveOccurred())
+				It("should configure deployment appropriately", func() {
+					t.expectMainDeployment()
 				})
-				It("should not emit a CertManagerUnavailable Event", func() {
-					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
-					Expect(recorder.Events).ToNot(Receive())
+			})
+			Context("with discovery port configurations", func() {
+				Context("with built-in values disabled", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithBuiltInPortConfigDisabled().Object)
+					})
+					It("should configure deployment appropriately", func() {
+						t.expectMainDeployment()
+					})
 				})
-				It("should set TLSSetupComplete Condition", func() {
-					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
-						"CertManagerDisabled")
+				Context("containing non-empty lists", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithDiscoveryPortConfig().Object)
+					})
+					It("should configure deployment appropriately", func() {
+						t.expectMainDeployment()
+					})
+				})
+			})
+		})
+		Context("with secret provided for database", func() {
+			BeforeEach(func() {
+				t.GeneratedPasswords = []string{"auth_cookie_secret", "object_storage", "keystore"}
+				t.DatabaseSecret = t.NewCustomDatabaseSecret()
+				t.objs = append(t.objs, t.NewCryostatWithDatabaseSecretProvided().Object, t.DatabaseSecret)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should configure deployment appropriately", func() {
+				t.expectMainDeployment()
+				t.expectDatabaseDeployment()
+			})
+			It("should set Database Secret in CR Status", func() {
+				instance := t.getCryostatInstance()
+				Expect(instance.Status.DatabaseSecret).To(Equal(t.DatabaseSecret.Name))
+			})
+			It("should not generate default secret", func() {
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-db", Namespace: t.Namespace}, secret)
+				Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			})
+			Context("with an existing Database Secret", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewDatabaseSecret())
+				})
+				It("should not delete the existing Database Secret", func() {
+					secret := &corev1.Secret{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-db", Namespace: t.Namespace}, secret)
+					Expect(err).ToNot(HaveOccurred())
 				})
 			})
 		})
-		Context("with service options", func() {
+		Context("with logging options", func() {
+			BeforeEach(func() {
+				t.LogLevel = "DEBUG"
+				t.objs = append(t.objs, t.NewCryostatWithLoggingOptions().Object)
+			})
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("containing core config", func() {
+			It("should configure deployment with custom log level", func() {
+				t.expectMainDeployment()
+			})
+		})
+		Context("with Agent options", func() {
+			Context("with hostname verification disabled", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithCoreSvc().Object)
+					t.objs = append(t.objs, t.NewCryostatWithAgentHostnameVerifyDisabled().Object)
+					t.DisableAgentHostnameVerify = true
 				})
-				It("should create the service as described", func() {
-					t.checkService(t.NewCustomizedCoreService())
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should configure deployment appropriately", func() {
+					t.expectMainDeployment()
 				})
 			})
-			Context("containing reports config", func() {
+			Context("with insecure connections allowed", func() {
 				BeforeEach(func() {
-					t.ReportReplicas = 1
-					t.objs = append(t.objs, t.NewCryostatWithReportsSvc().Object)
+					t.objs = append(t.objs, t.NewCryostatWithAgentInsecureAllowed().Object)
+					t.AllowAgentInsecure = true
 				})
-				It("should create the service as described", func() {
-					t.checkService(t.NewCustomizedReportsService())
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
+				})
+				It("should configure deployment appropriately", func() {
+					t.expectMainDeployment()
 				})
 			})
-			Context("containing agent gateway config", func() {
+		})
+		Context("with an existing Cryostat CR", func() {
+			var otherInput *cryostatTestInput
+
+			BeforeEach(func() {
+				otherInput = c.commonBeforeEach()
+			})
+
+			JustBeforeEach(func() {
+				c.commonJustBeforeEach(otherInput)
+
+				// Controllers need to share client to have shared view of objects
+				otherInput.Client = t.Client
+				config := otherInput.newReconcilerConfig(otherInput.Client.Scheme(), otherInput.Client)
+				reconciler, err := c.constructorFunc(config)
+				Expect(err).ToNot(HaveOccurred())
+				otherInput.reconciler = reconciler
+
+				// Reconcile conflicting namespaced Cryostat fully
+				otherInput.reconcileCryostatFully()
+			})
+
+			JustAfterEach(func() {
+				c.commonJustAfterEach(otherInput)
+			})
+
+			Context("installing into its target namespace", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithAgentGatewaySvc().Object)
+					// Set up the CRs so the Cryostat's namespace conflicts with the target
+					// namespace of the existing Cryostat
+					otherNS := "other-test"
+					otherInput.Namespace = otherNS
+					otherInput.TargetNamespaces = []string{otherNS, t.Namespace}
+					t.TargetNamespaces = []string{t.Namespace}
+
+					t.objs = append(t.objs, otherInput.NewNamespace(), t.NewCryostat().Object, otherInput.NewCryostat().Object)
+					otherInput.objs = t.objs
 				})
-				It("should create the service as described", func() {
-					t.checkService(t.NewCustomizedAgentGatewayService())
+
+				JustBeforeEach(func() {
+					// Try reconciling the new Cryostat
+					t.reconcileCryostatFully()
 				})
-			})
-			Context("containing agent callback config", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithAgentCallbackSvc().Object)
+
+				// Existing Cryostat installation should be unaffected
+				Context("existing installation", func() {
+					expectSuccessful(&otherInput)
 				})
-				It("should create the service as described", func() {
-					t.checkServiceNoOwner(t.NewCustomizedAgentCallbackService(t.Namespace))
+
+				// New installation should also be unaffected
+				Context("new installation", func() {
+					expectSuccessful(&t)
 				})
 			})
-			Context("and existing services", func() {
-				var cr *model.CryostatInstance
+
+			Context("with a common target namespace", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostat().Object)
-				})
-				JustBeforeEach(func() {
-					// Fetch the current Cryostat CR
-					current := t.getCryostatInstance()
+					// Set up the CRs so the Cryostat's target namespace conflicts with the target
+					// namespace of another Cryostat
+					targetNS := "other-test-target"
+					otherNS := "other-test"
+					otherInput.Namespace = otherNS
+					otherInput.TargetNamespaces = []string{otherNS, targetNS}
+					t.TargetNamespaces = []string{t.Namespace, targetNS}
 
-					// Customize it with service options from the test specs
-					*current.Spec = *cr.Spec
-					t.updateCryostatInstance(current)
+					t.objs = append(t.objs, t.NewOtherNamespace(targetNS), otherInput.NewNamespace(), t.NewCryostat().Object, otherInput.NewCryostat().Object)
+					otherInput.objs = t.objs
+				})
 
+				JustBeforeEach(func() {
+					// Try reconciling the new Cryostat
 					t.reconcileCryostatFully()
 				})
-				Context("containing core config", func() {
-					BeforeEach(func() {
-						cr = t.NewCryostatWithCoreSvc()
-					})
-					It("should create the service as described", func() {
-						t.checkService(t.NewCustomizedCoreService())
-					})
-				})
-				Context("containing reports config", func() {
-					BeforeEach(func() {
-						t.ReportReplicas = 1
-						cr = t.NewCryostatWithReportsSvc()
-					})
-					It("should create the service as described", func() {
-						t.checkService(t.NewCustomizedReportsService())
-					})
-				})
-				Context("containing agent gateway config", func() {
-					BeforeEach(func() {
-						cr = t.NewCryostatWithAgentGatewaySvc()
-					})
-					It("should create the service as described", func() {
-						t.checkService(t.NewCustomizedAgentGatewayService())
-					})
+
+				// Existing Cryostat installation should be unaffected
+				Context("existing installation", func() {
+					expectSuccessful(&otherInput)
 				})
-				Context("containing agent callback config", func() {
-					BeforeEach(func() {
-						cr = t.NewCryostatWithAgentCallbackSvc()
-					})
-					It("should create the service as described", func() {
-						t.checkServiceNoOwner(t.NewCustomizedAgentCallbackService(t.Namespace))
-					})
+
+				// New installation should also be unaffected
+				Context("new installation", func() {
+					expectSuccessful(&t)
 				})
 			})
 		})
-		Context("configuring environment variables with non-default spec values", func() {
+		Context("with a modified CA certificate", func() {
+			var oldCerts []*certv1.Certificate
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
+				oldCerts = []*certv1.Certificate{
+					t.NewCryostatCert(),
+					t.NewReportsCert(),
+				}
+				// Add an annotation for each cert, the test will assert that
+				// the annotation is gone.
+				for i, cert := range oldCerts {
+					metav1.SetMetaDataAnnotation(&oldCerts[i].ObjectMeta, "bad", "cert")
+					t.objs = append(t.objs, cert)
+				}
+			})
 			JustBeforeEach(func() {
+				cr := t.getCryostatInstance()
+				for _, cert := range oldCerts {
+					// Make the old certs owned by the Cryostat CR
+					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
+					Expect(err).ToNot(HaveOccurred())
+					err = t.Client.Update(context.Background(), cert)
+					Expect(err).ToNot(HaveOccurred())
+				}
 				t.reconcileCryostatFully()
 			})
-			Context("containing EnableAudit=false", func() {
-				BeforeEach(func() {
-					auditDisabled := false
-					t.EnableAudit = &auditDisabled
-					t.objs = append(t.objs, t.NewCryostat().Object)
-				})
-				It("should disable audit logging", func() {
-					t.checkCoreHasEnvironmentVariables([]corev1.EnvVar{
-						{
-							Name:  "CRYOSTAT_AUDIT_ENABLED",
-							Value: "false",
-						},
-					})
-				})
+			It("should recreate certificates", func() {
+				t.expectCertificates()
 			})
-			Context("without EnableAudit configured", func() {
-				BeforeEach(func() {
-					t.EnableAudit = nil
-					t.objs = append(t.objs, t.NewCryostat().Object)
-				})
-				It("should leave audit logging unset", func() {
-					t.checkCoreDoesNotHaveEnvironmentVariable("CRYOSTAT_AUDIT_ENABLED")
-				})
+		})
+		Context("with modified certificates", func() {
+			var oldCerts []*certv1.Certificate
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
+				oldCerts = []*certv1.Certificate{
+					t.OtherCACert(),
+					t.OtherAgentProxyCert(),
+					t.OtherCryostatCert(),
+					t.OtherReportsCert(),
+				}
+				// Add an annotation for each cert, the test will assert that
+				// the annotation is gone.
+				for i, cert := range oldCerts {
+					metav1.SetMetaDataAnnotation(&oldCerts[i].ObjectMeta, "bad", "cert")
+					t.objs = append(t.objs, cert)
+				}
+			})
+			JustBeforeEach(func() {
+				cr := t.getCryostatInstance()
+				for _, cert := range oldCerts {
+					// Make the old certs owned by the Cryostat CR
+					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
+					Expect(err).ToNot(HaveOccurred())
+					err = t.Client.Update(context.Background(), cert)
+					Expect(err).ToNot(HaveOccurred())
+				}
+				t.reconcileCryostatFully()
+			})
+			It("should recreate certificates", func() {
+				t.expectCertificates()
+			})
+		})
+		Context("with a modified certificate TLS CommonName", func() {
+			var oldCerts []*certv1.Certificate
+			BeforeEach(func() {
+				oldCerts = []*certv1.Certificate{
+					t.NewCryostatCert(),
+					t.NewReportsCert(),
+					t.NewAgentProxyCert(),
+				}
+				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
+				for _, cert := range oldCerts {
+					t.objs = append(t.objs, cert)
+				}
 			})
-			Context("containing JmxCacheOptions", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithJmxCacheOptionsSpec().Object)
-				})
-				It("should set JMX cache options", func() {
-					t.checkCoreHasEnvironmentVariables(t.NewJmxCacheOptionsEnv())
-				})
+			JustBeforeEach(func() {
+				cr := t.getCryostatInstance()
+				for _, cert := range oldCerts {
+					// Make the old certs owned by the Cryostat CR
+					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
+					Expect(err).ToNot(HaveOccurred())
+					err = t.Client.Update(context.Background(), cert)
+					Expect(err).ToNot(HaveOccurred())
+				}
+				t.reconcileCryostatFully()
+			})
+			It("should recreate certificates", func() {
+				t.expectCertificates()
 			})
 		})
-		Context("with resource requirements", func() {
+
+		Context("with a custom issuer", func() {
+			BeforeEach(func() {
+				t.IssuerRef = &operatorv1beta2.IssuerReference{
+					Name: "corporate-ca",
+					Kind: "ClusterIssuer",
+				}
+				t.TargetNamespaces = []string{t.Namespace, "custom-issuer-other"}
+				t.objs = append(t.objs, t.NewOtherNamespace("custom-issuer-other"), t.NewCryostatWithIssuerRef().Object)
+			})
+
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("fully specified", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithResources().Object)
-				})
-				It("should create expected deployment", func() {
-					t.expectMainDeployment()
-				})
+
+			It("should issue certificates from the custom issuer", func() {
+				certs := []*certv1.Certificate{t.NewCryostatCert(), t.NewReportsCert(), t.NewAgentProxyCert(),
+					t.NewDatabaseCert(), t.NewStorageCert(), t.NewAgentCert(t.Namespace), t.NewAgentCert("custom-issuer-other")}
+				for _, expected := range certs {
+					actual := &certv1.Certificate{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, actual)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(actual.Spec).To(Equal(expected.Spec))
+					Expect(actual.Spec.IssuerRef.Kind).To(Equal("ClusterIssuer"))
+				}
 			})
-			Context("with low limits", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithLowResourceLimit().Object)
-				})
-				It("should create expected deployment", func() {
-					t.expectMainDeployment()
-				})
+
+			It("should not create a self-signed CA", func() {
+				t.expectNoSelfSignedCA()
 			})
-			Context("with no limits", func() {
+
+			It("should store the issuer's CA certificate", func() {
+				t.expectIssuerCASecrets()
+			})
+
+			Context("after using a self-signed CA", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithResourcesNoLimit().Object)
+					caCert := t.NewCACert()
+					caSecret := t.NewCertSecret(caCert)
+					caSecret.Type = corev1.SecretTypeTLS
+					t.objs = append(t.objs, t.NewSelfSignedIssuer(), t.NewCryostatCAIssuer(), caCert, caSecret)
 				})
-				It("should create expected deployment", func() {
-					t.expectMainDeployment()
+
+				It("should delete the self-signed CA", func() {
+					t.expectNoSelfSignedCA()
+				})
+
+				It("should replace the CA certificate with the issuer's", func() {
+					t.expectIssuerCASecrets()
 				})
 			})
 		})
-		Context("with network options", func() {
+
+		Context("with a changed CA certificate", func() {
+			BeforeEach(func() {
+				t.TargetNamespaces = []string{t.Namespace, "ca-rotation-other"}
+				t.objs = append(t.objs, t.NewOtherNamespace("ca-rotation-other"), t.NewCryostat().Object,
+					t.NewCABundleSecretBeforeRotation(), t.NewCertSecretFromPreviousCA(t.NewCryostatCert()))
+			})
+
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("containing core config", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithCoreNetworkOptions().Object)
-				})
-				It("should create the route as described", func() {
-					t.checkRoute(t.NewCustomCoreRoute())
-				})
+
+			It("should trust both CA certificates", func() {
+				expected := t.NewCABundleSecret()
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", t.GetCABundleDuringRotation()))
+				Expect(secret.Annotations).To(HaveKey("operator.cryostat.io/ca-rotated-at"))
 			})
-			Context("containing core route host", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithCoreRouteHost().Object)
-				})
-				It("should create the route as described", func() {
-					t.checkRoute(t.NewCustomHostCoreRoute())
-				})
-				Context("changing host after creation", func() {
-					JustBeforeEach(func() {
-						// Remove custom route host from CR
-						cr := t.getCryostatInstance()
-						cr.Spec.NetworkOptions = t.NewCryostat().Spec.NetworkOptions
-						t.updateCryostatInstance(cr)
 
-						// Reconcile again
-						t.reconcileCryostatFully()
-					})
-					It("should leave the route as-is", func() {
-						t.checkRoute(t.NewCustomHostCoreRoute())
-					})
-				})
+			It("should publish both CA certificates to target namespaces", func() {
+				caSecret := t.NewCACertSecret("ca-rotation-other")
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: caSecret.Name, Namespace: caSecret.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", t.GetCABundleDuringRotation()))
+
+				agentSecret := t.NewAgentCertSecretCopy("ca-rotation-other")
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: agentSecret.Name, Namespace: agentSecret.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", t.GetCABundleDuringRotation()))
+			})
+
+			It("should trust both CA certificates in the agent proxy", func() {
+				expected := t.NewAgentProxyConfigMap()
+				cm := &corev1.ConfigMap{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, cm)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cm.Data).To(HaveKeyWithValue("ca-bundle.crt", string(t.GetCABundleDuringRotation())))
+			})
+
+			It("should reissue certificates signed by the previous CA certificate", func() {
+				cert := &certv1.Certificate{}
+				expected := t.NewCryostatCert()
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, cert)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.Status.Conditions).To(ContainElement(And(
+					HaveField("Type", certv1.CertificateConditionIssuing),
+					HaveField("Status", certMeta.ConditionTrue),
+				)))
+
+				expected = t.NewReportsCert()
+				err = t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, cert)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cert.Status.Conditions).ToNot(ContainElement(HaveField("Type", certv1.CertificateConditionIssuing)))
+			})
+
+			It("should set CARotationComplete condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionFalse,
+					"ReissuingCertificates")
 			})
 		})
-		Context("with security options", func() {
+
+		Context("during the CA rotation grace period", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostat().Object, t.NewCABundleSecretDuringRotation(time.Now().Add(-time.Hour)))
+			})
+
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("containing Cryostat security options", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithSecurityOptions().Object)
-				})
-				It("should add security context as described", func() {
-					t.expectMainDeployment()
-				})
+
+			It("should requeue when the grace period ends", func() {
+				result, err := t.reconcile()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 23*time.Hour, time.Minute))
+			})
+
+			It("should continue to trust both CA certificates", func() {
+				expected := t.NewCABundleSecret()
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(HaveKeyWithValue("ca.crt", t.GetCABundleDuringRotation()))
 			})
-			Context("containing Report security options", func() {
-				Context("with 0 report replica", func() {
-					BeforeEach(func() {
-						t.objs = append(t.objs, t.NewCryostatWithReportSecurityOptions().Object)
-					})
-					It("should add security context as described", func() {
-						t.expectNoReportsDeployment()
-					})
-				})
-				Context("with 1 report replicas", func() {
-					BeforeEach(func() {
-						t.ReportReplicas = 1
-						cr := t.NewCryostatWithReportSecurityOptions()
-						t.objs = append(t.objs, cr.Object)
-					})
-					It("should add security context as described", func() {
-						t.checkReportsDeployment()
-					})
-				})
 
+			It("should set CARotationComplete condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionFalse,
+					"PreviousCATrusted")
 			})
 		})
-		Context("with Scheduling options", func() {
+
+		Context("after the CA rotation grace period", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostatWithScheduling().Object)
+				t.objs = append(t.objs, t.NewCryostat().Object, t.NewCABundleSecretDuringRotation(time.Now().Add(-25*time.Hour)))
 			})
+
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			It("should configure deployment appropriately", func() {
-				t.expectMainDeployment()
+
+			It("should only trust the current CA certificate", func() {
+				expected := t.NewCABundleSecret()
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(Equal(expected.Data))
+				Expect(secret.Annotations).ToNot(HaveKey("operator.cryostat.io/ca-rotated-at"))
 			})
 
+			It("should set CARotationComplete condition", func() {
+				t.checkConditionPresent(operatorv1beta2.ConditionTypeCARotationComplete, metav1.ConditionTrue,
+					"CACertificateCurrent")
+			})
 		})
-		Context("with target discovery options provided", func() {
+
+		Context("with a CA rotation grace period", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithCARotationGracePeriod(2*time.Hour).Object,
+					t.NewCABundleSecretDuringRotation(time.Now().Add(-3*time.Hour)))
+			})
+
 			JustBeforeEach(func() {
 				t.reconcileCryostatFully()
 			})
-			Context("with built-in target discovery mechanism disabled", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithBuiltInDiscoveryDisabled().Object)
+
+			It("should stop trusting the previous CA certificate after the grace period", func() {
+				expected := t.NewCABundleSecret()
+				secret := &corev1.Secret{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(secret.Data).To(Equal(expected.Data))
+			})
+		})
+
+		Context("with trust-manager CA distribution", func() {
+			BeforeEach(func() {
+				t.TargetNamespaces = []string{t.Namespace, "trust-manager-other"}
+				t.objs = append(t.objs, t.NewOtherNamespace("trust-manager-other"), t.NewCryostatWithTrustManager().Object)
+			})
+
+			Context("with trust-manager installed", func() {
+				JustBeforeEach(func() {
+					t.reconciler.GetConfig().RESTMapper = test.NewTESTRESTMapperWithTrustManager()
+					t.reconcileCryostatFully()
 				})
-				It("should configure deployment appropriately", func() {
-					t.expectMainDeployment()
+
+				It("should create a Bundle for target namespaces", func() {
+					expected := t.NewTrustManagerBundle()
+					bundle := &unstructured.Unstructured{}
+					bundle.SetGroupVersionKind(test.TrustManagerBundleGVK)
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.GetName()}, bundle)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(bundle.GetLabels()).To(Equal(expected.GetLabels()))
+					Expect(bundle.Object["spec"]).To(Equal(expected.Object["spec"]))
 				})
-			})
-			Context("with discovery port configurations", func() {
-				Context("with built-in values disabled", func() {
+
+				It("should label target namespaces for the Bundle", func() {
+					for _, name := range t.TargetNamespaces {
+						ns := &corev1.Namespace{}
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: name}, ns)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(ns.Labels).To(HaveKeyWithValue(t.GetCABundleNamespaceLabel(), "true"))
+					}
+				})
+
+				It("should not copy the CA certificate into target namespaces", func() {
+					expected := t.NewCACertSecret("trust-manager-other")
+					secret := &corev1.Secret{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+					Expect(kerrors.IsNotFound(err)).To(BeTrue())
+				})
+
+				It("should copy the agent certificate into target namespaces", func() {
+					expected := t.NewAgentCertSecretCopy("trust-manager-other")
+					secret := &corev1.Secret{}
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(secret.Data).To(Equal(expected.Data))
+				})
+
+				Context("after copying the CA certificate", func() {
 					BeforeEach(func() {
-						t.objs = append(t.objs, t.NewCryostatWithBuiltInPortConfigDisabled().Object)
+						t.objs = append(t.objs, t.NewCACertSecret("trust-manager-other"))
 					})
-					It("should configure deployment appropriately", func() {
-						t.expectMainDeployment()
+
+					It("should delete the CA certificate copies", func() {
+						expected := t.NewCACertSecret("trust-manager-other")
+						secret := &corev1.Secret{}
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
 					})
 				})
-				Context("containing non-empty lists", func() {
-					BeforeEach(func() {
-						t.objs = append(t.objs, t.NewCryostatWithDiscoveryPortConfig().Object)
+
+				Context("when deleted", func() {
+					JustBeforeEach(func() {
+						t.reconcileDeletedCryostat()
 					})
-					It("should configure deployment appropriately", func() {
-						t.expectMainDeployment()
+
+					It("should delete the Bundle", func() {
+						bundle := &unstructured.Unstructured{}
+						bundle.SetGroupVersionKind(test.TrustManagerBundleGVK)
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.GetTrustManagerBundleName()}, bundle)
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					})
+
+					It("should remove the label from target namespaces", func() {
+						t.expectNoCABundleNamespaceLabel("trust-manager-other")
+					})
+				})
+
+				Context("with a target namespace removed", func() {
+					JustBeforeEach(func() {
+						cr := t.getCryostatInstance()
+						cr.Spec.TargetNamespaces = []string{t.Namespace}
+						t.updateCryostatInstance(cr)
+						t.reconcileCryostatFully()
+					})
+
+					It("should remove the label from the namespace", func() {
+						t.expectNoCABundleNamespaceLabel("trust-manager-other")
 					})
 				})
 			})
-		})
-		Context("with secret provided for database", func() {
-			BeforeEach(func() {
-				t.GeneratedPasswords = []string{"auth_cookie_secret", "object_storage", "keystore"}
-				t.DatabaseSecret = t.NewCustomDatabaseSecret()
-				t.objs = append(t.objs, t.NewCryostatWithDatabaseSecretProvided().Object, t.DatabaseSecret)
-			})
-			JustBeforeEach(func() {
-				t.reconcileCryostatFully()
-			})
-			It("should configure deployment appropriately", func() {
-				t.expectMainDeployment()
-				t.expectDatabaseDeployment()
-			})
-			It("should set Database Secret in CR Status", func() {
-				instance := t.getCryostatInstance()
-				Expect(instance.Status.DatabaseSecret).To(Equal(t.DatabaseSecret.Name))
-			})
-			It("should not generate default secret", func() {
-				secret := &corev1.Secret{}
-				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-db", Namespace: t.Namespace}, secret)
-				Expect(kerrors.IsNotFound(err)).To(BeTrue())
-			})
-			Context("with an existing Database Secret", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewDatabaseSecret())
+
+			Context("with trust-manager missing", func() {
+				JustBeforeEach(func() {
+					t.reconcileCryostatFully()
 				})
-				It("should not delete the existing Database Secret", func() {
+
+				It("should copy the CA certificate into target namespaces", func() {
+					expected := t.NewCACertSecret("trust-manager-other")
 					secret := &corev1.Secret{}
-					err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-db", Namespace: t.Namespace}, secret)
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, secret)
 					Expect(err).ToNot(HaveOccurred())
+					Expect(secret.Data).To(Equal(expected.Data))
+				})
+
+				It("should emit a TrustManagerUnavailable Event", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					var eventMsg string
+					Expect(recorder.Events).To(Receive(&eventMsg))
+					Expect(eventMsg).To(ContainSubstring("TrustManagerUnavailable"))
+				})
+
+				It("should set TLSSetupComplete condition", func() {
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue,
+						"TrustManagerUnavailable")
+				})
+
+				It("should not emit the Event again", func() {
+					recorder := t.reconciler.GetConfig().EventRecorder.(*record.FakeRecorder)
+					Expect(recorder.Events).To(Receive())
+					t.reconcileCryostatFully()
+					Expect(recorder.Events).ToNot(Receive())
 				})
 			})
 		})
-		Context("with logging options", func() {
+
+		Context("after using trust-manager CA distribution", func() {
 			BeforeEach(func() {
-				t.LogLevel = "DEBUG"
-				t.objs = append(t.objs, t.NewCryostatWithLoggingOptions().Object)
+				ns := t.NewNamespace()
+				ns.Labels = map[string]string{t.GetCABundleNamespaceLabel(): "true"}
+				t.objs = []ctrlclient.Object{ns, t.NewApiServer(), t.NewCryostat().Object, t.NewTrustManagerBundle()}
 			})
+
 			JustBeforeEach(func() {
+				t.reconciler.GetConfig().RESTMapper = test.NewTESTRESTMapperWithTrustManager()
 				t.reconcileCryostatFully()
 			})
-			It("should configure deployment with custom log level", func() {
-				t.expectMainDeployment()
+
+			It("should delete the Bundle", func() {
+				bundle := &unstructured.Unstructured{}
+				bundle.SetGroupVersionKind(test.TrustManagerBundleGVK)
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.GetTrustManagerBundleName()}, bundle)
+				Expect(kerrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			It("should remove the label from target namespaces", func() {
+				t.expectNoCABundleNamespaceLabel(t.Namespace)
 			})
 		})
-		Context("with Agent options", func() {
-			Context("with hostname verification disabled", func() {
-				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithAgentHostnameVerifyDisabled().Object)
-					t.DisableAgentHostnameVerify = true
-				})
-				JustBeforeEach(func() {
-					t.reconcileCryostatFully()
-				})
-				It("should configure deployment appropriately", func() {
-					t.expectMainDeployment()
-				})
+
+		Context("with certificate options", func() {
+			var cr *model.CryostatInstance
+			BeforeEach(func() {
+				t.CACertificateOptions = &operatorv1beta2.CertificateOptions{
+					Duration: &metav1.Duration{Duration: 365 * 24 * time.Hour},
+					PrivateKey: &operatorv1beta2.PrivateKeyOptions{
+						Algorithm: "ECDSA",
+						Size:      384,
+					},
+				}
+				t.CertificateOptions = &operatorv1beta2.CertificateOptions{
+					Duration:    &metav1.Duration{Duration: 30 * 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 10 * 24 * time.Hour},
+					PrivateKey: &operatorv1beta2.PrivateKeyOptions{
+						Algorithm:      "ECDSA",
+						Size:           384,
+						RotationPolicy: "Never",
+					},
+				}
+				cr = t.NewCryostatWithCertificateOptions()
+				t.objs = append(t.objs, cr.Object)
 			})
-			Context("with insecure connections allowed", func() {
+
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+
+			It("should create certificates with the options", func() {
+				t.expectCertificates()
+				expected := t.NewAgentCert(t.Namespace)
+				actual := &certv1.Certificate{}
+				err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, actual)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(actual.Spec).To(Equal(expected.Spec))
+			})
+
+			Context("issued by the operator", func() {
 				BeforeEach(func() {
-					t.objs = append(t.objs, t.NewCryostatWithAgentInsecureAllowed().Object)
-					t.AllowAgentInsecure = true
+					provider := "Operator"
+					cr.Spec.TLSOptions.CertificateProvider = &provider
 				})
-				JustBeforeEach(func() {
-					t.reconcileCryostatFully()
-				})
-				It("should configure deployment appropriately", func() {
-					t.expectMainDeployment()
+
+				It("should issue certificates with the options", func() {
+					t.expectOperatorCertificates()
+
+					caCert := t.NewCACert()
+					ca := t.getOperatorKeyPair(caCert.Spec.SecretName)
+					Expect(ca.Certificate.NotAfter.Sub(ca.Certificate.NotBefore)).To(Equal(365 * 24 * time.Hour))
+					Expect(ca.PrivateKey).To(BeAssignableToTypeOf(&ecdsa.PrivateKey{}))
+					Expect(ca.PrivateKey.(*ecdsa.PrivateKey).Curve).To(Equal(elliptic.P384()))
+
+					leaf := t.getOperatorKeyPair(t.NewCryostatCert().Spec.SecretName)
+					Expect(leaf.Certificate.NotAfter.Sub(leaf.Certificate.NotBefore)).To(Equal(30 * 24 * time.Hour))
+					Expect(leaf.PrivateKey).To(BeAssignableToTypeOf(&ecdsa.PrivateKey{}))
+					Expect(leaf.PrivateKey.(*ecdsa.PrivateKey).Curve).To(Equal(elliptic.P384()))
 				})
 			})
 		})
-		Context("with an existing Cryostat CR", func() {
-			var otherInput *cryostatTestInput
 
+		Context("with sidecar TLS enabled", func() {
 			BeforeEach(func() {
-				otherInput = c.commonBeforeEach()
+				t.SidecarTLS = true
+				t.objs = append(t.objs, t.NewCryostatWithSidecarTLS().Object)
 			})
 
 			JustBeforeEach(func() {
-				c.commonJustBeforeEach(otherInput)
-
-				// Controllers need to share client to have shared view of objects
-				otherInput.Client = t.Client
-				config := otherInput.newReconcilerConfig(otherInput.Client.Scheme(), otherInput.Client)
-				reconciler, err := c.constructorFunc(config)
-				Expect(err).ToNot(HaveOccurred())
-				otherInput.reconciler = reconciler
-
-				// Reconcile conflicting namespaced Cryostat fully
-				otherInput.reconcileCryostatFully()
+				t.reconcileCryostatFully()
 			})
 
-			JustAfterEach(func() {
-				c.commonJustAfterEach(otherInput)
+			It("should create certificates for the sidecars", func() {
+				t.expectCertificates()
 			})
 
-			Context("installing into its target namespace", func() {
-				BeforeEach(func() {
-					// Set up the CRs so the Cryostat's namespace conflicts with the target
-					// namespace of the existing Cryostat
-					otherNS := "other-test"
-					otherInput.Namespace = otherNS
-					otherInput.TargetNamespaces = []string{otherNS, t.Namespace}
-					t.TargetNamespaces = []string{t.Namespace}
-
-					t.objs = append(t.objs, otherInput.NewNamespace(), t.NewCryostat().Object, otherInput.NewCryostat().Object)
-					otherInput.objs = t.objs
-				})
+			It("should configure the main deployment to use TLS", func() {
+				t.expectMainDeployment()
+			})
 
+			Context("when disabled", func() {
 				JustBeforeEach(func() {
-					// Try reconciling the new Cryostat
+					cr := t.getCryostatInstance()
+					cr.Spec.TLSOptions.SidecarTLS = false
+					t.updateCryostatInstance(cr)
+					t.SidecarTLS = false
 					t.reconcileCryostatFully()
 				})
 
-				// Existing Cryostat installation should be unaffected
-				Context("existing installation", func() {
-					expectSuccessful(&otherInput)
+				It("should delete the sidecar certificates", func() {
+					for _, cert := range []*certv1.Certificate{t.NewGrafanaCert(), t.NewDatasourceCert()} {
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, &certv1.Certificate{})
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+						err = t.Client.Get(context.Background(), types.NamespacedName{Name: cert.Spec.SecretName, Namespace: cert.Namespace}, &corev1.Secret{})
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					}
 				})
 
-				// New installation should also be unaffected
-				Context("new installation", func() {
-					expectSuccessful(&t)
+				It("should configure the main deployment without TLS", func() {
+					t.expectMainDeployment()
 				})
 			})
 
-			Context("with a common target namespace", func() {
+			Context("with cert-manager disabled", func() {
 				BeforeEach(func() {
-					// Set up the CRs so the Cryostat's target namespace conflicts with the target
-					// namespace of another Cryostat
-					targetNS := "other-test-target"
-					otherNS := "other-test"
-					otherInput.Namespace = otherNS
-					otherInput.TargetNamespaces = []string{otherNS, targetNS}
-					t.TargetNamespaces = []string{t.Namespace, targetNS}
-
-					t.objs = append(t.objs, t.NewOtherNamespace(targetNS), otherInput.NewNamespace(), t.NewCryostat().Object, otherInput.NewCryostat().Object)
-					otherInput.objs = t.objs
-				})
-
-				JustBeforeEach(func() {
-					// Try reconciling the new Cryostat
-					t.reconcileCryostatFully()
-				})
-
-				// Existing Cryostat installation should be unaffected
-				Context("existing installation", func() {
-					expectSuccessful(&otherInput)
+					cr := t.NewCryostatCertManagerDisabled()
+					cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{SidecarTLS: true}
+					t.TLS = false
+					t.objs = []ctrlclient.Object{t.NewNamespace(), t.NewApiServer(), cr.Object}
 				})
 
-				// New installation should also be unaffected
-				Context("new installation", func() {
-					expectSuccessful(&t)
+				It("should not configure the sidecars to use TLS", func() {
+					t.expectMainDeployment()
 				})
 			})
 		})
-		Context("with a modified CA certificate", func() {
-			var oldCerts []*certv1.Certificate
+
+		Context("with a TLS security profile", func() {
 			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
-				oldCerts = []*certv1.Certificate{
-					t.NewCryostatCert(),
-					t.NewReportsCert(),
-				}
-				// Add an annotation for each cert, the test will assert that
-				// the annotation is gone.
-				for i, cert := range oldCerts {
-					metav1.SetMetaDataAnnotation(&oldCerts[i].ObjectMeta, "bad", "cert")
-					t.objs = append(t.objs, cert)
-				}
+				t.ModernTLSProfile = true
+				t.objs = append(t.objs, t.NewCryostatWithTLSSecurityProfile().Object)
 			})
+
 			JustBeforeEach(func() {
-				cr := t.getCryostatInstance()
-				for _, cert := range oldCerts {
-					// Make the old certs owned by the Cryostat CR
-					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
-					Expect(err).ToNot(HaveOccurred())
-					err = t.Client.Update(context.Background(), cert)
-					Expect(err).ToNot(HaveOccurred())
-				}
 				t.reconcileCryostatFully()
 			})
-			It("should recreate certificates", func() {
-				t.expectCertificates()
+
+			It("should configure the agent proxy with the profile", func() {
+				t.expectAgentProxyConfigMap()
 			})
-		})
-		Context("with modified certificates", func() {
-			var oldCerts []*certv1.Certificate
-			BeforeEach(func() {
-				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
-				oldCerts = []*certv1.Certificate{
-					t.OtherCACert(),
-					t.OtherAgentProxyCert(),
-					t.OtherCryostatCert(),
-					t.OtherReportsCert(),
-				}
-				// Add an annotation for each cert, the test will assert that
-				// the annotation is gone.
-				for i, cert := range oldCerts {
-					metav1.SetMetaDataAnnotation(&oldCerts[i].ObjectMeta, "bad", "cert")
-					t.objs = append(t.objs, cert)
-				}
+
+			It("should configure the main deployment with the profile", func() {
+				t.expectMainDeployment()
 			})
-			JustBeforeEach(func() {
-				cr := t.getCryostatInstance()
-				for _, cert := range oldCerts {
-					// Make the old certs owned by the Cryostat CR
-					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
-					Expect(err).ToNot(HaveOccurred())
-					err = t.Client.Update(context.Background(), cert)
-					Expect(err).ToNot(HaveOccurred())
-				}
-				t.reconcileCryostatFully()
+
+			It("should configure the database with the profile", func() {
+				t.expectDatabaseDeployment()
 			})
-			It("should recreate certificates", func() {
-				t.expectCertificates()
+
+			It("should configure the storage with the profile", func() {
+				t.expectStorageDeployment()
+			})
+
+			Context("with a different API server TLS security profile", func() {
+				BeforeEach(func() {
+					apiServer := t.NewApiServer()
+					apiServer.Spec.TLSSecurityProfile = &configv1.TLSSecurityProfile{
+						Type: configv1.TLSProfileOldType,
+						Old:  &configv1.OldTLSProfile{},
+					}
+					t.objs = []ctrlclient.Object{
+						t.NewNamespace(),
+						apiServer,
+						t.NewCryostatWithTLSSecurityProfile().Object,
+					}
+				})
+
+				It("should prefer the profile from the CR", func() {
+					t.expectMainDeployment()
+					t.expectAgentProxyConfigMap()
+				})
 			})
 		})
-		Context("with a modified certificate TLS CommonName", func() {
-			var oldCerts []*certv1.Certificate
+
+		Context("with an API server TLS security profile", func() {
 			BeforeEach(func() {
-				oldCerts = []*certv1.Certificate{
-					t.NewCryostatCert(),
-					t.NewReportsCert(),
-					t.NewAgentProxyCert(),
-				}
-				t.objs = append(t.objs, t.NewCryostat().Object, t.OtherCAIssuer())
-				for _, cert := range oldCerts {
-					t.objs = append(t.objs, cert)
+				t.ModernTLSProfile = true
+				t.objs = []ctrlclient.Object{
+					t.NewNamespace(),
+					t.NewApiServerWithTLSSecurityProfile(),
+					t.NewCryostat().Object,
 				}
 			})
+
 			JustBeforeEach(func() {
-				cr := t.getCryostatInstance()
-				for _, cert := range oldCerts {
-					// Make the old certs owned by the Cryostat CR
-					err := controllerutil.SetControllerReference(cr.Object, cert, t.Client.Scheme())
-					Expect(err).ToNot(HaveOccurred())
-					err = t.Client.Update(context.Background(), cert)
-					Expect(err).ToNot(HaveOccurred())
-				}
 				t.reconcileCryostatFully()
 			})
-			It("should recreate certificates", func() {
-				t.expectCertificates()
+
+			It("should configure the agent proxy with the profile", func() {
+				t.expectAgentProxyConfigMap()
+			})
+
+			It("should configure the main deployment with the profile", func() {
+				t.expectMainDeployment()
+			})
+
+			It("should configure the database with the profile", func() {
+				t.expectDatabaseDeployment()
+			})
+
+			It("should configure the storage with the profile", func() {
+				t.expectStorageDeployment()
 			})
 		})
 
@@ -2537,6 +4654,17 @@ func (c *controllerTest) commonTests() {
 						return nil
 					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(BeAssignableToTypeOf(&controllerutil.AlreadyOwnedError{}))
 				})
+
+				It("should report the failure in conditions", func() {
+					Eventually(func() error {
+						_, err := t.reconcile()
+						return err
+					}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(HaveOccurred())
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionFalse,
+						"ReconcileFailed")
+					t.checkConditionPresent(operatorv1beta2.ConditionTypeReady, metav1.ConditionFalse,
+						"ReconcileFailed")
+				})
 			})
 		})
 
@@ -2576,6 +4704,60 @@ func (c *controllerTest) commonTests() {
 					t.expectTargetNamespaces()
 				})
 
+				It("should report every target namespace in the agent injection status", func() {
+					t.reconcileAgentInjection()
+					cr := t.getCryostatInstance()
+					Expect(cr.Status.AgentInjection).To(Equal([]operatorv1beta2.AgentInjectionStatus{
+						{Namespace: targetNamespaces[0]},
+						{Namespace: targetNamespaces[1]},
+					}))
+				})
+
+				Context("with agent pods", func() {
+					BeforeEach(func() {
+						injected := t.NewAgentPod(targetNamespaces[0], true)
+						invalid := t.NewAgentPod(targetNamespaces[1], false)
+						invalid.Labels["cryostat.io/callback-port"] = "invalid"
+						notInjected := t.NewAgentPod(targetNamespaces[1], false)
+						notInjected.Name = "test-agent-2"
+						notTargeted := t.NewAgentPod(t.Namespace, false)
+						t.objs = append(t.objs, injected, invalid, notInjected, notTargeted)
+					})
+
+					JustBeforeEach(func() {
+						t.reconcileAgentInjection()
+					})
+
+					It("should count pods in each namespace", func() {
+						cr := t.getCryostatInstance()
+						Expect(cr.Status.AgentInjection).To(HaveLen(3))
+						Expect(cr.Status.AgentInjection[0].Namespace).To(Equal(targetNamespaces[0]))
+						Expect(cr.Status.AgentInjection[0].InjectedPods).To(Equal(int32(1)))
+						Expect(cr.Status.AgentInjection[0].NotInjectedPods).To(BeZero())
+						Expect(cr.Status.AgentInjection[0].RecentFailures).To(BeEmpty())
+						Expect(cr.Status.AgentInjection[1].Namespace).To(Equal(targetNamespaces[1]))
+						Expect(cr.Status.AgentInjection[1].InjectedPods).To(BeZero())
+						Expect(cr.Status.AgentInjection[1].NotInjectedPods).To(Equal(int32(2)))
+						Expect(cr.Status.AgentInjection[2].Namespace).To(Equal(t.Namespace))
+						Expect(cr.Status.AgentInjection[2].NotInjectedPods).To(Equal(int32(1)))
+					})
+
+					It("should report the reason for each failure", func() {
+						cr := t.getCryostatInstance()
+						Expect(cr.Status.AgentInjection).To(HaveLen(3))
+						reasons := map[string]string{}
+						for _, failure := range cr.Status.AgentInjection[1].RecentFailures {
+							reasons[failure.PodName] = failure.Reason
+						}
+						Expect(reasons).To(Equal(map[string]string{
+							"test-agent":   "InvalidConfiguration",
+							"test-agent-2": "NotInjected",
+						}))
+						Expect(cr.Status.AgentInjection[2].RecentFailures).To(HaveLen(1))
+						Expect(cr.Status.AgentInjection[2].RecentFailures[0].Reason).To(Equal("NamespaceNotTargeted"))
+					})
+				})
+
 				Context("when deleted", func() {
 					Context("RoleBindings exist", func() {
 						JustBeforeEach(func() {
@@ -2813,6 +4995,178 @@ func (c *controllerTest) commonTests() {
 				})
 			})
 		})
+
+		Context("reconciling a namespace selector request", func() {
+			selectedNamespaces := []string{"selector-test-one", "selector-test-two"}
+			otherNamespace := "selector-test-other"
+
+			BeforeEach(func() {
+				for _, ns := range selectedNamespaces {
+					t.objs = append(t.objs, t.NewSelectedNamespace(ns))
+				}
+				t.objs = append(t.objs, t.NewOtherNamespace(otherNamespace))
+				t.TargetNamespaces = selectedNamespaces
+			})
+
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+
+			Context("with only a selector", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithTargetNamespaceSelector().Object)
+				})
+
+				It("should create the expected main deployment", func() {
+					t.expectMainDeployment()
+				})
+
+				It("should create certificate secrets in each selected namespace", func() {
+					t.expectCertificates()
+				})
+
+				It("should create RBAC in each selected namespace", func() {
+					t.expectRBAC()
+				})
+
+				It("should not create RBAC in other namespaces", func() {
+					binding := t.NewRoleBinding(otherNamespace)
+					err := t.Client.Get(context.Background(), types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}, binding)
+					Expect(kerrors.IsNotFound(err)).To(BeTrue())
+				})
+
+				It("should update the target namespaces in Status", func() {
+					t.expectTargetNamespaces()
+				})
+
+				It("should not modify the spec", func() {
+					cr := t.getCryostatInstance()
+					Expect(cr.Spec.TargetNamespaces).To(BeEmpty())
+				})
+
+				Context("with agent pods", func() {
+					BeforeEach(func() {
+						injected := t.NewAgentPod(selectedNamespaces[0], true)
+						notInjected := t.NewAgentPod(selectedNamespaces[1], false)
+						notTargeted := t.NewAgentPod(otherNamespace, false)
+						t.objs = append(t.objs, injected, notInjected, notTargeted)
+					})
+
+					JustBeforeEach(func() {
+						t.reconcileAgentInjection()
+					})
+
+					It("should report the selected namespaces in the agent injection status", func() {
+						cr := t.getCryostatInstance()
+						Expect(cr.Status.AgentInjection).To(HaveLen(3))
+						Expect(cr.Status.AgentInjection[0].Namespace).To(Equal(selectedNamespaces[0]))
+						Expect(cr.Status.AgentInjection[0].InjectedPods).To(Equal(int32(1)))
+						Expect(cr.Status.AgentInjection[1].Namespace).To(Equal(otherNamespace))
+						Expect(cr.Status.AgentInjection[1].RecentFailures).To(HaveLen(1))
+						Expect(cr.Status.AgentInjection[1].RecentFailures[0].Reason).To(Equal("NamespaceNotTargeted"))
+						Expect(cr.Status.AgentInjection[2].Namespace).To(Equal(selectedNamespaces[1]))
+						Expect(cr.Status.AgentInjection[2].RecentFailures).To(HaveLen(1))
+						Expect(cr.Status.AgentInjection[2].RecentFailures[0].Reason).To(Equal("NotInjected"))
+					})
+				})
+
+				Context("when a namespace no longer matches", func() {
+					JustBeforeEach(func() {
+						ns := &corev1.Namespace{}
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: selectedNamespaces[1]}, ns)
+						Expect(err).ToNot(HaveOccurred())
+						ns.Labels = nil
+						err = t.Client.Update(context.Background(), ns)
+						Expect(err).ToNot(HaveOccurred())
+
+						t.TargetNamespaces = selectedNamespaces[:1]
+						t.reconcileCryostatFully()
+					})
+
+					It("should leave RBAC for the first namespace", func() {
+						t.expectRBAC()
+					})
+
+					It("should remove RBAC from the second namespace", func() {
+						binding := t.NewRoleBinding(selectedNamespaces[1])
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: binding.Name, Namespace: binding.Namespace}, binding)
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					})
+
+					It("should remove CA cert secret from the second namespace", func() {
+						secret := t.NewCACertSecret(selectedNamespaces[1])
+						err := t.Client.Get(context.Background(), types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}, secret)
+						Expect(kerrors.IsNotFound(err)).To(BeTrue())
+					})
+
+					It("should update the target namespaces in Status", func() {
+						t.expectTargetNamespaces()
+					})
+				})
+
+				Context("when a new namespace matches", func() {
+					JustBeforeEach(func() {
+						newNamespace := t.NewSelectedNamespace("selector-test-three")
+						t.objs = append(t.objs, newNamespace)
+						err := t.Client.Create(context.Background(), newNamespace)
+						Expect(err).ToNot(HaveOccurred())
+
+						// Selected namespaces are sorted by name
+						t.TargetNamespaces = []string{selectedNamespaces[0], newNamespace.Name, selectedNamespaces[1]}
+						t.reconcileCryostatFully()
+					})
+
+					It("should update the main deployment", func() {
+						t.expectMainDeployment()
+					})
+
+					It("should create RBAC for the new namespace", func() {
+						t.expectRBAC()
+					})
+
+					It("should create certificate secrets for the new namespace", func() {
+						t.expectCertificates()
+					})
+
+					It("should update the target namespaces in Status", func() {
+						t.expectTargetNamespaces()
+					})
+				})
+			})
+
+			Context("with a terminating namespace", func() {
+				BeforeEach(func() {
+					ns := t.NewSelectedNamespace("selector-test-terminating")
+					ns.Status.Phase = corev1.NamespaceTerminating
+					t.objs = append(t.objs, ns, t.NewCryostatWithTargetNamespaceSelector().Object)
+				})
+
+				It("should exclude the terminating namespace", func() {
+					t.expectTargetNamespaces()
+				})
+			})
+
+			Context("with target namespaces and a selector", func() {
+				BeforeEach(func() {
+					cr := t.NewCryostatWithTargetNamespaceSelector()
+					cr.Spec.TargetNamespaces = []string{t.Namespace, selectedNamespaces[1]}
+					t.objs = append(t.objs, cr.Object)
+					t.TargetNamespaces = []string{t.Namespace, selectedNamespaces[1], selectedNamespaces[0]}
+				})
+
+				It("should create the expected main deployment", func() {
+					t.expectMainDeployment()
+				})
+
+				It("should create RBAC in each namespace", func() {
+					t.expectRBAC()
+				})
+
+				It("should update the target namespaces in Status", func() {
+					t.expectTargetNamespaces()
+				})
+			})
+		})
 	})
 
 	Describe("reconciling a request in Kubernetes", func() {
@@ -2849,6 +5203,47 @@ func (c *controllerTest) commonTests() {
 				t.expectRBAC()
 			})
 		})
+		Context("with an external TLS secret for the ingress", func() {
+			BeforeEach(func() {
+				t.objs = append(t.objs, t.NewCryostatWithIngressExternalTLS().Object, t.NewExternalTLSSecret())
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should serve the ingress host using the certificate", func() {
+				t.checkIngress(t.NewExternalTLSCoreIngress())
+			})
+			It("should use HTTPS in the application URL", func() {
+				cr := t.getCryostatInstance()
+				Expect(cr.Status.ApplicationURL).To(Equal("https://cryostat.example.com"))
+			})
+			Context("with TLS entries for other hosts", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewNamespace(),
+						t.NewApiServer(),
+						t.NewCryostatWithIngressExternalTLSForOtherHosts().Object,
+						t.NewExternalTLSSecret(),
+					}
+				})
+				It("should keep the existing entries", func() {
+					t.checkIngress(t.NewExternalTLSCoreIngressForOtherHosts())
+				})
+			})
+			Context("with a TLS entry for the ingress host", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewNamespace(),
+						t.NewApiServer(),
+						t.NewCryostatWithIngressTLSForHost().Object,
+						t.NewExternalTLSSecret(),
+					}
+				})
+				It("should leave the entry as-is", func() {
+					t.checkIngress(t.NewCoreIngressWithTLSForHost())
+				})
+			})
+		})
 		Context("with non-TLS ingress", func() {
 			BeforeEach(func() {
 				t.ExternalTLS = false
@@ -2870,6 +5265,49 @@ func (c *controllerTest) commonTests() {
 				t.expectRBAC()
 			})
 		})
+		Context("with a TLS security profile", func() {
+			BeforeEach(func() {
+				t.ModernTLSProfile = true
+				t.objs = append(t.objs, t.NewCryostatWithTLSSecurityProfile().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should configure OAuth2 Proxy with the profile", func() {
+				t.expectOAuth2ConfigMap()
+			})
+			It("should configure the main deployment with the profile", func() {
+				t.expectMainDeployment()
+			})
+		})
+		Context("with sidecar TLS enabled", func() {
+			BeforeEach(func() {
+				t.SidecarTLS = true
+				t.objs = append(t.objs, t.NewCryostatWithSidecarTLS().Object)
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should configure OAuth2 Proxy to connect to Grafana using HTTPS", func() {
+				t.expectOAuth2ConfigMap()
+			})
+		})
+		Context("with an API server TLS security profile", func() {
+			BeforeEach(func() {
+				t.objs = []ctrlclient.Object{
+					t.NewNamespace(),
+					t.NewApiServerWithTLSSecurityProfile(),
+					t.NewCryostat().Object,
+				}
+			})
+			JustBeforeEach(func() {
+				t.reconcileCryostatFully()
+			})
+			It("should ignore the API server's profile", func() {
+				t.expectOAuth2ConfigMap()
+				t.expectAgentProxyConfigMap()
+			})
+		})
 		Context("no ingress configuration is provided", func() {
 			BeforeEach(func() {
 				t.objs = append(t.objs, t.NewCryostat().Object)
@@ -3197,6 +5635,8 @@ func (c *controllerTest) commonTests() {
 					&rbacv1.Role{},
 					&rbacv1.RoleBinding{},
 					&netv1.Ingress{},
+					&batchv1.CronJob{},
+					&batchv1.Job{},
 				}
 			})
 
@@ -3261,9 +5701,10 @@ func (c *controllerTest) commonTests() {
 			})
 
 			It("should watch specified resources", func() {
-				Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources)))
+				// The API server, external TLS secrets and namespaces are watched in addition to objects in target namespaces
+				Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources) + 3))
 				resources := make([]ctrlclient.Object, 0, len(expectedResources))
-				for _, watch := range t.ControllerBuilder.WatchesCalls {
+				for _, watch := range t.ControllerBuilder.WatchesCalls[:len(expectedResources)] {
 					resources = append(resources, watch.Object)
 				}
 				Expect(resources).To(ConsistOf(expectedResources))
@@ -3274,9 +5715,9 @@ func (c *controllerTest) commonTests() {
 				var obj ctrlclient.Object
 
 				JustBeforeEach(func() {
-					Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources)))
+					Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources) + 3))
 					Expect(t.ControllerBuilder.Predicates).To(HaveLen(len(expectedResources)))
-					for _, watch := range t.ControllerBuilder.WatchesCalls {
+					for _, watch := range t.ControllerBuilder.WatchesCalls[:len(expectedResources)] {
 						Expect(watch.Opts).To(HaveLen(1))
 						Expect(watch.Opts[0]).To(BeAssignableToTypeOf(builder.Predicates{}))
 					}
@@ -3321,78 +5762,253 @@ func (c *controllerTest) commonTests() {
 						delete(obj.GetLabels(), "operator.cryostat.io/name")
 					})
 
-					It("should reject", func() {
-						t.expectPredicateToReject(pred, obj)
-					})
+					It("should reject", func() {
+						t.expectPredicateToReject(pred, obj)
+					})
+				})
+			})
+
+			Context("handling events", func() {
+				var handlerFunc handler.MapFunc
+				var obj ctrlclient.Object
+
+				JustBeforeEach(func() {
+					Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources) + 3))
+					Expect(t.ControllerBuilder.MapFuncs).To(HaveLen(len(expectedResources) + 3))
+					for i, watch := range t.ControllerBuilder.WatchesCalls {
+						Expect(watch.EventHandler).ToNot(BeNil())
+						// Check that the handler uses the expected underlying type
+						mapFunc := t.ControllerBuilder.MapFuncs[i]
+						expectedHandler := handler.EnqueueRequestsFromMapFunc(mapFunc)
+						Expect(watch.EventHandler).To(BeAssignableToTypeOf(expectedHandler))
+					}
+					handlerFunc = t.ControllerBuilder.MapFuncs[0]
+				})
+
+				Context("with both labels present", func() {
+					BeforeEach(func() {
+						obj = t.NewAgentCertSecretCopy("foo")
+					})
+
+					It("should accept", func() {
+						result := handlerFunc(context.Background(), obj)
+						Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
+					})
+				})
+
+				Context("with name label missing", func() {
+					BeforeEach(func() {
+						obj = t.NewAgentCertSecretCopy("foo")
+						delete(obj.GetLabels(), "operator.cryostat.io/name")
+					})
+
+					It("should reject", func() {
+						result := handlerFunc(context.Background(), obj)
+						Expect(result).To(BeEmpty())
+					})
+				})
+
+				Context("with namespace label missing", func() {
+					BeforeEach(func() {
+						obj = t.NewAgentCertSecretCopy("foo")
+						delete(obj.GetLabels(), "operator.cryostat.io/namespace")
+					})
+
+					It("should reject", func() {
+						result := handlerFunc(context.Background(), obj)
+						Expect(result).To(BeEmpty())
+					})
+				})
+
+				Context("both labels missing", func() {
+					BeforeEach(func() {
+						obj = t.NewAgentCertSecret("foo")
+						delete(obj.GetLabels(), "operator.cryostat.io/name")
+					})
+
+					It("should reject", func() {
+						result := handlerFunc(context.Background(), obj)
+						Expect(result).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Context("watches the API server", func() {
+			var handlerFunc handler.MapFunc
+
+			JustBeforeEach(func() {
+				Expect(len(t.ControllerBuilder.WatchesCalls)).To(BeNumerically(">=", 3))
+				idx := len(t.ControllerBuilder.WatchesCalls) - 3
+				watch := t.ControllerBuilder.WatchesCalls[idx]
+				Expect(watch.Object).To(BeAssignableToTypeOf(&configv1.APIServer{}))
+				Expect(watch.Opts).To(BeEmpty())
+				handlerFunc = t.ControllerBuilder.MapFuncs[idx]
+			})
+
+			Context("with a Cryostat using the API server's TLS security profile", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+
+				It("should enqueue the Cryostat", func() {
+					result := handlerFunc(context.Background(), t.NewApiServer())
+					Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
+				})
+			})
+
+			Context("with a Cryostat specifying a TLS security profile", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithTLSSecurityProfile().Object)
+				})
+
+				It("should not enqueue the Cryostat", func() {
+					result := handlerFunc(context.Background(), t.NewApiServer())
+					Expect(result).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("watches external TLS secrets", func() {
+			var handlerFunc handler.MapFunc
+
+			JustBeforeEach(func() {
+				Expect(len(t.ControllerBuilder.WatchesCalls)).To(BeNumerically(">=", 2))
+				idx := len(t.ControllerBuilder.WatchesCalls) - 2
+				watch := t.ControllerBuilder.WatchesCalls[idx]
+				Expect(watch.Object).To(BeAssignableToTypeOf(&corev1.Secret{}))
+				Expect(watch.Opts).To(BeEmpty())
+				handlerFunc = t.ControllerBuilder.MapFuncs[idx]
+			})
+
+			Context("with a Cryostat referencing the secret", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithExternalTLS().Object)
+				})
+
+				It("should enqueue the Cryostat", func() {
+					result := handlerFunc(context.Background(), t.NewExternalTLSSecret())
+					Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
+				})
+
+				It("should ignore other secrets", func() {
+					result := handlerFunc(context.Background(), t.NewDatabaseSecret())
+					Expect(result).To(BeEmpty())
+				})
+			})
+
+			Context("with a Cryostat referencing the secret for object storage credentials", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithExternalS3("external-s3-creds").Object)
+				})
+
+				It("should enqueue the Cryostat", func() {
+					result := handlerFunc(context.Background(), t.NewExternalStorageSecret("external-s3-creds"))
+					Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
 				})
 			})
 
-			Context("handling events", func() {
-				var handlerFunc handler.MapFunc
-				var obj ctrlclient.Object
+			Context("with a Cryostat not referencing the secret", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
 
-				JustBeforeEach(func() {
-					Expect(t.ControllerBuilder.WatchesCalls).To(HaveLen(len(expectedResources)))
-					Expect(t.ControllerBuilder.MapFuncs).To(HaveLen(len(expectedResources)))
-					for i, watch := range t.ControllerBuilder.WatchesCalls {
-						Expect(watch.EventHandler).ToNot(BeNil())
-						// Check that the handler uses the expected underlying type
-						mapFunc := t.ControllerBuilder.MapFuncs[i]
-						expectedHandler := handler.EnqueueRequestsFromMapFunc(mapFunc)
-						Expect(watch.EventHandler).To(BeAssignableToTypeOf(expectedHandler))
-					}
-					handlerFunc = t.ControllerBuilder.MapFuncs[0]
+				It("should not enqueue the Cryostat", func() {
+					result := handlerFunc(context.Background(), t.NewExternalTLSSecret())
+					Expect(result).To(BeEmpty())
 				})
+			})
+		})
 
-				Context("with both labels present", func() {
-					BeforeEach(func() {
-						obj = t.NewAgentCertSecretCopy("foo")
-					})
+		Context("watches namespaces", func() {
+			var handlerFunc handler.MapFunc
 
-					It("should accept", func() {
-						result := handlerFunc(context.Background(), obj)
-						Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
-					})
+			JustBeforeEach(func() {
+				Expect(t.ControllerBuilder.WatchesCalls).ToNot(BeEmpty())
+				watch := t.ControllerBuilder.WatchesCalls[len(t.ControllerBuilder.WatchesCalls)-1]
+				Expect(watch.Object).To(BeAssignableToTypeOf(&corev1.Namespace{}))
+				Expect(watch.Opts).To(BeEmpty())
+				handlerFunc = t.ControllerBuilder.MapFuncs[len(t.ControllerBuilder.MapFuncs)-1]
+			})
+
+			Context("with a target namespace selector", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithTargetNamespaceSelector().Object)
 				})
 
-				Context("with name label missing", func() {
-					BeforeEach(func() {
-						obj = t.NewAgentCertSecretCopy("foo")
-						delete(obj.GetLabels(), "operator.cryostat.io/name")
-					})
+				It("should enqueue the Cryostat for a matching namespace", func() {
+					result := handlerFunc(context.Background(), t.NewSelectedNamespace("foo"))
+					Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
+				})
 
-					It("should reject", func() {
-						result := handlerFunc(context.Background(), obj)
-						Expect(result).To(BeEmpty())
-					})
+				It("should ignore a namespace that does not match", func() {
+					result := handlerFunc(context.Background(), t.NewOtherNamespace("foo"))
+					Expect(result).To(BeEmpty())
 				})
 
-				Context("with namespace label missing", func() {
+				Context("that previously matched the namespace", func() {
 					BeforeEach(func() {
-						obj = t.NewAgentCertSecretCopy("foo")
-						delete(obj.GetLabels(), "operator.cryostat.io/namespace")
+						cr := t.objs[len(t.objs)-1].(*operatorv1beta2.Cryostat)
+						cr.Status.TargetNamespaces = []string{"foo"}
 					})
 
-					It("should reject", func() {
-						result := handlerFunc(context.Background(), obj)
-						Expect(result).To(BeEmpty())
+					It("should enqueue the Cryostat", func() {
+						result := handlerFunc(context.Background(), t.NewOtherNamespace("foo"))
+						Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
 					})
 				})
+			})
 
-				Context("both labels missing", func() {
-					BeforeEach(func() {
-						obj = t.NewAgentCertSecret("foo")
-						delete(obj.GetLabels(), "operator.cryostat.io/name")
-					})
+			Context("without a target namespace selector", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
 
-					It("should reject", func() {
-						result := handlerFunc(context.Background(), obj)
-						Expect(result).To(BeEmpty())
-					})
+				It("should ignore all namespaces", func() {
+					result := handlerFunc(context.Background(), t.NewSelectedNamespace("foo"))
+					Expect(result).To(BeEmpty())
 				})
 			})
 		})
 	})
+
+	Describe("setting up the agent injection controller", func() {
+		BeforeEach(func() {
+			t = c.commonBeforeEach()
+			t.TargetNamespaces = []string{t.Namespace}
+		})
+
+		JustBeforeEach(func() {
+			c.commonJustBeforeEach(t)
+			// Create a default manager, not called
+			mgr, err := manager.New(cfg, manager.Options{})
+			Expect(err).ToNot(HaveOccurred())
+			err = controller.NewAgentInjectionReconciler(t.reconciler.GetConfig()).SetupWithManager(mgr)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustAfterEach(func() {
+			c.commonJustAfterEach(t)
+		})
+
+		It("should watch Cryostat CRs", func() {
+			Expect(t.ControllerBuilder.Name).To(Equal("agentinjection"))
+			Expect(t.ControllerBuilder.ForCalls).To(HaveLen(1))
+			Expect(t.ControllerBuilder.ForCalls[0].Object).To(BeAssignableToTypeOf(&operatorv1beta2.Cryostat{}))
+			Expect(t.ControllerBuilder.CompleteCalled).To(BeTrue())
+		})
+
+		It("should only watch agent pods through a separate cache", func() {
+			Expect(t.ControllerBuilder.WatchesCalls).To(BeEmpty())
+			Expect(t.ControllerBuilder.RawSources).To(HaveLen(1))
+		})
+
+		It("should enqueue the Cryostat selected by a pod", func() {
+			Expect(t.ControllerBuilder.MapFuncs).To(HaveLen(1))
+			result := t.ControllerBuilder.MapFuncs[0](context.Background(), t.NewAgentPod("foo", true))
+			Expect(result).To(ConsistOf(newReconcileRequest(t.Namespace, t.Name)))
+		})
+	})
 }
 
 func (t *cryostatTestInput) expectRoutes() {
@@ -3432,7 +6048,20 @@ func (t *cryostatTestInput) reconcileCryostatFully() {
 		result, err := t.reconcile()
 		Expect(err).ToNot(HaveOccurred())
 		return result
-	}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(Equal(reconcile.Result{}))
+	}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(Satisfy(isFullyReconciled))
+}
+
+// isFullyReconciled returns whether the reconcile only requested to be repeated far in the future,
+// such as to renew certificates, rather than to wait for resources to become ready
+func isFullyReconciled(result reconcile.Result) bool {
+	return !result.Requeue && (result.RequeueAfter == 0 || result.RequeueAfter > time.Hour)
+}
+
+func (t *cryostatTestInput) expectNoCABundleNamespaceLabel(name string) {
+	ns := &corev1.Namespace{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: name}, ns)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ns.Labels).ToNot(HaveKey(t.GetCABundleNamespaceLabel()))
 }
 
 func (t *cryostatTestInput) reconcileDeletedCryostat() {
@@ -3465,6 +6094,109 @@ func (t *cryostatTestInput) expectNoCryostat() {
 	Expect(kerrors.IsNotFound(err)).To(BeTrue())
 }
 
+func (t *cryostatTestInput) expectNoSelfSignedCA() {
+	caCert := t.NewCACert()
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: caCert.Name, Namespace: caCert.Namespace}, &certv1.Certificate{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	for _, issuer := range []*certv1.Issuer{t.NewSelfSignedIssuer(), t.NewCryostatCAIssuer()} {
+		err := t.Client.Get(context.Background(), types.NamespacedName{Name: issuer.Name, Namespace: issuer.Namespace}, &certv1.Issuer{})
+		Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	}
+}
+
+func (t *cryostatTestInput) expectIssuerCASecrets() {
+	for _, ns := range t.TargetNamespaces {
+		expected := t.NewCACertSecret(ns)
+		if ns == t.Namespace {
+			// The trusted CA bundle is stored in its own secret in the install namespace
+			delete(expected.Data, "ca.crt")
+		}
+		secret := &corev1.Secret{}
+		err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: ns}, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(Equal(expected.Data))
+		Expect(secret.Type).To(Equal(expected.Type))
+		if ns == t.Namespace {
+			Expect(metav1.IsControlledBy(secret, t.getCryostatInstance().Object)).To(BeTrue())
+		}
+	}
+}
+
+func (t *cryostatTestInput) getOperatorKeyPair(secretName string) *pki.KeyPair {
+	secret := &corev1.Secret{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: secretName, Namespace: t.Namespace}, secret)
+	Expect(err).ToNot(HaveOccurred())
+	keyPair, err := pki.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	Expect(err).ToNot(HaveOccurred())
+	return keyPair
+}
+
+func (t *cryostatTestInput) expectOperatorCertificates() {
+	cr := t.getCryostatInstance()
+	caCert := t.NewCACert()
+	caSecret := &corev1.Secret{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: caCert.Spec.SecretName, Namespace: t.Namespace}, caSecret)
+	Expect(err).ToNot(HaveOccurred())
+	ca, err := pki.ParseKeyPair(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey])
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ca.Certificate.IsCA).To(BeTrue())
+	Expect(ca.Certificate.Subject.CommonName).To(Equal(caCert.Spec.CommonName))
+	Expect(caSecret.Type).To(Equal(corev1.SecretTypeTLS))
+	Expect(metav1.IsControlledBy(caSecret, cr.Object)).To(BeTrue())
+
+	certs := []*certv1.Certificate{t.NewCryostatCert(), t.NewReportsCert(), t.NewAgentProxyCert(), t.NewDatabaseCert(), t.NewStorageCert()}
+	if t.SidecarTLS {
+		certs = append(certs, t.NewGrafanaCert(), t.NewDatasourceCert())
+	}
+	for _, ns := range t.TargetNamespaces {
+		certs = append(certs, t.NewAgentCert(ns))
+	}
+	for _, expected := range certs {
+		secret := &corev1.Secret{}
+		err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Spec.SecretName, Namespace: t.Namespace}, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Type).To(Equal(corev1.SecretTypeTLS))
+		Expect(metav1.IsControlledBy(secret, cr.Object)).To(BeTrue())
+		Expect(secret.Data).To(HaveKeyWithValue("ca.crt", ca.CertificatePEM()))
+
+		keyPair, err := pki.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keyPair.Certificate.CheckSignatureFrom(ca.Certificate)).To(Succeed())
+		Expect(keyPair.Certificate.Subject.CommonName).To(Equal(expected.Spec.CommonName))
+		Expect(keyPair.Certificate.DNSNames).To(Equal(expected.Spec.DNSNames))
+		ips := make([]string, 0, len(keyPair.Certificate.IPAddresses))
+		for _, ip := range keyPair.Certificate.IPAddresses {
+			ips = append(ips, ip.String())
+		}
+		Expect(ips).To(ConsistOf(expected.Spec.IPAddresses))
+
+		if expected.Spec.Keystores != nil {
+			Expect(secret.Data).To(HaveKeyWithValue("keystore.p12", Not(BeEmpty())))
+		} else {
+			Expect(secret.Data).ToNot(HaveKey("keystore.p12"))
+		}
+	}
+
+	// Check copies in other target namespaces
+	for _, ns := range t.TargetNamespaces {
+		if ns == t.Namespace {
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := t.Client.Get(context.Background(), types.NamespacedName{Name: caCert.Spec.SecretName, Namespace: ns}, secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Data).To(HaveKeyWithValue(corev1.TLSCertKey, ca.CertificatePEM()))
+
+		agentSecret := &corev1.Secret{}
+		err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.GetClusterUniqueNameForAgent(ns), Namespace: ns}, agentSecret)
+		Expect(err).ToNot(HaveOccurred())
+		original := &corev1.Secret{}
+		err = t.Client.Get(context.Background(), types.NamespacedName{Name: t.GetClusterUniqueNameForAgent(ns), Namespace: t.Namespace}, original)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(agentSecret.Data).To(Equal(original.Data))
+	}
+}
+
 func (t *cryostatTestInput) expectWaitingForCertificate() {
 	result, err := t.reconcile()
 	Expect(err).ToNot(HaveOccurred())
@@ -3478,6 +6210,9 @@ func (t *cryostatTestInput) expectWaitingForCertificate() {
 func (t *cryostatTestInput) expectCertificates() {
 	// Check certificates
 	certs := []*certv1.Certificate{t.NewCryostatCert(), t.NewCACert(), t.NewReportsCert(), t.NewAgentProxyCert(), t.NewDatabaseCert(), t.NewStorageCert()}
+	if t.SidecarTLS {
+		certs = append(certs, t.NewGrafanaCert(), t.NewDatasourceCert())
+	}
 	for _, expected := range certs {
 		actual := &certv1.Certificate{}
 		err := t.Client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, actual)
@@ -3592,6 +6327,33 @@ func (t *cryostatTestInput) expectRBAC() {
 	Expect(clusterBinding.RoleRef).To(Equal(expectedClusterBinding.RoleRef))
 }
 
+func (t *cryostatTestInput) expectRouterSecretAccess() {
+	expectedRole := t.NewRouterSecretRole()
+	role := &rbacv1.Role{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedRole.Name, Namespace: expectedRole.Namespace}, role)
+	Expect(err).ToNot(HaveOccurred())
+	t.checkMetadata(role, expectedRole)
+	Expect(role.Rules).To(Equal(expectedRole.Rules))
+
+	expectedBinding := t.NewRouterSecretRoleBinding()
+	binding := &rbacv1.RoleBinding{}
+	err = t.Client.Get(context.Background(), types.NamespacedName{Name: expectedBinding.Name, Namespace: expectedBinding.Namespace}, binding)
+	Expect(err).ToNot(HaveOccurred())
+	t.checkMetadata(binding, expectedBinding)
+	Expect(binding.Subjects).To(Equal(expectedBinding.Subjects))
+	Expect(binding.RoleRef).To(Equal(expectedBinding.RoleRef))
+}
+
+func (t *cryostatTestInput) expectNoRouterSecretAccess() {
+	expectedRole := t.NewRouterSecretRole()
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: expectedRole.Name, Namespace: expectedRole.Namespace}, &rbacv1.Role{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+
+	expectedBinding := t.NewRouterSecretRoleBinding()
+	err = t.Client.Get(context.Background(), types.NamespacedName{Name: expectedBinding.Name, Namespace: expectedBinding.Namespace}, &rbacv1.RoleBinding{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
 func (t *cryostatTestInput) checkClusterRoleBindingDeleted() {
 	clusterBinding := &rbacv1.ClusterRoleBinding{}
 	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.NewClusterRoleBinding().Name}, clusterBinding)
@@ -3913,6 +6675,278 @@ func (t *cryostatTestInput) expectNoNetworkPolicy(policyName string) {
 	Expect(kerrors.IsNotFound(err)).To(BeTrue())
 }
 
+func (t *cryostatTestInput) expectNoDatabase() {
+	deployment := &appsv1.Deployment{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database", Namespace: t.Namespace}, deployment)
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	t.expectNoService(t.Name + "-database")
+	t.expectNoNetworkPolicy(t.NewDatabaseIngressNetworkPolicy().Name)
+
+	cert := t.NewDatabaseCert()
+	err = t.Client.Get(context.Background(), types.NamespacedName{Name: cert.Name, Namespace: cert.Namespace}, &certv1.Certificate{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+	err = t.Client.Get(context.Background(), types.NamespacedName{Name: cert.Spec.SecretName, Namespace: cert.Namespace}, &corev1.Secret{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (t *cryostatTestInput) getDatabaseBackupCronJob() *batchv1.CronJob {
+	cronJob := &batchv1.CronJob{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database-backup", Namespace: t.Namespace}, cronJob)
+	Expect(err).ToNot(HaveOccurred())
+	return cronJob
+}
+
+func (t *cryostatTestInput) expectNoDatabaseBackupCronJob() {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database-backup", Namespace: t.Namespace}, &batchv1.CronJob{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (t *cryostatTestInput) expectNoMainDeployment() {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, &appsv1.Deployment{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (t *cryostatTestInput) getStorageLifecycleJob() *batchv1.Job {
+	job := &batchv1.Job{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage-lifecycle", Namespace: t.Namespace}, job)
+	Expect(err).ToNot(HaveOccurred())
+	return job
+}
+
+func (t *cryostatTestInput) expectNoStorageLifecycleJob() {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage-lifecycle", Namespace: t.Namespace}, &batchv1.Job{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (t *cryostatTestInput) setStorageLifecycleJobCondition(condType batchv1.JobConditionType, reason string) {
+	job := t.getStorageLifecycleJob()
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:    condType,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: "Test set the lifecycle Job condition.",
+	})
+	err := t.Client.Status().Update(context.Background(), job)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func (t *cryostatTestInput) getStorageRetentionCronJob() *batchv1.CronJob {
+	cronJob := &batchv1.CronJob{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage-retention", Namespace: t.Namespace}, cronJob)
+	Expect(err).ToNot(HaveOccurred())
+	return cronJob
+}
+
+func (t *cryostatTestInput) expectNoStorageRetentionCronJob() {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-storage-retention", Namespace: t.Namespace}, &batchv1.CronJob{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+func (t *cryostatTestInput) getDatabaseRestoreJob() *batchv1.Job {
+	job := &batchv1.Job{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database-restore", Namespace: t.Namespace}, job)
+	Expect(err).ToNot(HaveOccurred())
+	return job
+}
+
+func (t *cryostatTestInput) expectNoDatabaseRestoreJob() {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-database-restore", Namespace: t.Namespace}, &batchv1.Job{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+// reconcileUntilDatabaseRestoring reconciles until the operator waits for the restore Job
+func (t *cryostatTestInput) reconcileUntilDatabaseRestoring() {
+	Eventually(func() string {
+		result, err := t.reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		condition := meta.FindStatusCondition(t.getCryostatInstance().Status.Conditions,
+			string(operatorv1beta2.ConditionTypeStorageReady))
+		if condition == nil {
+			return ""
+		}
+		return condition.Reason
+	}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(Equal("DatabaseRestoreInProgress"))
+}
+
+func (t *cryostatTestInput) setDatabaseRestoreJobCondition(condType batchv1.JobConditionType, reason string) {
+	job := t.getDatabaseRestoreJob()
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:    condType,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: "Test set the restore Job condition.",
+	})
+	err := t.Client.Status().Update(context.Background(), job)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+// runContainerScript runs the script of a Job's container with bash, replacing the commands it calls
+// with the given shell scripts. It returns the combined output and the exit code of the script.
+func runContainerScript(container *corev1.Container, commands map[string]string) (string, int) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		Skip("bash is not installed")
+	}
+	dir := GinkgoT().TempDir()
+	for name, script := range commands {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0700)).To(Succeed())
+	}
+	Expect(container.Command).To(HaveLen(3))
+	cmd := exec.Command(bash, "-c", container.Command[2])
+	cmd.Env = []string{"PATH=" + dir + string(os.PathListSeparator) + os.Getenv("PATH")}
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			cmd.Env = append(cmd.Env, env.Name+"="+env.Value)
+		}
+	}
+	cmd.Env = append(cmd.Env, "BACKUP_DIR="+dir, "AWS_ACCESS_KEY_ID=access", "AWS_SECRET_ACCESS_KEY=secret")
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode()
+	}
+	Expect(err).ToNot(HaveOccurred())
+	return string(out), 0
+}
+
+// fakeCurl returns a script for a curl command that lists the given options in its help,
+// and prints the arguments of any request instead of sending it
+func fakeCurl(helpOptions string) string {
+	return fmt.Sprintf(`case "$1" in
+  --help) printf '%%s\n' 'Usage: curl [options...] <url>' %q ;;
+  --version) echo 'curl 7.61.1 (x86_64-redhat-linux-gnu)' ;;
+  *) echo "curl $*" ;;
+esac`, helpOptions)
+}
+
+// runStorageLifecycleScript runs the lifecycle script against a fake object storage server with the
+// given lifecycle configuration of each bucket, which may instead be "unsupported" to reject lifecycle
+// requests, or "ignored" to accept and drop them. It returns the directory holding the configuration
+// of each bucket, and the requests sent.
+func (t *cryostatTestInput) runStorageLifecycleScript(container *corev1.Container, configs map[string]string,
+	expectedCode int) (string, []string) {
+	dir := GinkgoT().TempDir()
+	for bucket, config := range configs {
+		Expect(os.WriteFile(filepath.Join(dir, bucket+".xml"), []byte(config), 0600)).To(Succeed())
+	}
+	script := `dir="$S3_DIR"; out=/dev/stdout; format=""; method=GET; data=""; url=""
+while [ $# -gt 0 ]; do
+  case "$1" in
+    --help) echo '--aws-sigv4 <provider1[:provider2[:region[:service]]]>'; exit 0 ;;
+    --request) method="$2"; shift ;;
+    --output) out="$2"; shift ;;
+    --write-out) format="$2"; shift ;;
+    --data-binary) data="$2"; shift ;;
+    --header|--user|--aws-sigv4|--cacert) shift ;;
+    -*) ;;
+    *) url="$1" ;;
+  esac
+  shift
+done
+echo "${method} ${url}" >> "${dir}/requests"
+bucket="${url%%\?*}"; file="${dir}/${bucket##*/}.xml"; code=200
+case "${method} ${url}" in
+  *"?lifecycle")
+    if [ "$(cat "${file}" 2>/dev/null)" = "unsupported" ]; then
+      code=501; echo '<Error><Code>NotImplemented</Code></Error>' > "${out}"
+    elif [ "$(cat "${file}" 2>/dev/null)" = "ignored" ]; then
+      [ "${method}" != GET ] || echo '<LifecycleConfiguration></LifecycleConfiguration>' > "${out}"
+    elif [ "${method}" = PUT ]; then
+      printf '%s' "${data}" > "${file}"
+    elif [ "${method}" = DELETE ]; then
+      rm "${file}"; code=204
+    elif [ -f "${file}" ]; then
+      cat "${file}" > "${out}"
+    else
+      code=404
+    fi ;;
+esac
+[ -z "${format}" ] || printf '%s' "${code}"`
+	script = strings.Replace(script, "$S3_DIR", dir, 1)
+	out, code := runContainerScript(container, map[string]string{"curl": script})
+	Expect(code).To(Equal(expectedCode), out)
+
+	requests, err := os.ReadFile(filepath.Join(dir, "requests"))
+	Expect(err).ToNot(HaveOccurred())
+	return dir, strings.Split(strings.TrimSpace(string(requests)), "\n")
+}
+
+func (t *cryostatTestInput) getPVC(name string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: t.Namespace}, pvc)
+	Expect(err).ToNot(HaveOccurred())
+	return pvc
+}
+
+// bindPVC simulates the PVC being bound to a volume with the given capacity
+func (t *cryostatTestInput) bindPVC(name string, capacity string) {
+	pvc := t.getPVC(name)
+	pvc.Status.Phase = corev1.ClaimBound
+	pvc.Status.Capacity = corev1.ResourceList{
+		corev1.ResourceStorage: resource.MustParse(capacity),
+	}
+	err := t.Client.Status().Update(context.Background(), pvc)
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func (t *cryostatTestInput) getDeployment(name string) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: t.Namespace}, deployment)
+	Expect(err).ToNot(HaveOccurred())
+	return deployment
+}
+
+// getDeploymentClaimName returns the PVC mounted as the deployment's data volume
+func (t *cryostatTestInput) getDeploymentClaimName(name string) string {
+	for _, volume := range t.getDeployment(name).Spec.Template.Spec.Volumes {
+		if volume.Name == name {
+			Expect(volume.PersistentVolumeClaim).ToNot(BeNil())
+			return volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	Fail("no data volume in deployment " + name)
+	return ""
+}
+
+func (t *cryostatTestInput) getVolumeMigrationJob(component string) *batchv1.Job {
+	job := &batchv1.Job{}
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-" + component + "-migration", Namespace: t.Namespace}, job)
+	Expect(err).ToNot(HaveOccurred())
+	return job
+}
+
+func (t *cryostatTestInput) expectNoVolumeMigrationJob(component string) {
+	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-" + component + "-migration", Namespace: t.Namespace}, &batchv1.Job{})
+	Expect(kerrors.IsNotFound(err)).To(BeTrue())
+}
+
+// reconcileUntilVolumeMigrating reconciles until the operator waits for a migration Job
+func (t *cryostatTestInput) reconcileUntilVolumeMigrating() {
+	Eventually(func() string {
+		result, err := t.reconcile()
+		Expect(err).ToNot(HaveOccurred())
+		condition := meta.FindStatusCondition(t.getCryostatInstance().Status.Conditions,
+			string(operatorv1beta2.ConditionTypeStorageReady))
+		if condition == nil || condition.Reason != "VolumeMigrationInProgress" {
+			return ""
+		}
+		Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+		return condition.Reason
+	}).WithTimeout(time.Minute).WithPolling(time.Millisecond).Should(Equal("VolumeMigrationInProgress"))
+}
+
+func (t *cryostatTestInput) setVolumeMigrationJobCondition(component string, condType batchv1.JobConditionType) {
+	job := t.getVolumeMigrationJob(component)
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:    condType,
+		Status:  corev1.ConditionTrue,
+		Message: "Test set the migration Job condition.",
+	})
+	err := t.Client.Status().Update(context.Background(), job)
+	Expect(err).ToNot(HaveOccurred())
+}
+
 func (t *cryostatTestInput) expectNoReportsDeployment() {
 	deployment := &appsv1.Deployment{}
 	err := t.Client.Get(context.Background(), types.NamespacedName{Name: t.Name + "-reports", Namespace: t.Namespace}, deployment)
@@ -4408,7 +7442,7 @@ func (t *cryostatTestInput) checkGrafanaContainer(container *corev1.Container, r
 	}
 	Expect(container.Ports).To(ConsistOf(t.NewGrafanaPorts()))
 	Expect(container.Env).To(ConsistOf(t.NewGrafanaEnvironmentVariables()))
-	Expect(container.VolumeMounts).To(BeEmpty())
+	Expect(container.VolumeMounts).To(ConsistOf(t.NewGrafanaVolumeMounts()))
 	Expect(container.LivenessProbe).To(Equal(t.NewGrafanaLivenessProbe()))
 	Expect(container.SecurityContext).To(Equal(securityContext))
 
@@ -4586,6 +7620,13 @@ func (t *cryostatTestInput) reconcileWithName(name string) (reconcile.Result, er
 	return t.reconciler.Reconcile(context.Background(), req)
 }
 
+func (t *cryostatTestInput) reconcileAgentInjection() {
+	req := newReconcileRequest(t.Namespace, t.Name)
+	result, err := controller.NewAgentInjectionReconciler(t.reconciler.GetConfig()).Reconcile(context.Background(), req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(result).To(Equal(reconcile.Result{}))
+}
+
 func newReconcileRequest(namespace string, name string) reconcile.Request {
 	return reconcile.Request{
 		NamespacedName: types.NamespacedName{Namespace: namespace, Name: name},
@@ -4600,6 +7641,14 @@ func (t *cryostatTestInput) expectConsoleLink() {
 	Expect(link.Spec).To(Equal(expectedLink.Spec))
 }
 
+func (t *cryostatTestInput) expectStatusObservedGeneration() {
+	cr := t.getCryostatInstance()
+	Expect(cr.Status.ObservedGeneration).To(Equal(cr.Object.GetGeneration()))
+	for _, condition := range cr.Status.Conditions {
+		Expect(condition.ObservedGeneration).To(Equal(cr.Object.GetGeneration()))
+	}
+}
+
 func (t *cryostatTestInput) expectTargetNamespaces() {
 	cr := t.getCryostatInstance()
 	Expect(*cr.TargetNamespaceStatus).To(ConsistOf(t.TargetNamespaces))
@@ -26,6 +26,7 @@ import (
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	routev1 "github.com/openshift/api/route/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -43,7 +44,15 @@ func (r *Reconciler) reconcileCoreRoute(ctx context.Context, svc *corev1.Service
 	tls *resource_definitions.TLSConfig, specs *resource_definitions.ServiceSpecs) error {
 	route := newCoreRoute(cr)
 	coreConfig := configureCoreRoute(cr)
-	routeURL, err := r.reconcileRoute(ctx, route, svc, cr, tls, coreConfig)
+	external, err := r.getExternalTLS(ctx, cr, coreConfig, tls)
+	if err != nil {
+		return err
+	}
+	err = r.reconcileRouterSecretAccess(ctx, cr, external)
+	if err != nil {
+		return err
+	}
+	routeURL, err := r.reconcileRoute(ctx, route, svc, cr, tls, external, coreConfig)
 	if err != nil {
 		return err
 	}
@@ -57,12 +66,13 @@ func (r *Reconciler) reconcileCoreRoute(ctx context.Context, svc *corev1.Service
 var ErrIngressNotReady = goerrors.New("ingress configuration not yet available")
 
 func (r *Reconciler) reconcileRoute(ctx context.Context, route *routev1.Route, svc *corev1.Service,
-	cr *model.CryostatInstance, tls *resource_definitions.TLSConfig, config *operatorv1beta2.NetworkConfiguration) (*url.URL, error) {
+	cr *model.CryostatInstance, tls *resource_definitions.TLSConfig, external *externalTLS,
+	config *operatorv1beta2.NetworkConfiguration) (*url.URL, error) {
 	port, err := GetHTTPPort(svc)
 	if err != nil {
 		return nil, err
 	}
-	route, err = r.createOrUpdateRoute(ctx, route, cr.Object, svc, port, tls, config)
+	route, err = r.createOrUpdateRoute(ctx, route, cr.Object, svc, port, tls, external, config)
 	if err != nil {
 		return nil, err
 	}
@@ -79,10 +89,25 @@ func (r *Reconciler) reconcileRoute(ctx context.Context, route *routev1.Route, s
 }
 
 func (r *Reconciler) createOrUpdateRoute(ctx context.Context, route *routev1.Route, owner metav1.Object,
-	svc *corev1.Service, exposePort *corev1.ServicePort, tlsConfig *resource_definitions.TLSConfig, config *operatorv1beta2.NetworkConfiguration) (*routev1.Route, error) {
+	svc *corev1.Service, exposePort *corev1.ServicePort, tlsConfig *resource_definitions.TLSConfig, external *externalTLS,
+	config *operatorv1beta2.NetworkConfiguration) (*routev1.Route, error) {
 	// Use edge termination by default
 	var routeTLS *routev1.TLSConfig
-	if tlsConfig == nil {
+	if external != nil {
+		// Present the user-provided certificate instead of the router's default certificate.
+		// The router reads the certificate and key from the Secret, so the key is never
+		// copied into the Route.
+		routeTLS = &routev1.TLSConfig{
+			Termination:                   routev1.TLSTerminationEdge,
+			ExternalCertificate:           &routev1.LocalObjectReference{Name: external.secretName},
+			CACertificate:                 string(external.caCert),
+			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		}
+		if external.termination == common.ExternalTLSTerminationReencrypt {
+			routeTLS.Termination = routev1.TLSTerminationReencrypt
+			routeTLS.DestinationCACertificate = string(tlsConfig.CABundle)
+		}
+	} else if tlsConfig == nil {
 		routeTLS = &routev1.TLSConfig{
 			Termination:                   routev1.TLSTerminationEdge,
 			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
@@ -90,7 +115,7 @@ func (r *Reconciler) createOrUpdateRoute(ctx context.Context, route *routev1.Rou
 	} else {
 		routeTLS = &routev1.TLSConfig{
 			Termination:                   routev1.TLSTerminationReencrypt,
-			DestinationCACertificate:      string(tlsConfig.CACert),
+			DestinationCACertificate:      string(tlsConfig.CABundle),
 			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
 		}
 	}
@@ -114,6 +139,12 @@ func (r *Reconciler) createOrUpdateRoute(ctx context.Context, route *routev1.Rou
 		if route.CreationTimestamp.IsZero() && config.ExternalHost != nil {
 			route.Spec.Host = *config.ExternalHost
 		}
+
+		// Check the external certificate against the host. Without a custom host,
+		// the host is assigned when the route is created and checked on the next update.
+		if external != nil && len(route.Spec.Host) > 0 {
+			return external.verifyHost(route.Spec.Host)
+		}
 		return nil
 	})
 	if err != nil {
@@ -123,6 +154,90 @@ func (r *Reconciler) createOrUpdateRoute(ctx context.Context, route *routev1.Rou
 	return route, nil
 }
 
+// The service account used by the OpenShift router, which must be able
+// to read Secrets referenced by a Route's external certificate
+const (
+	routerServiceAccountName      = "router"
+	routerServiceAccountNamespace = "openshift-ingress"
+)
+
+func newRouterSecretRole(cr *model.CryostatInstance) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-router-tls",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+}
+
+func newRouterSecretRoleBinding(cr *model.CryostatInstance) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-router-tls",
+			Namespace: cr.InstallNamespace,
+		},
+	}
+}
+
+// reconcileRouterSecretAccess allows the OpenShift router to read the external TLS secret,
+// which it requires in order to serve a Route's external certificate
+func (r *Reconciler) reconcileRouterSecretAccess(ctx context.Context, cr *model.CryostatInstance,
+	external *externalTLS) error {
+	role := newRouterSecretRole(cr)
+	binding := newRouterSecretRoleBinding(cr)
+	if external == nil {
+		err := r.deleteRoleBinding(ctx, binding)
+		if err != nil {
+			return err
+		}
+		return r.cleanUpRole(ctx, cr, role)
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		if err := controllerutil.SetControllerReference(cr.Object, role, r.Scheme); err != nil {
+			return err
+		}
+		role.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{external.secretName},
+				Verbs:         []string{"get", "list", "watch"},
+			},
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.Log.Info(fmt.Sprintf("Role %s", op), "name", role.Name, "namespace", role.Namespace)
+
+	op, err = controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		if err := controllerutil.SetControllerReference(cr.Object, binding, r.Scheme); err != nil {
+			return err
+		}
+		binding.Subjects = []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      routerServiceAccountName,
+				Namespace: routerServiceAccountNamespace,
+			},
+		}
+		// The Role reference never changes, and cannot be updated
+		binding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.Log.Info(fmt.Sprintf("Role Binding %s", op), "name", binding.Name, "namespace", binding.Namespace)
+	return nil
+}
+
 func getProtocol(route *routev1.Route) string {
 	if route.Spec.TLS == nil {
 		return "http"
@@ -19,12 +19,16 @@ import (
 	"errors"
 	"fmt"
 
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func (r *Reconciler) reconcileSecrets(ctx context.Context, cr *model.CryostatInstance) error {
@@ -189,3 +193,38 @@ func (r *Reconciler) deleteSecret(ctx context.Context, secret *corev1.Secret) er
 	r.Log.Info("Secret deleted", "name", secret.Name, "namespace", secret.Namespace)
 	return nil
 }
+
+// mapFromUserSecret enqueues the Cryostats that reference a user-provided Secret, which we don't own.
+// Deployments are annotated with a hash of the Secrets they use, so a rotated Secret is rolled out.
+func (r *Reconciler) mapFromUserSecret() func(ctx context.Context, obj client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		// Find all Cryostat CRs in this namespace that reference the Secret
+		crs := &operatorv1beta2.CryostatList{}
+		err := r.List(ctx, crs, client.InNamespace(obj.GetNamespace()))
+		if err != nil {
+			r.Log.Error(err, "Failed to list Cryostats for Secret event")
+			return nil
+		}
+
+		requests := []reconcile.Request{}
+		for _, cr := range crs.Items {
+			if !referencesExternalTLSSecret(&cr, obj.GetName()) && !referencesObjectStorageSecret(&cr, obj.GetName()) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+			})
+		}
+		return requests
+	}
+}
+
+func referencesExternalTLSSecret(cr *operatorv1beta2.Cryostat, name string) bool {
+	return cr.Spec.NetworkOptions != nil && cr.Spec.NetworkOptions.CoreConfig != nil &&
+		cr.Spec.NetworkOptions.CoreConfig.TLS != nil && cr.Spec.NetworkOptions.CoreConfig.TLS.SecretName == name
+}
+
+func referencesObjectStorageSecret(cr *operatorv1beta2.Cryostat, name string) bool {
+	return cr.Spec.ObjectStorageOptions != nil && cr.Spec.ObjectStorageOptions.SecretName != nil &&
+		*cr.Spec.ObjectStorageOptions.SecretName == name
+}
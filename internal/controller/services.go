@@ -68,7 +68,7 @@ func (r *Reconciler) reconcileCoreService(ctx context.Context, cr *model.Cryosta
 	if r.IsOpenShift {
 		return r.reconcileCoreRoute(ctx, svc, cr, tls, specs)
 	} else {
-		return r.reconcileCoreIngress(ctx, cr, specs)
+		return r.reconcileCoreIngress(ctx, cr, tls, specs)
 	}
 }
 
@@ -155,6 +155,10 @@ func (r *Reconciler) reconcileDatabaseService(ctx context.Context, cr *model.Cry
 		},
 	}
 
+	if !resources.DeployManagedDatabase(cr) {
+		return r.deleteService(ctx, svc)
+	}
+
 	port := *config.DatabasePort
 	err := r.createOrUpdateService(ctx, svc, cr.Object, &config.ServiceConfig, func() error {
 		svc.Spec.Selector = map[string]string{
@@ -0,0 +1,249 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Seconds to keep a lifecycle Job that failed with a transient error before it is run again
+const storageLifecycleRetryDelay = int32(600)
+
+// storageLifecycleState describes the progress of the Job applying lifecycle configuration
+type storageLifecycleState int
+
+const (
+	// No lifecycle configuration is managed by the operator
+	storageLifecycleNone storageLifecycleState = iota
+	// The lifecycle configuration is being applied or removed
+	storageLifecyclePending
+	// The age limits are applied as lifecycle configuration
+	storageLifecycleApplied
+	// The object storage does not support lifecycle configuration
+	storageLifecycleFailed
+)
+
+// reconcileStorageRetention enforces the retention rules for object storage buckets, using lifecycle
+// configuration for age limits where possible, and a pruning CronJob for the remaining rules.
+// Cryostat can run without them, so unusable object storage credentials are reported in the
+// StorageRetentionReady condition instead of failing the reconcile.
+func (r *Reconciler) reconcileStorageRetention(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
+	err := r.reconcileStorageRetentionJobs(ctx, cr, tls, imageTags, serviceSpecs, fsGroup)
+	if isObjectStorageCredentialsError(err) {
+		// The Jobs would fail to authenticate, so stop running them
+		if err := r.deleteJob(ctx, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name: cr.Name + "-storage-lifecycle", Namespace: cr.InstallNamespace}}); err != nil {
+			return err
+		}
+		if err := r.deleteCronJob(ctx, &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{
+			Name: cr.Name + "-storage-retention", Namespace: cr.InstallNamespace}}); err != nil {
+			return err
+		}
+		cr.Status.StorageRetention = nil
+		setCondition(cr, operatorv1beta2.ConditionTypeStorageRetentionReady, metav1.ConditionFalse,
+			conditionReasonForError(err), err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !resources.StorageRetentionEnabled(cr) {
+		removeConditionIfPresent(cr, operatorv1beta2.ConditionTypeStorageRetentionReady)
+	} else {
+		setCondition(cr, operatorv1beta2.ConditionTypeStorageRetentionReady, metav1.ConditionTrue, reasonReconciled,
+			"Object storage retention policies are enforced.")
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileStorageRetentionJobs(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) error {
+	state, err := r.reconcileStorageLifecycle(ctx, cr, tls, imageTags, serviceSpecs, fsGroup)
+	if err != nil {
+		return err
+	}
+
+	lifecycleApplied := state == storageLifecycleApplied
+	cronJob, err := resources.NewCronJobForStoragePruning(cr, imageTags, tls, serviceSpecs, r.IsOpenShift, fsGroup,
+		lifecycleApplied)
+	if err != nil {
+		return err
+	}
+	if !resources.StoragePruningEnabled(cr, lifecycleApplied) {
+		err = r.deleteCronJob(ctx, cronJob)
+	} else {
+		err = r.createOrUpdateCronJob(ctx, cronJob, cr.Object)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Lifecycle configuration remains in effect until it is removed
+	if resources.StorageLifecycleRules(cr) != "" || state != storageLifecyclePending {
+		cr.Status.StorageRetention = newStorageRetentionStatus(cr, lifecycleApplied)
+	}
+	return nil
+}
+
+// reconcileStorageLifecycle runs a Job to apply the age limits as lifecycle configuration of the buckets.
+// Once the age limits are removed, the Job is run again to remove the lifecycle configuration. The Job
+// is also run again after a transient failure, and when the object storage deployed by the operator restarts,
+// since its configuration is lost with an emptyDir volume.
+func (r *Reconciler) reconcileStorageLifecycle(ctx context.Context, cr *model.CryostatInstance, tls *resources.TLSConfig,
+	imageTags *resources.ImageTags, serviceSpecs *resources.ServiceSpecs, fsGroup int64) (storageLifecycleState, error) {
+	rules := resources.StorageLifecycleRules(cr)
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Name + "-storage-lifecycle", Namespace: cr.InstallNamespace}, job)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return storageLifecycleNone, err
+	}
+	found := err == nil
+	if !found && len(rules) == 0 && !hasLifecycleRetentionStatus(cr) {
+		return storageLifecycleNone, nil
+	}
+
+	instance, available, err := r.getStorageInstance(ctx, cr)
+	if err != nil {
+		return storageLifecycleNone, err
+	}
+	desired, err := resources.NewJobForStorageLifecycle(cr, imageTags, tls, serviceSpecs, r.IsOpenShift, fsGroup, instance)
+	if err != nil {
+		return storageLifecycleNone, err
+	}
+	if !found {
+		if !available {
+			// The Job's requests would fail until the object storage is ready
+			return storageLifecyclePending, nil
+		}
+		if err := controllerutil.SetControllerReference(cr.Object, desired, r.Scheme); err != nil {
+			return storageLifecycleNone, err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return storageLifecycleNone, err
+		}
+		r.Log.Info("Job created", "name", desired.Name, "namespace", desired.Namespace)
+		return storageLifecyclePending, nil
+	}
+
+	// The Job is immutable, so replace it when the age limits change or the object storage restarts
+	if job.Annotations[resources.StorageLifecycleRulesAnnotation] != rules ||
+		(available && job.Annotations[resources.StorageLifecycleInstanceAnnotation] != instance) {
+		if err := r.deleteJob(ctx, job); err != nil {
+			return storageLifecycleNone, err
+		}
+		return storageLifecyclePending, nil
+	}
+
+	state := storageLifecyclePending
+	if hasJobCondition(job, batchv1.JobComplete) {
+		state = storageLifecycleApplied
+	} else if failed := findJobCondition(job, batchv1.JobFailed); failed != nil {
+		// The script exits with a non-retryable code if lifecycle configuration is not supported
+		if failed.Reason == batchv1.JobReasonPodFailurePolicy {
+			r.Log.Info("Object storage does not support lifecycle configuration, pruning buckets instead",
+				"name", job.Name, "namespace", job.Namespace)
+			state = storageLifecycleFailed
+		} else if err := r.retryStorageLifecycleJob(ctx, job); err != nil {
+			return storageLifecycleNone, err
+		}
+	}
+	if len(rules) == 0 && state != storageLifecyclePending {
+		// The lifecycle configuration was removed, so the Job is no longer needed
+		return storageLifecycleNone, r.deleteJob(ctx, job)
+	}
+	return state, nil
+}
+
+// retryStorageLifecycleJob has Kubernetes delete a Job that failed with a transient error after a delay,
+// so that it is created again. The age limits are enforced by pruning in the meantime.
+func (r *Reconciler) retryStorageLifecycleJob(ctx context.Context, job *batchv1.Job) error {
+	if job.Spec.TTLSecondsAfterFinished != nil {
+		return nil
+	}
+	r.Log.Info("Failed to apply lifecycle configuration, retrying later", "name", job.Name, "namespace", job.Namespace,
+		"delaySeconds", storageLifecycleRetryDelay)
+	ttl := storageLifecycleRetryDelay
+	job.Spec.TTLSecondsAfterFinished = &ttl
+	return r.Update(ctx, job)
+}
+
+// getStorageInstance identifies the running object storage deployed by the operator by its Deployment
+// and the time it last became available. It also returns whether the object storage is available,
+// which is always true for external object storage.
+func (r *Reconciler) getStorageInstance(ctx context.Context, cr *model.CryostatInstance) (string, bool, error) {
+	if !resources.DeployManagedStorage(cr) {
+		return "", true, nil
+	}
+	deploy := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: cr.Name + "-storage", Namespace: cr.InstallNamespace}, deploy)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	condition := findDeployCondition(deploy.Status.Conditions, appsv1.DeploymentAvailable)
+	if condition == nil || condition.Status != corev1.ConditionTrue {
+		return "", false, nil
+	}
+	return fmt.Sprintf("%s/%s", deploy.UID, condition.LastTransitionTime.UTC().Format(time.RFC3339)), true, nil
+}
+
+func newStorageRetentionStatus(cr *model.CryostatInstance, lifecycleApplied bool) []operatorv1beta2.BucketRetentionStatus {
+	var result []operatorv1beta2.BucketRetentionStatus
+	for _, retention := range resources.GetStorageRetention(cr) {
+		status := operatorv1beta2.BucketRetentionStatus{
+			Bucket:       retention.Bucket,
+			MaxAgeDays:   retention.Rule.MaxAgeDays,
+			MaxTotalSize: retention.Rule.MaxTotalSize,
+		}
+		if status.MaxAgeDays != nil {
+			status.MaxAgeEnforcedBy = operatorv1beta2.RetentionEnforcedByPruning
+			if lifecycleApplied {
+				status.MaxAgeEnforcedBy = operatorv1beta2.RetentionEnforcedByLifecycle
+			}
+		}
+		if status.MaxTotalSize != nil {
+			status.MaxTotalSizeEnforcedBy = operatorv1beta2.RetentionEnforcedByPruning
+		}
+		result = append(result, status)
+	}
+	return result
+}
+
+// hasLifecycleRetentionStatus returns whether any age limits were applied as lifecycle configuration
+func hasLifecycleRetentionStatus(cr *model.CryostatInstance) bool {
+	for _, status := range cr.Status.StorageRetention {
+		if status.MaxAgeEnforcedBy == operatorv1beta2.RetentionEnforcedByLifecycle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/model"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// trust-manager has no Go types we depend on, so its Bundles are handled as unstructured
+var trustManagerBundleGVK = schema.GroupVersionKind{
+	Group:   "trust.cert-manager.io",
+	Version: "v1alpha1",
+	Kind:    "Bundle",
+}
+
+const (
+	eventTrustManagerUnavailableType = "TrustManagerUnavailable"
+	reasonTrustManagerUnavailable    = "TrustManagerUnavailable"
+)
+
+const eventTrustManagerUnavailableMsg = "trust-manager is not detected in the cluster, so the CA certificate is copied " +
+	"into a Secret in each target namespace instead. Please install trust-manager or remove \"tlsOptions.caDistribution\" " +
+	"from this Cryostat custom resource."
+
+// distributeCACertificate makes the trusted CA certificates available in each target namespace,
+// using a trust-manager Bundle if requested and available, or by copying the CA certificate secret otherwise
+func (r *Reconciler) distributeCACertificate(ctx context.Context, cr *model.CryostatInstance, caBytes []byte,
+	caBundle []byte) error {
+	caCert := resources.NewCryostatCACert(r.gvk, cr)
+	useBundle, err := r.useTrustManager(cr)
+	if err != nil {
+		return err
+	}
+
+	if useBundle {
+		err = r.reconcileTrustManagerBundle(ctx, cr, caBundle)
+		if err != nil {
+			return err
+		}
+		// Remove any CA certificate secrets previously copied into target namespaces
+		for _, ns := range cr.TargetNamespaces {
+			if ns != cr.InstallNamespace {
+				err = r.deleteSecret(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      caCert.Spec.SecretName,
+						Namespace: ns,
+					},
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	// Remove any Bundle previously created for this CR
+	err = r.deleteTrustManagerBundle(ctx, cr)
+	if err != nil {
+		return err
+	}
+	for _, ns := range cr.TargetNamespaces {
+		if ns != cr.InstallNamespace {
+			err = r.copyCASecret(ctx, cr, caCert, ns, caBytes, caBundle)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// useTrustManager returns whether the CA certificate should be distributed using trust-manager
+func (r *Reconciler) useTrustManager(cr *model.CryostatInstance) (bool, error) {
+	if !common.IsTrustManagerCADistribution(cr) {
+		return false, nil
+	}
+	return r.trustManagerAvailable()
+}
+
+// setTLSSetupComplete marks the TLSSetupComplete condition as true. If trust-manager was requested
+// but is unavailable, the condition says so, and an Event informs the user when this first happens.
+func (r *Reconciler) setTLSSetupComplete(ctx context.Context, cr *model.CryostatInstance) error {
+	reason := reasonAllCertsReady
+	message := "All certificates for Cryostat components are ready."
+	if common.IsTrustManagerCADistribution(cr) {
+		available, err := r.trustManagerAvailable()
+		if err != nil {
+			return err
+		}
+		if !available {
+			previous := meta.FindStatusCondition(cr.Status.Conditions, string(operatorv1beta2.ConditionTypeTLSSetupComplete))
+			if previous == nil || previous.Reason != reasonTrustManagerUnavailable {
+				r.EventRecorder.Event(cr.Object, corev1.EventTypeWarning, eventTrustManagerUnavailableType, eventTrustManagerUnavailableMsg)
+			}
+			reason = reasonTrustManagerUnavailable
+			message = "All certificates for Cryostat components are ready. trust-manager is not detected in the cluster, " +
+				"so the CA certificate is copied into a Secret in each target namespace instead."
+		}
+	}
+	return r.updateCondition(ctx, cr, operatorv1beta2.ConditionTypeTLSSetupComplete, metav1.ConditionTrue, reason, message)
+}
+
+func (r *Reconciler) trustManagerAvailable() (bool, error) {
+	_, err := r.RESTMapper.RESTMapping(trustManagerBundleGVK.GroupKind(), trustManagerBundleGVK.Version)
+	if err != nil {
+		// No matches for Bundle GVK
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		// Unexpected error occurred
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Reconciler) newTrustManagerBundle(cr *model.CryostatInstance) *unstructured.Unstructured {
+	bundle := &unstructured.Unstructured{}
+	bundle.SetGroupVersionKind(trustManagerBundleGVK)
+	bundle.SetName(common.CABundleName(r.gvk, cr))
+	return bundle
+}
+
+// reconcileTrustManagerBundle creates or updates a cluster-scoped Bundle containing the trusted
+// CA certificates, which trust-manager synchronizes into a ConfigMap in each target namespace
+func (r *Reconciler) reconcileTrustManagerBundle(ctx context.Context, cr *model.CryostatInstance, caBundle []byte) error {
+	err := r.reconcileCABundleNamespaceLabels(ctx, cr, cr.TargetNamespaces)
+	if err != nil {
+		return err
+	}
+
+	bundle := r.newTrustManagerBundle(cr)
+	spec := map[string]any{
+		// Use an inline source, since secret sources must be in the trust-manager namespace
+		"sources": []any{
+			map[string]any{
+				"inLine": string(caBundle),
+			},
+		},
+		"target": map[string]any{
+			"configMap": map[string]any{
+				"key": constants.CAKey,
+			},
+			"namespaceSelector": map[string]any{
+				"matchLabels": map[string]any{
+					common.CABundleNamespaceLabel(r.gvk, cr): "true",
+				},
+			},
+		},
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, bundle, func() error {
+		// Cluster-scoped, so the CR cannot own it. It is deleted by the finalizer instead.
+		labels := bundle.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		maps.Copy(labels, common.LabelsForTargetNamespaceObject(cr))
+		bundle.SetLabels(labels)
+		return unstructured.SetNestedField(bundle.Object, spec, "spec")
+	})
+	if err != nil {
+		return err
+	}
+	r.Log.Info(fmt.Sprintf("Bundle %s", op), "name", bundle.GetName())
+	return nil
+}
+
+func (r *Reconciler) deleteTrustManagerBundle(ctx context.Context, cr *model.CryostatInstance) error {
+	err := r.reconcileCABundleNamespaceLabels(ctx, cr, nil)
+	if err != nil {
+		return err
+	}
+
+	// Nothing to delete if trust-manager is not installed
+	available, err := r.trustManagerAvailable()
+	if err != nil || !available {
+		return err
+	}
+	bundle := r.newTrustManagerBundle(cr)
+	err = r.Delete(ctx, bundle)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil
+		}
+		r.Log.Error(err, "Could not delete Bundle", "name", bundle.GetName())
+		return err
+	}
+	r.Log.Info("deleted Bundle", "name", bundle.GetName())
+	return nil
+}
+
+// reconcileCABundleNamespaceLabels labels the given namespaces so that the CR's Bundle selects them,
+// and removes the label from any other namespaces. The Bundle selects namespaces using this label,
+// rather than by name, since older trust-manager releases only support "matchLabels" in its selector.
+func (r *Reconciler) reconcileCABundleNamespaceLabels(ctx context.Context, cr *model.CryostatInstance, namespaces []string) error {
+	key := common.CABundleNamespaceLabel(r.gvk, cr)
+	labelled := &corev1.NamespaceList{}
+	err := r.List(ctx, labelled, ctrlclient.HasLabels{key})
+	if err != nil {
+		return err
+	}
+	for i := range labelled.Items {
+		ns := &labelled.Items[i]
+		if !slices.Contains(namespaces, ns.Name) {
+			err = r.patchNamespaceLabel(ctx, ns, key, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range namespaces {
+		ns := &corev1.Namespace{}
+		err = r.Get(ctx, types.NamespacedName{Name: name}, ns)
+		if err != nil {
+			return err
+		}
+		if ns.Labels[key] != "true" {
+			err = r.patchNamespaceLabel(ctx, ns, key, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) patchNamespaceLabel(ctx context.Context, ns *corev1.Namespace, key string, add bool) error {
+	original := ns.DeepCopy()
+	if add {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[key] = "true"
+	} else {
+		delete(ns.Labels, key)
+	}
+	err := r.Patch(ctx, ns, ctrlclient.MergeFrom(original))
+	if err != nil {
+		r.Log.Error(err, "Could not update namespace labels", "name", ns.Name)
+		return err
+	}
+	r.Log.Info("Namespace labels updated", "name", ns.Name, "label", key, "present", add)
+	return nil
+}
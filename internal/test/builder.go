@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 )
@@ -29,8 +30,10 @@ import (
 // controller watches
 type TestCtrlBuilder struct {
 	ForCalls       []ForArgs
+	Name           string
 	OwnsCalls      []OwnsArgs
 	WatchesCalls   []WatchesArgs
+	RawSources     []source.Source
 	MapFuncs       []handler.MapFunc
 	Predicates     []predicate.Predicate
 	CompleteCalled bool
@@ -71,6 +74,11 @@ func (b *TestCtrlBuilder) For(object client.Object, opts ...builder.ForOption) c
 	return b
 }
 
+func (b *TestCtrlBuilder) Named(name string) common.ControllerBuilder {
+	b.Name = name
+	return b
+}
+
 func (b *TestCtrlBuilder) Owns(object client.Object, opts ...builder.OwnsOption) common.ControllerBuilder {
 	b.OwnsCalls = append(b.OwnsCalls, OwnsArgs{
 		Object: object,
@@ -89,6 +97,11 @@ func (b *TestCtrlBuilder) Watches(object client.Object, eventHandler handler.Eve
 	return b
 }
 
+func (b *TestCtrlBuilder) WatchesRawSource(src source.Source) common.ControllerBuilder {
+	b.RawSources = append(b.RawSources, src)
+	return b
+}
+
 func (b *TestCtrlBuilder) Complete(r reconcile.Reconciler) error {
 	b.CompleteCalled = true
 	return nil
@@ -118,7 +118,7 @@ func (c *testClient) updateRouteStatus(obj runtime.Object) {
 
 func (c *testClient) matchesCert(cert *certv1.Certificate) bool {
 	return c.matchesName(cert, c.NewCryostatCert(), c.NewCACert(), c.NewReportsCert(), c.NewAgentProxyCert(),
-		c.NewDatabaseCert(), c.NewStorageCert()) || c.matchesPrefix(cert, c.GetAgentCertPrefix())
+		c.NewDatabaseCert(), c.NewStorageCert(), c.NewGrafanaCert(), c.NewDatasourceCert()) || c.matchesPrefix(cert, c.GetAgentCertPrefix())
 }
 
 func (c *testClient) migrateStringData(obj runtime.Object) {
@@ -16,15 +16,18 @@ package test
 
 import (
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"slices"
 	"strings"
+	"time"
 
 	certv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	certMeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common/pki"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
@@ -33,12 +36,15 @@ import (
 	securityv1 "github.com/openshift/api/security/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	authzv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -62,6 +68,12 @@ type TestResources struct {
 	DatabaseSecret             *corev1.Secret
 	StorageSecret              *corev1.Secret
 	LogLevel                   string
+	IssuerRef                  *operatorv1beta2.IssuerReference
+	CACertificateOptions       *operatorv1beta2.CertificateOptions
+	CertificateOptions         *operatorv1beta2.CertificateOptions
+	ModernTLSProfile           bool
+	SidecarTLS                 bool
+	ExternalDatabase           bool
 }
 
 func NewTestScheme() *runtime.Scheme {
@@ -80,6 +92,19 @@ func NewTestScheme() *runtime.Scheme {
 	return s
 }
 
+var TrustManagerBundleGVK = schema.GroupVersionKind{
+	Group:   "trust.cert-manager.io",
+	Version: "v1alpha1",
+	Kind:    "Bundle",
+}
+
+// NewTESTRESTMapperWithTrustManager returns a RESTMapper that also maps the trust-manager Bundle kind
+func NewTESTRESTMapperWithTrustManager() meta.RESTMapper {
+	mapper := NewTESTRESTMapper().(*meta.DefaultRESTMapper)
+	mapper.Add(TrustManagerBundleGVK, meta.RESTScopeRoot)
+	return mapper
+}
+
 func NewTESTRESTMapper() meta.RESTMapper {
 	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{
 		certv1.SchemeGroupVersion,
@@ -299,6 +324,28 @@ func (r *TestResources) NewCryostatWithExternalS3(secretName string) *model.Cryo
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithS3WebIdentity() *model.CryostatInstance {
+	cr := r.NewCryostatWithCustomizedStorageBucketNames()
+	cr.Spec.ObjectStorageOptions.Credentials = &operatorv1beta2.ObjectStorageCredentials{
+		Mode: operatorv1beta2.ObjectStorageCredentialsWebIdentity,
+		WebIdentity: &operatorv1beta2.WebIdentityCredentials{
+			RoleARN: "arn:aws:iam::123456789012:role/cryostat",
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithS3DefaultChain() *model.CryostatInstance {
+	cr := r.NewCryostatWithCustomizedStorageBucketNames()
+	cr.Spec.ObjectStorageOptions.Credentials = &operatorv1beta2.ObjectStorageCredentials{
+		Mode: operatorv1beta2.ObjectStorageCredentialsDefaultChain,
+		ServiceAccountAnnotations: map[string]string{
+			"iam.gke.io/gcp-service-account": "cryostat@example.iam.gserviceaccount.com",
+		},
+	}
+	return cr
+}
+
 func (r *TestResources) NewCryostatWithCustomizedStorageBucketNames() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	providerUrl := "https://example.com:1234"
@@ -489,6 +536,23 @@ func (r *TestResources) NewCryostatWithDefaultEmptyDir() *model.CryostatInstance
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithPVCStorageClass(storageClass string) *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.StorageOptions = &operatorv1beta2.StorageConfigurations{
+		Database: &operatorv1beta2.StorageConfiguration{
+			PVC: &operatorv1beta2.PersistentVolumeClaimConfig{
+				Spec: newPVCSpec(storageClass, "500Mi", corev1.ReadWriteOnce),
+			},
+		},
+		ObjectStorage: &operatorv1beta2.StorageConfiguration{
+			PVC: &operatorv1beta2.PersistentVolumeClaimConfig{
+				Spec: newPVCSpec(storageClass, "32Gi", corev1.ReadWriteOnce),
+			},
+		},
+	}
+	return cr
+}
+
 func (r *TestResources) NewCryostatWithDefaultEmptyDirLegacy() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.StorageOptions = &operatorv1beta2.StorageConfigurations{
@@ -681,6 +745,28 @@ func (r *TestResources) NewCryostatWithCoreRouteHost() *model.CryostatInstance {
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithExternalTLS() *model.CryostatInstance {
+	cr := r.NewCryostatWithCoreRouteHost()
+	cr.Spec.NetworkOptions.CoreConfig.TLS = &operatorv1beta2.ExternalTLSConfig{
+		SecretName: r.newExternalTLSSecretName(),
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithExternalTLSTermination(termination string) *model.CryostatInstance {
+	cr := r.NewCryostatWithExternalTLS()
+	cr.Spec.NetworkOptions.CoreConfig.TLS.Termination = &termination
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithIngressExternalTLS() *model.CryostatInstance {
+	cr := r.NewCryostatWithIngress()
+	cr.Spec.NetworkOptions.CoreConfig.TLS = &operatorv1beta2.ExternalTLSConfig{
+		SecretName: r.newExternalTLSSecretName(),
+	}
+	return cr
+}
+
 func (r *TestResources) NewCryostatWithReportsResources() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.ReportOptions = &operatorv1beta2.ReportConfiguration{
@@ -781,6 +867,14 @@ func (r *TestResources) NewCryostatWithReportsScheduling() *model.CryostatInstan
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithIssuerRef() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		IssuerRef: r.IssuerRef,
+	}
+	return cr
+}
+
 func (r *TestResources) NewCryostatCertManagerDisabled() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	certManager := false
@@ -788,6 +882,76 @@ func (r *TestResources) NewCryostatCertManagerDisabled() *model.CryostatInstance
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithCertificateOptions() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		CACertificate: r.CACertificateOptions,
+		Certificates:  r.CertificateOptions,
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithSidecarTLS() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		SidecarTLS: true,
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithTLSSecurityProfile() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		SecurityProfile: &configv1.TLSSecurityProfile{
+			Type:   configv1.TLSProfileModernType,
+			Modern: &configv1.ModernTLSProfile{},
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithOperatorCertificates() *model.CryostatInstance {
+	cr := r.NewCryostatCertManagerDisabled()
+	provider := "Operator"
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		CertificateProvider: &provider,
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithAgentRestarts() *model.CryostatInstance {
+	cr := r.NewCryostatWithOperatorCertificates()
+	cr.Spec.AgentOptions = &operatorv1beta2.AgentOptions{
+		RestartOnCertificateRotation: true,
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithTrustManager() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	distribution := "TrustManager"
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		CADistribution: &distribution,
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithCARotationGracePeriod(gracePeriod time.Duration) *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		CARotationGracePeriod: &metav1.Duration{Duration: gracePeriod},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithCertificateReadyTimeout(timeout time.Duration) *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		CertificateReadyTimeout: &metav1.Duration{Duration: timeout},
+	}
+	return cr
+}
+
 func (r *TestResources) NewCryostatCertManagerUndefined() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.EnableCertManager = nil
@@ -969,6 +1133,26 @@ func (r *TestResources) NewCryostatWithResourcesNoLimit() *model.CryostatInstanc
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithTargetNamespaceSelector() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TargetNamespaces = nil
+	cr.TargetNamespaces = nil
+	cr.Spec.TargetNamespaceSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"cryostat.io/target": r.Name,
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewSelectedNamespace(name string) *corev1.Namespace {
+	ns := r.NewOtherNamespace(name)
+	ns.Labels = map[string]string{
+		"cryostat.io/target": r.Name,
+	}
+	return ns
+}
+
 func (r *TestResources) NewCryostatWithBuiltInDiscoveryDisabled() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.TargetDiscoveryOptions = &operatorv1beta2.TargetDiscoveryOptions{
@@ -1129,6 +1313,199 @@ func (r *TestResources) NewCryostatWithDatabaseSecretProvided() *model.CryostatI
 	return cr
 }
 
+func (r *TestResources) NewCryostatWithExternalDatabase() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	port := int32(5433)
+	database := "cryostat-db"
+	cr.Spec.DatabaseOptions = &operatorv1beta2.DatabaseOptions{
+		External: &operatorv1beta2.ExternalDatabaseOptions{
+			Host:     "postgres.example.com",
+			Port:     &port,
+			Database: &database,
+			CACertificate: &operatorv1beta2.CertificateSecret{
+				ConfigMapName: r.NewExternalDatabaseCAConfigMap().Name,
+			},
+			CredentialsSecretName: r.NewExternalDatabaseCredentialsSecret().Name,
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithDatabaseBackup() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	retention := int32(3)
+	cr.Spec.DatabaseOptions = &operatorv1beta2.DatabaseOptions{
+		Backup: &operatorv1beta2.DatabaseBackupOptions{
+			Schedule:  "0 2 * * *",
+			Retention: &retention,
+			Destination: operatorv1beta2.DatabaseBackupDestination{
+				PersistentVolumeClaim: &operatorv1beta2.DatabaseBackupPVC{
+					ClaimName: "cryostat-db-backups",
+				},
+			},
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithDatabaseBackupToObjectStorage() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.DatabaseOptions = &operatorv1beta2.DatabaseOptions{
+		Backup: &operatorv1beta2.DatabaseBackupOptions{
+			Schedule: "@daily",
+			Destination: operatorv1beta2.DatabaseBackupDestination{
+				ObjectStorage: &operatorv1beta2.DatabaseBackupObjectStorage{},
+			},
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithDatabaseRestore() *model.CryostatInstance {
+	cr := r.NewCryostatWithDatabaseBackup()
+	cr.Spec.DatabaseOptions.Restore = &operatorv1beta2.DatabaseRestoreOptions{
+		BackupName: "cryostat-20240102030405.dump",
+	}
+	return cr
+}
+
+func (r *TestResources) NewDatabaseBackupPodLabels() map[string]string {
+	return map[string]string{
+		"app":       r.Name,
+		"kind":      "cryostat",
+		"component": "database-backup",
+	}
+}
+
+func (r *TestResources) NewNonRetryablePodFailurePolicy(containerName string) *batchv1.PodFailurePolicy {
+	return &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					ContainerName: &containerName,
+					Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+					Values:        []int32{3},
+				},
+			},
+		},
+	}
+}
+
+func (r *TestResources) NewDatabaseBackupNetworkPolicyPeer() netv1.NetworkPolicyPeer {
+	return netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": r.Namespace,
+			},
+		},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: r.NewDatabaseBackupPodLabels(),
+		},
+	}
+}
+
+func (r *TestResources) NewCryostatWithStorageRetention() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	maxAgeDays := int32(30)
+	recordingsSize := resource.MustParse("10Gi")
+	heapDumpsSize := resource.MustParse("5Gi")
+	cr.Spec.ObjectStorageOptions = &operatorv1beta2.ObjectStorageOptions{
+		Retention: &operatorv1beta2.ObjectStorageRetention{
+			ArchivedRecordings: &operatorv1beta2.BucketRetentionRule{
+				MaxAgeDays:   &maxAgeDays,
+				MaxTotalSize: &recordingsSize,
+			},
+			HeapDumps: &operatorv1beta2.BucketRetentionRule{
+				MaxTotalSize: &heapDumpsSize,
+			},
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCryostatWithStorageRetentionByAge() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	maxAgeDays := int32(7)
+	cr.Spec.ObjectStorageOptions = &operatorv1beta2.ObjectStorageOptions{
+		Retention: &operatorv1beta2.ObjectStorageRetention{
+			ArchivedReports: &operatorv1beta2.BucketRetentionRule{
+				MaxAgeDays: &maxAgeDays,
+			},
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewStorageRetentionNetworkPolicyPeer() netv1.NetworkPolicyPeer {
+	return netv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"kubernetes.io/metadata.name": r.Namespace,
+			},
+		},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app":       r.Name,
+				"kind":      "cryostat",
+				"component": "storage-retention",
+			},
+		},
+	}
+}
+
+func (r *TestResources) NewDatabaseClientEnvironmentVariables() []corev1.EnvVar {
+	optional := false
+	envs := []corev1.EnvVar{
+		{
+			Name:  "PGHOST",
+			Value: fmt.Sprintf("%s-database.%s.svc", r.Name, r.Namespace),
+		},
+		{
+			Name:  "PGPORT",
+			Value: "5432",
+		},
+		{
+			Name:  "PGUSER",
+			Value: "cryostat",
+		},
+		{
+			Name:  "PGDATABASE",
+			Value: "cryostat",
+		},
+		{
+			Name: "PGPASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: r.Name + "-db",
+					},
+					Key:      "CONNECTION_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name:  "BACKUP_DIR",
+			Value: "/var/lib/cryostat/backups",
+		},
+	}
+	return envs
+}
+
+func (r *TestResources) NewDatabaseClientTLSEnvironmentVariables() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  "PGSSLMODE",
+			Value: "verify-full",
+		},
+		{
+			Name:  "PGSSLROOTCERT",
+			Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-database-tls/ca.crt", r.Name),
+		},
+	}
+}
+
 func (r *TestResources) NewCryostatWithAdditionalMetadata() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.OperandMetadata = &operatorv1beta2.OperandMetadata{
@@ -1826,11 +2203,204 @@ func (r *TestResources) NewCACertSecret(ns string) *corev1.Secret {
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: map[string][]byte{
-			corev1.TLSCertKey: []byte(r.Name + "-ca-bytes"),
+			corev1.TLSCertKey: r.getCABytes(),
+			"ca.crt":          r.getCABytes(),
+		},
+	}
+}
+
+func (r *TestResources) NewCABundleSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name + "-ca-bundle",
+			Namespace: r.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"ca.crt": r.getCABytes(),
 		},
 	}
 }
 
+// NewCABundleSecretBeforeRotation returns the CA bundle secret as it was before the CA certificate changed
+func (r *TestResources) NewCABundleSecretBeforeRotation() *corev1.Secret {
+	secret := r.NewCABundleSecret()
+	secret.Data["ca.crt"] = testPreviousCACert
+	return secret
+}
+
+// NewCABundleSecretDuringRotation returns the CA bundle secret after the CA certificate changed at rotatedAt
+func (r *TestResources) NewCABundleSecretDuringRotation(rotatedAt time.Time) *corev1.Secret {
+	secret := r.NewCABundleSecret()
+	secret.Annotations = map[string]string{
+		"operator.cryostat.io/ca-rotated-at": rotatedAt.UTC().Format(time.RFC3339),
+	}
+	secret.Data["ca.crt"] = r.GetCABundleDuringRotation()
+	return secret
+}
+
+// GetCABundleDuringRotation returns the current and previous CA certificates, in that order
+func (r *TestResources) GetCABundleDuringRotation() []byte {
+	return append(append([]byte{}, r.getCABytes()...), testPreviousCACert...)
+}
+
+// NewCertSecretFromPreviousCA returns a certificate secret as if it were signed by the previous CA certificate
+func (r *TestResources) NewCertSecretFromPreviousCA(cert *certv1.Certificate) *corev1.Secret {
+	secret := r.NewCertSecret(cert)
+	secret.Data["ca.crt"] = testPreviousCACert
+	return secret
+}
+
+// NewTrustManagerBundle returns the trust-manager Bundle distributing the CA certificate to target namespaces
+func (r *TestResources) NewTrustManagerBundle() *unstructured.Unstructured {
+	bundle := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": map[string]any{
+				"sources": []any{
+					map[string]any{
+						"inLine": string(r.getCABytes()),
+					},
+				},
+				"target": map[string]any{
+					"configMap": map[string]any{
+						"key": "ca.crt",
+					},
+					"namespaceSelector": map[string]any{
+						"matchLabels": map[string]any{
+							r.GetCABundleNamespaceLabel(): "true",
+						},
+					},
+				},
+			},
+		},
+	}
+	bundle.SetGroupVersionKind(TrustManagerBundleGVK)
+	bundle.SetName(r.GetTrustManagerBundleName())
+	bundle.SetLabels(map[string]string{
+		"operator.cryostat.io/name":      r.Name,
+		"operator.cryostat.io/namespace": r.Namespace,
+	})
+	return bundle
+}
+
+// GetTrustManagerBundleName returns the name of the trust-manager Bundle, and the ConfigMaps it creates
+func (r *TestResources) GetTrustManagerBundleName() string {
+	return r.getClusterUniqueNameForCA()
+}
+
+// GetCABundleNamespaceLabel returns the label selecting target namespaces for the trust-manager Bundle
+func (r *TestResources) GetCABundleNamespaceLabel() string {
+	return "operator.cryostat.io/cryostat-ca-" + r.clusterUniqueShortSuffix()
+}
+
+func (r *TestResources) getCABytes() []byte {
+	if r.IssuerRef != nil {
+		return testIssuerCACert
+	}
+	return testCACert
+}
+
+// CA certificates standing in for those issued by cert-manager, since the operator parses them
+var (
+	testCACert         = newTestCACert("cryostat-ca-cert-manager")
+	testIssuerCACert   = newTestCACert("test-issuer-ca")
+	testPreviousCACert = newTestCACert("cryostat-ca-cert-manager")
+)
+
+func newTestCACert(commonName string) []byte {
+	ca, err := pki.NewSelfSignedCA(&pki.CertificateRequest{
+		CommonName:   commonName,
+		IsCA:         true,
+		Duration:     24 * time.Hour,
+		KeyAlgorithm: pki.ECDSAKeyAlgorithm,
+		KeySize:      256,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return ca.CertificatePEM()
+}
+
+// Certificates standing in for those issued by a public CA for the external host
+var (
+	testExternalCACert, testExternalCert, testExternalKey = newTestExternalCert("cryostat.example.com")
+	_, testOtherExternalCert, testOtherExternalKey        = newTestExternalCert("other.example.com")
+)
+
+func newTestExternalCert(host string) (caPEM []byte, certPEM []byte, keyPEM []byte) {
+	ca, err := pki.NewSelfSignedCA(&pki.CertificateRequest{
+		CommonName:   "test-public-ca",
+		IsCA:         true,
+		Duration:     24 * time.Hour,
+		KeyAlgorithm: pki.ECDSAKeyAlgorithm,
+		KeySize:      256,
+	})
+	if err != nil {
+		panic(err)
+	}
+	cert, err := ca.Issue(&pki.CertificateRequest{
+		CommonName:   host,
+		DNSNames:     []string{host},
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		Duration:     24 * time.Hour,
+		KeyAlgorithm: pki.ECDSAKeyAlgorithm,
+		KeySize:      256,
+	})
+	if err != nil {
+		panic(err)
+	}
+	keyPEM, err = cert.PrivateKeyPEM()
+	if err != nil {
+		panic(err)
+	}
+	return ca.CertificatePEM(), cert.CertificatePEM(), keyPEM
+}
+
+func (r *TestResources) newExternalTLSSecretName() string {
+	return r.Name + "-external-tls"
+}
+
+func (r *TestResources) NewExternalTLSSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.newExternalTLSSecretName(),
+			Namespace: r.Namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       testExternalCert,
+			corev1.TLSPrivateKeyKey: testExternalKey,
+			"ca.crt":                testExternalCACert,
+		},
+	}
+}
+
+func (r *TestResources) NewExternalTLSSecretOtherHost() *corev1.Secret {
+	secret := r.NewExternalTLSSecret()
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       testOtherExternalCert,
+		corev1.TLSPrivateKeyKey: testOtherExternalKey,
+	}
+	return secret
+}
+
+func (r *TestResources) newIssuerRef() certMeta.ObjectReference {
+	if r.IssuerRef == nil {
+		return certMeta.ObjectReference{
+			Name: r.Name + "-ca",
+		}
+	}
+	kind := r.IssuerRef.Kind
+	if len(kind) == 0 {
+		kind = "Issuer"
+	}
+	return certMeta.ObjectReference{
+		Name:  r.IssuerRef.Name,
+		Kind:  kind,
+		Group: "cert-manager.io",
+	}
+}
+
 func (r *TestResources) NewAgentCertSecret(ns string) *corev1.Secret {
 	name := r.GetClusterUniqueNameForAgent(ns)
 	return &corev1.Secret{
@@ -1852,6 +2422,7 @@ func (r *TestResources) NewAgentCertSecretCopy(ns string) *corev1.Secret {
 		"operator.cryostat.io/namespace": r.Namespace,
 	}
 	secret.Namespace = ns
+	secret.Data["ca.crt"] = r.getCABytes()
 	return secret
 }
 
@@ -1882,6 +2453,32 @@ func (r *TestResources) NewCustomDatabaseSecret() *corev1.Secret {
 	}
 }
 
+func (r *TestResources) NewExternalDatabaseCredentialsSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "external-database-credentials",
+			Namespace: r.Namespace,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			"username": []byte("cryostat-user"),
+			"password": []byte("cryostat-password"),
+		},
+	}
+}
+
+func (r *TestResources) NewExternalDatabaseCAConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "external-database-ca",
+			Namespace: r.Namespace,
+		},
+		Data: map[string]string{
+			"service-ca.crt": "external-database-ca",
+		},
+	}
+}
+
 func (r *TestResources) NewExternalStorageSecret(name string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1971,7 +2568,7 @@ func (r *TestResources) NewTestCertConfigMap(name string) *corev1.ConfigMap {
 }
 
 func (r *TestResources) NewCryostatCert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name,
 			Namespace: r.Namespace,
@@ -1996,9 +2593,7 @@ func (r *TestResources) NewCryostatCert() *certv1.Certificate {
 					Profile: certv1.Modern2023PKCS12Profile,
 				},
 			},
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
-			},
+			IssuerRef: r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
@@ -2006,7 +2601,7 @@ func (r *TestResources) NewCryostatCert() *certv1.Certificate {
 				certv1.UsageClientAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) NewCryostatKeystorePassSecret() *corev1.Secret {
@@ -2029,7 +2624,7 @@ func (r *TestResources) OtherCryostatCert() *certv1.Certificate {
 }
 
 func (r *TestResources) NewReportsCert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name + "-reports",
 			Namespace: r.Namespace,
@@ -2042,20 +2637,18 @@ func (r *TestResources) NewReportsCert() *certv1.Certificate {
 				fmt.Sprintf(r.Name+"-reports.%s.svc.cluster.local", r.Namespace),
 			},
 			SecretName: r.Name + "-reports-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
-			},
+			IssuerRef:  r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
 				certv1.UsageServerAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) NewDatabaseCert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name + "-database",
 			Namespace: r.Namespace,
@@ -2068,16 +2661,43 @@ func (r *TestResources) NewDatabaseCert() *certv1.Certificate {
 				fmt.Sprintf(r.Name+"-database.%s.svc.cluster.local", r.Namespace),
 			},
 			SecretName: r.Name + "-database-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
+			IssuerRef:  r.newIssuerRef(),
+			Usages: []certv1.KeyUsage{
+				certv1.UsageDigitalSignature,
+				certv1.UsageKeyEncipherment,
+				certv1.UsageServerAuth,
 			},
+		},
+	}, r.CertificateOptions)
+}
+
+func (r *TestResources) NewGrafanaCert() *certv1.Certificate {
+	return r.newSidecarCert(r.Name+"-grafana", "cryostat-grafana")
+}
+
+func (r *TestResources) NewDatasourceCert() *certv1.Certificate {
+	return r.newSidecarCert(r.Name+"-jfr-datasource", "cryostat-jfr-datasource")
+}
+
+func (r *TestResources) newSidecarCert(name string, commonName string) *certv1.Certificate {
+	return withCertificateOptions(&certv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.Namespace,
+		},
+		Spec: certv1.CertificateSpec{
+			CommonName:  commonName,
+			DNSNames:    []string{"localhost"},
+			IPAddresses: []string{"127.0.0.1"},
+			SecretName:  name + "-tls",
+			IssuerRef:   r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
 				certv1.UsageServerAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) OtherReportsCert() *certv1.Certificate {
@@ -2087,7 +2707,7 @@ func (r *TestResources) OtherReportsCert() *certv1.Certificate {
 }
 
 func (r *TestResources) NewAgentProxyCert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name + "-agent-proxy",
 			Namespace: r.Namespace,
@@ -2100,20 +2720,18 @@ func (r *TestResources) NewAgentProxyCert() *certv1.Certificate {
 				fmt.Sprintf(r.Name+"-agent.%s.svc.cluster.local", r.Namespace),
 			},
 			SecretName: r.Name + "-agent-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
-			},
+			IssuerRef:  r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
 				certv1.UsageServerAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) NewStorageCert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name + "-storage",
 			Namespace: r.Namespace,
@@ -2126,9 +2744,7 @@ func (r *TestResources) NewStorageCert() *certv1.Certificate {
 				fmt.Sprintf(r.Name+"-storage.%s.svc.cluster.local", r.Namespace),
 			},
 			SecretName: r.Name + "-storage-tls",
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
-			},
+			IssuerRef:  r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
@@ -2136,7 +2752,7 @@ func (r *TestResources) NewStorageCert() *certv1.Certificate {
 				certv1.UsageClientAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) OtherAgentProxyCert() *certv1.Certificate {
@@ -2145,8 +2761,38 @@ func (r *TestResources) OtherAgentProxyCert() *certv1.Certificate {
 	return cert
 }
 
+func withCertificateOptions(cert *certv1.Certificate, options *operatorv1beta2.CertificateOptions) *certv1.Certificate {
+	if options == nil {
+		return cert
+	}
+	cert.Spec.Duration = options.Duration
+	cert.Spec.RenewBefore = options.RenewBefore
+	if options.PrivateKey != nil {
+		cert.Spec.PrivateKey = &certv1.CertificatePrivateKey{
+			Algorithm:      certv1.PrivateKeyAlgorithm(options.PrivateKey.Algorithm),
+			Size:           options.PrivateKey.Size,
+			RotationPolicy: certv1.PrivateKeyRotationPolicy(options.PrivateKey.RotationPolicy),
+		}
+	}
+	return cert
+}
+
+func (r *TestResources) NewCACertNotReady(since time.Time) *certv1.Certificate {
+	cert := r.NewCACert()
+	cert.Status.Conditions = []certv1.CertificateCondition{
+		{
+			Type:               certv1.CertificateConditionReady,
+			Status:             certMeta.ConditionFalse,
+			Reason:             "DoesNotExist",
+			Message:            "Issuing certificate as Secret does not exist",
+			LastTransitionTime: &metav1.Time{Time: since},
+		},
+	}
+	return cert
+}
+
 func (r *TestResources) NewCACert() *certv1.Certificate {
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      r.Name + "-ca",
 			Namespace: r.Namespace,
@@ -2159,7 +2805,7 @@ func (r *TestResources) NewCACert() *certv1.Certificate {
 			},
 			IsCA: true,
 		},
-	}
+	}, r.CACertificateOptions)
 }
 
 func (r *TestResources) OtherCACert() *certv1.Certificate {
@@ -2171,7 +2817,7 @@ func (r *TestResources) OtherCACert() *certv1.Certificate {
 
 func (r *TestResources) NewAgentCert(namespace string) *certv1.Certificate {
 	name := r.GetClusterUniqueNameForAgent(namespace)
-	return &certv1.Certificate{
+	return withCertificateOptions(&certv1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: r.Namespace,
@@ -2182,9 +2828,7 @@ func (r *TestResources) NewAgentCert(namespace string) *certv1.Certificate {
 				fmt.Sprintf("*.%s.%s.svc", r.GetAgentServiceName(), namespace),
 			},
 			SecretName: name,
-			IssuerRef: certMeta.ObjectReference{
-				Name: r.Name + "-ca",
-			},
+			IssuerRef:  r.newIssuerRef(),
 			Usages: []certv1.KeyUsage{
 				certv1.UsageDigitalSignature,
 				certv1.UsageKeyEncipherment,
@@ -2192,12 +2836,12 @@ func (r *TestResources) NewAgentCert(namespace string) *certv1.Certificate {
 				certv1.UsageClientAuth,
 			},
 		},
-	}
+	}, r.CertificateOptions)
 }
 
 func (r *TestResources) NewCertSecret(cert *certv1.Certificate) *corev1.Secret {
 	// The secret's data isn't important, we simply need it to exist
-	return &corev1.Secret{
+	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cert.Spec.SecretName,
 			Namespace: cert.Namespace,
@@ -2207,6 +2851,14 @@ func (r *TestResources) NewCertSecret(cert *certv1.Certificate) *corev1.Secret {
 			corev1.TLSPrivateKeyKey: []byte(cert.Name + "-key"),
 		},
 	}
+	if cert.Spec.IsCA {
+		secret.Data[corev1.TLSCertKey] = r.getCABytes()
+	}
+	// A user-provided issuer includes its CA with each certificate
+	if r.IssuerRef != nil {
+		secret.Data["ca.crt"] = r.getCABytes()
+	}
+	return secret
 }
 
 func (r *TestResources) NewSelfSignedIssuer() *certv1.Issuer {
@@ -2268,6 +2920,32 @@ func (r *TestResources) newPVC(spec *corev1.PersistentVolumeClaimSpec, labels ma
 	}
 }
 
+func (r *TestResources) NewStorageClass(name string, allowExpansion bool) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Provisioner:          "example.com/csi",
+		AllowVolumeExpansion: &allowExpansion,
+	}
+}
+
+func (r *TestResources) NewDefaultStorageClass(name string, allowExpansion bool) *storagev1.StorageClass {
+	storageClass := r.NewStorageClass(name, allowExpansion)
+	storageClass.Annotations = map[string]string{
+		"storageclass.kubernetes.io/is-default-class": "true",
+	}
+	return storageClass
+}
+
+func (r *TestResources) NewVolumeMigrationPodLabels(component string) map[string]string {
+	return map[string]string{
+		"app":       r.Name,
+		"kind":      "cryostat",
+		"component": component + "-migration",
+	}
+}
+
 func (r *TestResources) NewDefaultPVC() *corev1.PersistentVolumeClaim {
 	return r.newPVC(&corev1.PersistentVolumeClaimSpec{
 		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
@@ -2601,7 +3279,9 @@ func (r *TestResources) NewMainPodAnnotations() map[string]string {
 		r.NewStorageSecret(),
 		r.NewAuthProxyCookieSecret(),
 	}
-	if r.DatabaseSecret != nil {
+	if r.ExternalDatabase {
+		secrets = append(secrets, r.NewExternalDatabaseCredentialsSecret())
+	} else if r.DatabaseSecret != nil {
 		secrets = append(secrets, r.DatabaseSecret)
 	} else {
 		secrets = append(secrets, r.NewDatabaseSecret())
@@ -2610,10 +3290,19 @@ func (r *TestResources) NewMainPodAnnotations() map[string]string {
 		secrets = append(secrets,
 			r.NewCertSecret(r.NewCryostatCert()),
 			r.NewCryostatKeystorePassSecret(),
-			r.NewCertSecret(r.NewDatabaseCert()),
 			r.NewCertSecret(r.NewStorageCert()),
 			r.NewCertSecret(r.NewAgentProxyCert()),
+			r.NewCABundleSecret(),
 		)
+		if !r.ExternalDatabase {
+			secrets = append(secrets, r.NewCertSecret(r.NewDatabaseCert()))
+		}
+		if r.SidecarTLS {
+			secrets = append(secrets,
+				r.NewCertSecret(r.NewGrafanaCert()),
+				r.NewCertSecret(r.NewDatasourceCert()),
+			)
+		}
 	}
 
 	configMaps := []*corev1.ConfigMap{
@@ -2623,6 +3312,9 @@ func (r *TestResources) NewMainPodAnnotations() map[string]string {
 	if !r.OpenShift {
 		configMaps = append(configMaps, r.NewOAuth2ProxyConfigMap())
 	}
+	if r.ExternalDatabase {
+		configMaps = append(configMaps, r.NewExternalDatabaseCAConfigMap())
+	}
 
 	hashAnnotations(secrets, configMaps, annotations)
 	return annotations
@@ -2760,10 +3452,6 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 			Name:  "QUARKUS_HIBERNATE_ORM_SQL_LOAD_SCRIPT",
 			Value: "no-file",
 		},
-		{
-			Name:  "QUARKUS_DATASOURCE_USERNAME",
-			Value: "cryostat",
-		},
 		{
 			Name:  "QUARKUS_S3_ENDPOINT_OVERRIDE",
 			Value: fmt.Sprintf("%s://%s-storage.%s.svc.cluster.local:%d", storageProtocol, r.Name, r.Namespace, storagePort),
@@ -2818,7 +3506,7 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 		},
 		{
 			Name:  "GRAFANA_DATASOURCE_URL",
-			Value: "http://127.0.0.1:8989",
+			Value: r.getDatasourceURL(),
 		},
 		{
 			Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_ACCESS_KEY_ID",
@@ -2846,11 +3534,13 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 		},
 	}...)
 	if r.TLS {
-		envs = append(envs,
-			corev1.EnvVar{
+		if !r.ExternalDatabase {
+			envs = append(envs, corev1.EnvVar{
 				Name:  "QUARKUS_DATASOURCE_JDBC_URL",
 				Value: fmt.Sprintf("jdbc:postgresql://%s-database.%s.svc.cluster.local:5432/cryostat?ssl=true&sslmode=verify-full&sslcert=&sslrootcert=/var/run/secrets/operator.cryostat.io/%s-database-tls/ca.crt", r.Name, r.Namespace, r.Name),
-			},
+			})
+		}
+		envs = append(envs,
 			corev1.EnvVar{
 				Name:  "SSL_KEYSTORE",
 				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/client-tls/%s-tls/keystore.p12", r.Name),
@@ -2859,9 +3549,17 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 				Name:  "SSL_KEYSTORE_PASS_FILE",
 				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/client-tls/%s-keystore/keystore.pass", r.Name),
 			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_PROTOCOLS",
+				Value: r.getJavaTLSProtocols(),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_CIPHER_SUITES",
+				Value: r.GetJavaTLSCiphers(),
+			},
 		)
 
-	} else {
+	} else if !r.ExternalDatabase {
 		envs = append(envs, corev1.EnvVar{
 			Name:  "QUARKUS_DATASOURCE_JDBC_URL",
 			Value: fmt.Sprintf("jdbc:postgresql://%s-database.%s.svc.cluster.local:5432/cryostat", r.Name, r.Namespace),
@@ -2870,25 +3568,9 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 
 	envs = append(envs, r.NewTargetDiscoveryEnvVars(hasPortConfig, builtInDiscoveryDisabled, builtInPortConfigDisabled)...)
 
-	secretName := r.NewDatabaseSecret().Name
-	if dbSecretProvided {
-		secretName = providedDatabaseSecretName
-	}
-	envs = append(envs, corev1.EnvVar{
-		Name: "QUARKUS_DATASOURCE_PASSWORD",
-		ValueFrom: &corev1.EnvVarSource{
-			SecretKeyRef: &corev1.SecretKeySelector{
-				LocalObjectReference: corev1.LocalObjectReference{
-					Name: secretName,
-				},
-				Key:      "CONNECTION_KEY",
-				Optional: &optional,
-			},
-		},
-	},
-	)
+	envs = append(envs, r.newDatabaseEnvironmentVariables(dbSecretProvided)...)
 
-	secretName = r.NewStorageSecret().Name
+	secretName := r.NewStorageSecret().Name
 	envs = append(envs, corev1.EnvVar{
 		Name: "QUARKUS_S3_AWS_CREDENTIALS_STATIC_PROVIDER_SECRET_ACCESS_KEY",
 		ValueFrom: &corev1.EnvVarSource{
@@ -2941,11 +3623,71 @@ func (r *TestResources) NewCoreEnvironmentVariables(reportsUrl string, ingress b
 	return envs
 }
 
+func (r *TestResources) newDatabaseEnvironmentVariables(dbSecretProvided bool) []corev1.EnvVar {
+	optional := false
+	if r.ExternalDatabase {
+		secretName := r.NewExternalDatabaseCredentialsSecret().Name
+		return []corev1.EnvVar{
+			{
+				Name: "QUARKUS_DATASOURCE_USERNAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secretName,
+						},
+						Key:      "username",
+						Optional: &optional,
+					},
+				},
+			},
+			{
+				Name: "QUARKUS_DATASOURCE_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secretName,
+						},
+						Key:      "password",
+						Optional: &optional,
+					},
+				},
+			},
+			{
+				Name:  "QUARKUS_DATASOURCE_JDBC_URL",
+				Value: "jdbc:postgresql://postgres.example.com:5433/cryostat-db?sslmode=verify-full&sslrootcert=%2Fvar%2Frun%2Fsecrets%2Foperator.cryostat.io%2Fexternal-database-ca%2Fca.crt",
+			},
+		}
+	}
+
+	secretName := r.NewDatabaseSecret().Name
+	if dbSecretProvided {
+		secretName = providedDatabaseSecretName
+	}
+	return []corev1.EnvVar{
+		{
+			Name:  "QUARKUS_DATASOURCE_USERNAME",
+			Value: "cryostat",
+		},
+		{
+			Name: "QUARKUS_DATASOURCE_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretName,
+					},
+					Key:      "CONNECTION_KEY",
+					Optional: &optional,
+				},
+			},
+		},
+	}
+}
+
 func (r *TestResources) newNetworkEnvironmentVariables() []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{
 			Name:  "GRAFANA_DASHBOARD_URL",
-			Value: "http://localhost:3000",
+			Value: r.getSidecarScheme() + "://localhost:3000",
 		},
 		{
 			Name:  "GRAFANA_DASHBOARD_EXT_URL",
@@ -2959,7 +3701,7 @@ func (r *TestResources) NewGrafanaEnvironmentVariables() []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{
 			Name:  "JFR_DATASOURCE_URL",
-			Value: "http://127.0.0.1:8989",
+			Value: r.getDatasourceURL(),
 		},
 		{
 			Name:      "GF_AUTH_ANONYMOUS_ENABLED",
@@ -2981,19 +3723,126 @@ func (r *TestResources) NewGrafanaEnvironmentVariables() []corev1.EnvVar {
 			Value: "http://localhost:4180/grafana/",
 		},
 	}
+	if r.useSidecarTLS() {
+		envs = append(envs,
+			corev1.EnvVar{
+				Name:  "GF_SERVER_PROTOCOL",
+				Value: "https",
+			},
+			corev1.EnvVar{
+				Name:  "GF_SERVER_CERT_FILE",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-grafana-tls/tls.crt", r.Name),
+			},
+			corev1.EnvVar{
+				Name:  "GF_SERVER_CERT_KEY",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-grafana-tls/tls.key", r.Name),
+			},
+			corev1.EnvVar{
+				Name:  "SSL_CERT_DIR",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-ca-bundle", r.Name),
+			},
+		)
+		minVersion := "TLS1.2"
+		if r.ModernTLSProfile {
+			minVersion = "TLS1.3"
+		}
+		envs = append(envs, corev1.EnvVar{
+			Name:  "GF_SERVER_MIN_TLS_VERSION",
+			Value: minVersion,
+		})
+	}
 	return envs
 }
 
+func (r *TestResources) NewGrafanaVolumeMounts() []corev1.VolumeMount {
+	mounts := []corev1.VolumeMount{}
+	if r.useSidecarTLS() {
+		mounts = append(mounts,
+			corev1.VolumeMount{
+				Name:      "grafana-tls-secret",
+				MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-grafana-tls", r.Name),
+				ReadOnly:  true,
+			},
+			r.newCABundleVolumeMount(),
+		)
+	}
+	return mounts
+}
+
+func (r *TestResources) newCABundleVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      "ca-bundle",
+		MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-ca-bundle", r.Name),
+		ReadOnly:  true,
+	}
+}
+
+func (r *TestResources) useSidecarTLS() bool {
+	return r.TLS && r.SidecarTLS
+}
+
+func (r *TestResources) getSidecarScheme() string {
+	if r.useSidecarTLS() {
+		return "https"
+	}
+	return "http"
+}
+
+func (r *TestResources) getDatasourceURL() string {
+	return r.getSidecarScheme() + "://127.0.0.1:8989"
+}
+
 func (r *TestResources) NewDatasourceEnvironmentVariables() []corev1.EnvVar {
 	envs := []corev1.EnvVar{
 		{
 			Name:  "QUARKUS_HTTP_HOST",
 			Value: "127.0.0.1",
 		},
-		{
+	}
+	if r.useSidecarTLS() {
+		envs = append(envs,
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_SSL_PORT",
+				Value: "8989",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_INSECURE_REQUESTS",
+				Value: "disabled",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_RELOAD_PERIOD",
+				Value: "1h",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_KEY_STORE_PEM_0_CERT",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-jfr-datasource-tls/tls.crt", r.Name),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_KEY_STORE_PEM_0_KEY",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-jfr-datasource-tls/tls.key", r.Name),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_HTTP_SSL_CLIENT_AUTH",
+				Value: "request",
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_TRUST_STORE_PEM_CERTS",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-ca-bundle/ca.crt", r.Name),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_PROTOCOLS",
+				Value: r.getJavaTLSProtocols(),
+			},
+			corev1.EnvVar{
+				Name:  "QUARKUS_TLS_CIPHER_SUITES",
+				Value: r.GetJavaTLSCiphers(),
+			},
+		)
+	} else {
+		envs = append(envs, corev1.EnvVar{
 			Name:  "QUARKUS_HTTP_PORT",
 			Value: "8989",
-		},
+		})
 	}
 	if r.TLS {
 		envs = append(envs,
@@ -3019,7 +3868,8 @@ func (r *TestResources) NewReportsEnvironmentVariables(resources *corev1.Resourc
 	}
 	opts := fmt.Sprintf("-XX:+PrintCommandLineFlags -XX:ActiveProcessorCount=%d -Dorg.openjdk.jmc.flightrecorder.parser.singlethreaded=%t", cpus, cpus < 2)
 	if r.TLS {
-		opts += " -Dquarkus.http.tls-configuration-name=https -Dquarkus.tls.https.reload-period=1h -Dquarkus.tls.https.key-store.pem.0.cert=/var/run/secrets/operator.cryostat.io/cryostat-reports-tls/tls.crt -Dquarkus.tls.https.key-store.pem.0.key=/var/run/secrets/operator.cryostat.io/cryostat-reports-tls/tls.key"
+		opts += " -Dquarkus.http.tls-configuration-name=https -Dquarkus.tls.https.reload-period=1h -Dquarkus.tls.https.key-store.pem.0.cert=/var/run/secrets/operator.cryostat.io/cryostat-reports-tls/tls.crt -Dquarkus.tls.https.key-store.pem.0.key=/var/run/secrets/operator.cryostat.io/cryostat-reports-tls/tls.key" +
+			" -Dquarkus.tls.https.protocols=" + r.getJavaTLSProtocols() + " -Dquarkus.tls.https.cipher-suites=" + r.GetJavaTLSCiphers()
 	}
 	envs := []corev1.EnvVar{
 		{
@@ -3090,6 +3940,25 @@ func (r *TestResources) NewStorageEnvironmentVariables() []corev1.EnvVar {
 			},
 		},
 	}
+	if r.TLS {
+		if r.ModernTLSProfile {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "WEED_TLS_MIN_VERSION",
+				Value: "TLS 1.3",
+			})
+		} else {
+			envs = append(envs,
+				corev1.EnvVar{
+					Name:  "WEED_TLS_MIN_VERSION",
+					Value: "TLS 1.2",
+				},
+				corev1.EnvVar{
+					Name:  "WEED_TLS_CIPHER_SUITES",
+					Value: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+				},
+			)
+		}
+	}
 	return envs
 }
 
@@ -3167,6 +4036,16 @@ func (r *TestResources) NewDatabaseArgs() []string {
 			"-c",
 			fmt.Sprintf("ssl_key_file=/var/run/secrets/operator.cryostat.io/%s-database-tls/%s", r.Name, corev1.TLSPrivateKeyKey),
 		)
+		if r.ModernTLSProfile {
+			args = append(args, "-c", "ssl_min_protocol_version=TLSv1.3")
+		} else {
+			args = append(args,
+				"-c",
+				"ssl_min_protocol_version=TLSv1.2",
+				"-c",
+				"ssl_ciphers="+openSSLIntermediateCiphers,
+			)
+		}
 	}
 
 	return args
@@ -3211,6 +4090,13 @@ func (r *TestResources) NewAuthProxyEnvironmentVariables(authOptions *operatorv1
 					Value: ".*",
 				})
 		}
+
+		if r.useSidecarTLS() {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "SSL_CERT_DIR",
+				Value: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-ca-bundle", r.Name),
+			})
+		}
 	}
 
 	return envs
@@ -3366,7 +4252,7 @@ func (r *TestResources) NewAuthProxyArguments(authOptions *operatorv1beta2.Autho
 		"--pass-user-bearer-token=false",
 		"--pass-basic-auth=false",
 		"--upstream=http://localhost:8181/",
-		"--upstream=http://localhost:3000/grafana/",
+		fmt.Sprintf("--upstream=%s://localhost:3000/grafana/", r.getSidecarScheme()),
 		// "--upstream=http://localhost:8333/storage/",
 		fmt.Sprintf("--openshift-service-account=%s", r.Name),
 		"--proxy-websockets=true",
@@ -3399,6 +4285,10 @@ func (r *TestResources) NewAuthProxyArguments(authOptions *operatorv1beta2.Autho
 			"--https-address=",
 		)
 	}
+
+	if r.useSidecarTLS() {
+		args = append(args, fmt.Sprintf("--upstream-ca=/var/run/secrets/operator.cryostat.io/%s-ca-bundle/ca.crt", r.Name))
+	}
 	return args, nil
 }
 
@@ -3416,6 +4306,19 @@ func (r *TestResources) NewCoreVolumeMounts() []corev1.VolumeMount {
 			MountPath: "/truststore/operator",
 		},
 	}
+	if r.ExternalDatabase {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "external-database-ca",
+			MountPath: "/var/run/secrets/operator.cryostat.io/external-database-ca",
+			ReadOnly:  true,
+		})
+	} else if r.TLS {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "database-tls-secret",
+			ReadOnly:  true,
+			MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-database-tls", r.Name),
+		})
+	}
 	if r.TLS {
 		mounts = append(mounts,
 			corev1.VolumeMount{
@@ -3423,11 +4326,6 @@ func (r *TestResources) NewCoreVolumeMounts() []corev1.VolumeMount {
 				MountPath: "/truststore/storage",
 				ReadOnly:  true,
 			},
-			corev1.VolumeMount{
-				Name:      "database-tls-secret",
-				ReadOnly:  true,
-				MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-database-tls", r.Name),
-			},
 			corev1.VolumeMount{
 				Name:      "keystore",
 				MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/client-tls/%s-tls", r.Name),
@@ -3445,6 +4343,16 @@ func (r *TestResources) NewCoreVolumeMounts() []corev1.VolumeMount {
 
 func (r *TestResources) NewDatasourceVolumeMounts() []corev1.VolumeMount {
 	mounts := []corev1.VolumeMount{}
+	if r.useSidecarTLS() {
+		mounts = append(mounts,
+			corev1.VolumeMount{
+				Name:      "jfr-datasource-tls-secret",
+				MountPath: fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-jfr-datasource-tls", r.Name),
+				ReadOnly:  true,
+			},
+			r.newCABundleVolumeMount(),
+		)
+	}
 	if r.TLS {
 		mounts = append(mounts,
 			corev1.VolumeMount{
@@ -3504,6 +4412,10 @@ func (r *TestResources) NewAuthProxyVolumeMounts(authOptions *operatorv1beta2.Au
 		})
 	}
 
+	if r.useSidecarTLS() {
+		mounts = append(mounts, r.newCABundleVolumeMount())
+	}
+
 	basicAuthConfigured := authOptions != nil && authOptions.BasicAuth != nil &&
 		authOptions.BasicAuth.Filename != nil && authOptions.BasicAuth.SecretName != nil
 	if basicAuthConfigured {
@@ -3667,12 +4579,16 @@ func (r *TestResources) newCoreProbeHandler() corev1.ProbeHandler {
 }
 
 func (r *TestResources) NewGrafanaLivenessProbe() *corev1.Probe {
+	scheme := corev1.URISchemeHTTP
+	if r.useSidecarTLS() {
+		scheme = corev1.URISchemeHTTPS
+	}
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
 				Port:   intstr.IntOrString{IntVal: 3000},
 				Path:   "/api/health",
-				Scheme: corev1.URISchemeHTTP,
+				Scheme: scheme,
 			},
 		},
 	}
@@ -3682,12 +4598,20 @@ func (r *TestResources) NewDatasourceLivenessProbe() *corev1.Probe {
 	return &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			Exec: &corev1.ExecAction{
-				Command: []string{"curl", "--fail", "http://127.0.0.1:8989"},
+				Command: r.newDatasourceLivenessCommand(),
 			},
 		},
 	}
 }
 
+func (r *TestResources) newDatasourceLivenessCommand() []string {
+	if r.useSidecarTLS() {
+		return []string{"curl", "--fail", "--cacert",
+			fmt.Sprintf("/var/run/secrets/operator.cryostat.io/%s-jfr-datasource-tls/ca.crt", r.Name), r.getDatasourceURL()}
+	}
+	return []string{"curl", "--fail", r.getDatasourceURL()}
+}
+
 func (r *TestResources) NewStorageLivenessProbe() *corev1.Probe {
 	protocol := corev1.URISchemeHTTP
 	port := int32(8333)
@@ -4108,7 +5032,7 @@ func (r *TestResources) newVolumes(certProjections []corev1.VolumeProjection) []
 		projs = append(projs, corev1.VolumeProjection{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: r.Name + "-tls",
+					Name: r.Name + "-ca-bundle",
 				},
 				Items: []corev1.KeyToPath{
 					{
@@ -4169,7 +5093,10 @@ func (r *TestResources) newVolumes(certProjections []corev1.VolumeProjection) []
 					},
 				},
 			},
-			corev1.Volume{
+		)
+
+		if !r.ExternalDatabase {
+			volumes = append(volumes, corev1.Volume{
 				Name: "database-tls-secret",
 				VolumeSource: corev1.VolumeSource{
 					Secret: &corev1.SecretVolumeSource{
@@ -4189,8 +5116,40 @@ func (r *TestResources) newVolumes(certProjections []corev1.VolumeProjection) []
 						},
 					},
 				},
-			},
-		)
+			})
+		}
+
+		if r.SidecarTLS {
+			volumes = append(volumes,
+				corev1.Volume{
+					Name: "grafana-tls-secret",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  r.Name + "-grafana-tls",
+							DefaultMode: &readOnlymode,
+						},
+					},
+				},
+				corev1.Volume{
+					Name: "jfr-datasource-tls-secret",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  r.Name + "-jfr-datasource-tls",
+							DefaultMode: &readOnlymode,
+						},
+					},
+				},
+				corev1.Volume{
+					Name: "ca-bundle",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  r.Name + "-ca-bundle",
+							DefaultMode: &readOnlymode,
+						},
+					},
+				},
+			)
+		}
 
 		volumes = append(volumes,
 			corev1.Volume{
@@ -4248,6 +5207,32 @@ func (r *TestResources) newVolumes(certProjections []corev1.VolumeProjection) []
 		})
 	}
 
+	if r.ExternalDatabase {
+		volumes = append(volumes, corev1.Volume{
+			Name: "external-database-ca",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ConfigMap: &corev1.ConfigMapProjection{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: r.NewExternalDatabaseCAConfigMap().Name,
+								},
+								Items: []corev1.KeyToPath{
+									{
+										Key:  "service-ca.crt",
+										Path: "ca.crt",
+										Mode: &readOnlymode,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
 	return volumes
 }
 
@@ -4463,6 +5448,51 @@ func (r *TestResources) NewCustomHostCoreRoute() *routev1.Route {
 	return route
 }
 
+func (r *TestResources) NewExternalTLSCoreRoute() *routev1.Route {
+	route := r.NewCustomHostCoreRoute()
+	route.Spec.TLS.ExternalCertificate = &routev1.LocalObjectReference{Name: r.newExternalTLSSecretName()}
+	route.Spec.TLS.CACertificate = string(testExternalCACert)
+	return route
+}
+
+func (r *TestResources) NewRouterSecretRole() *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name + "-router-tls",
+			Namespace: r.Namespace,
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{r.newExternalTLSSecretName()},
+				Verbs:         []string{"get", "list", "watch"},
+			},
+		},
+	}
+}
+
+func (r *TestResources) NewRouterSecretRoleBinding() *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.Name + "-router-tls",
+			Namespace: r.Namespace,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "router",
+				Namespace: "openshift-ingress",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     r.Name + "-router-tls",
+		},
+	}
+}
+
 func (r *TestResources) newRoute(name string, port int) *routev1.Route {
 	var routeTLS *routev1.TLSConfig
 	if !r.TLS {
@@ -4473,7 +5503,7 @@ func (r *TestResources) newRoute(name string, port int) *routev1.Route {
 	} else {
 		routeTLS = &routev1.TLSConfig{
 			Termination:                   routev1.TLSTerminationReencrypt,
-			DestinationCACertificate:      r.Name + "-ca-bytes",
+			DestinationCACertificate:      string(r.getCABytes()),
 			InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
 		}
 	}
@@ -4530,6 +5560,63 @@ func (r *TestResources) NewCoreIngress() *netv1.Ingress {
 		map[string]string{"my": customLabelValue, "custom": customLabelValue})
 }
 
+func (r *TestResources) NewExternalTLSCoreIngress() *netv1.Ingress {
+	ingress := r.NewCoreIngress()
+	ingress.Spec.TLS = append(ingress.Spec.TLS, netv1.IngressTLS{
+		Hosts:      []string{r.Name + ".example.com"},
+		SecretName: r.newExternalTLSSecretName(),
+	})
+	return ingress
+}
+
+func (r *TestResources) NewCryostatWithIngressExternalTLSForOtherHosts() *model.CryostatInstance {
+	cr := r.NewCryostatWithIngressExternalTLS()
+	cr.Spec.NetworkOptions.CoreConfig.IngressSpec.TLS = []netv1.IngressTLS{
+		{
+			Hosts:      []string{"other.example.com"},
+			SecretName: "other-tls",
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewExternalTLSCoreIngressForOtherHosts() *netv1.Ingress {
+	ingress := r.NewCoreIngress()
+	ingress.Spec.TLS = []netv1.IngressTLS{
+		{
+			Hosts:      []string{"other.example.com"},
+			SecretName: "other-tls",
+		},
+		{
+			Hosts:      []string{r.Name + ".example.com"},
+			SecretName: r.newExternalTLSSecretName(),
+		},
+	}
+	return ingress
+}
+
+func (r *TestResources) NewCryostatWithIngressTLSForHost() *model.CryostatInstance {
+	cr := r.NewCryostatWithIngressExternalTLS()
+	cr.Spec.NetworkOptions.CoreConfig.IngressSpec.TLS = []netv1.IngressTLS{
+		{
+			Hosts:      []string{r.Name + ".example.com"},
+			SecretName: "user-tls",
+		},
+	}
+	return cr
+}
+
+func (r *TestResources) NewCoreIngressWithTLSForHost() *netv1.Ingress {
+	ingress := r.NewCoreIngress()
+	ingress.Spec.TLS = []netv1.IngressTLS{
+		{
+			Hosts:      []string{r.Name + ".example.com"},
+			SecretName: "user-tls",
+		},
+	}
+	return ingress
+}
+
 func (r *TestResources) newIngress(name string, svcPort int32, annotations, labels map[string]string) *netv1.Ingress {
 	pathtype := netv1.PathTypePrefix
 
@@ -4989,6 +6076,86 @@ func (r *TestResources) NewNamespace() *corev1.Namespace {
 	}
 }
 
+func (r *TestResources) NewAgentPod(namespace string, injected bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-agent",
+			Namespace: namespace,
+			Labels: map[string]string{
+				"cryostat.io/name":      r.Name,
+				"cryostat.io/namespace": r.Namespace,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "test",
+					Image: "example.com/test:latest",
+				},
+			},
+		},
+	}
+	if injected {
+		pod.Spec.InitContainers = []corev1.Container{
+			{
+				Name:  "cryostat-agent-init",
+				Image: "quay.io/cryostat/cryostat-agent-init:latest",
+			},
+		}
+	}
+	return pod
+}
+
+func (r *TestResources) NewAgentDeployment(namespace string, name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app":                   name,
+						"cryostat.io/name":      r.Name,
+						"cryostat.io/namespace": r.Namespace,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "test",
+							Image: "example.com/test:latest",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TestResources) NewAgentDeploymentPod(deployment *appsv1.Deployment) *corev1.Pod {
+	pod := r.NewAgentPod(deployment.Namespace, true)
+	pod.Name = deployment.Name + "-5d8f7b9c4-x2k7q"
+	pod.Labels["app"] = deployment.Name
+	pod.Labels["pod-template-hash"] = "5d8f7b9c4"
+	pod.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+			Name:       deployment.Name + "-5d8f7b9c4",
+			UID:        "a1b2c3d4-5678-90ab-cdef-1234567890ab",
+			Controller: &[]bool{true}[0],
+		},
+	}
+	return pod
+}
+
 func (r *TestResources) NewOtherNamespace(name string) *corev1.Namespace {
 	return &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -5058,6 +6225,15 @@ func (r *TestResources) NewApiServer() *configv1.APIServer {
 	}
 }
 
+func (r *TestResources) NewApiServerWithTLSSecurityProfile() *configv1.APIServer {
+	apiServer := r.NewApiServer()
+	apiServer.Spec.TLSSecurityProfile = &configv1.TLSSecurityProfile{
+		Type:   configv1.TLSProfileModernType,
+		Modern: &configv1.ModernTLSProfile{},
+	}
+	return apiServer
+}
+
 func (r *TestResources) NewApiServerWithApplicationURL() *configv1.APIServer {
 	return &configv1.APIServer{
 		ObjectMeta: metav1.ObjectMeta{
@@ -5297,10 +6473,9 @@ http {
 
 		ssl_dhparam /etc/nginx-cryostat/dhparam.pem;
 
-		# intermediate configuration
-		ssl_protocols TLSv1.2 TLSv1.3;
-		ssl_ciphers ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384:DHE-RSA-CHACHA20-POLY1305;
-		ssl_prefer_server_ciphers off;
+		# TLS security profile configuration
+		ssl_protocols %s;
+%s		ssl_prefer_server_ciphers off;
 
 		# HSTS (ngx_http_headers_module is required) (63072000 seconds)
 		add_header Strict-Transport-Security "max-age=63072000" always;
@@ -5309,10 +6484,10 @@ http {
 		ssl_stapling on;
 		ssl_stapling_verify on;
 
-		ssl_trusted_certificate /var/run/secrets/operator.cryostat.io/%s-agent-tls/ca.crt;
+		ssl_trusted_certificate /etc/nginx-cryostat/ca-bundle.crt;
 
 		# Client certificate authentication
-		ssl_client_certificate /var/run/secrets/operator.cryostat.io/%s-agent-tls/ca.crt;
+		ssl_client_certificate /etc/nginx-cryostat/ca-bundle.crt;
 		ssl_verify_client on;
 
 		location /health/ {
@@ -5520,7 +6695,8 @@ func (r *TestResources) NewAgentProxyConfigMap() *corev1.ConfigMap {
 	var data map[string]string
 	if r.TLS {
 		data = map[string]string{
-			"nginx.conf": fmt.Sprintf(nginxFormatTLS, r.Name, r.Namespace, r.Name, r.Name, r.Name, r.Name),
+			"nginx.conf":    fmt.Sprintf(nginxFormatTLS, r.Name, r.Namespace, r.Name, r.Name, r.getNginxTLSProtocols(), r.getNginxTLSCiphers()),
+			"ca-bundle.crt": string(r.getCABytes()),
 			"dhparam.pem": `-----BEGIN DH PARAMETERS-----
 MIIBCAKCAQEA//////////+t+FRYortKmq/cViAnPTzx2LnFg84tNpWp4TZBFGQz
 +8yTnc4kmz75fS/jY2MMddj2gbICrsRhetPfHtXV/WVhJDP1H18GbtCFY2VVPe0a
@@ -5545,6 +6721,39 @@ ssbzSibBsu/6iGtCOGEoXJf//////////wIBAg==
 	}
 }
 
+func (r *TestResources) getNginxTLSProtocols() string {
+	if r.ModernTLSProfile {
+		return "TLSv1.3"
+	}
+	return "TLSv1.2 TLSv1.3"
+}
+
+func (r *TestResources) getNginxTLSCiphers() string {
+	if r.ModernTLSProfile {
+		return ""
+	}
+	return "\t\tssl_ciphers " + openSSLIntermediateCiphers + ";\n"
+}
+
+const openSSLIntermediateCiphers = "ECDHE-ECDSA-AES128-GCM-SHA256:ECDHE-RSA-AES128-GCM-SHA256:ECDHE-ECDSA-AES256-GCM-SHA384:ECDHE-RSA-AES256-GCM-SHA384:ECDHE-ECDSA-CHACHA20-POLY1305:ECDHE-RSA-CHACHA20-POLY1305:DHE-RSA-AES128-GCM-SHA256:DHE-RSA-AES256-GCM-SHA384"
+
+func (r *TestResources) getJavaTLSProtocols() string {
+	if r.ModernTLSProfile {
+		return "TLSv1.3"
+	}
+	return "TLSv1.2,TLSv1.3"
+}
+
+func (r *TestResources) GetJavaTLSCiphers() string {
+	ciphers := "TLS_AES_128_GCM_SHA256,TLS_AES_256_GCM_SHA384,TLS_CHACHA20_POLY1305_SHA256"
+	if r.ModernTLSProfile {
+		return ciphers
+	}
+	return ciphers + ",TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384," +
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256," +
+		"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256,TLS_DHE_RSA_WITH_AES_256_GCM_SHA384"
+}
+
 var alphaConfigTLS = `{
   "server": {
     "SecureBindAddress": "https://0.0.0.0:4180",
@@ -5554,7 +6763,8 @@ var alphaConfigTLS = `{
       },
       "Cert": {
         "fromFile": "/var/run/secrets/operator.cryostat.io/%s-tls/tls.crt"
-      }
+      },
+%s
     }
   },
   "upstreamConfig": {
@@ -5630,9 +6840,11 @@ var alphaConfigNoTLS = `{
 }`
 
 func (r *TestResources) NewOAuth2ProxyConfigMap() *corev1.ConfigMap {
-	alphaConfig := fmt.Sprintf(alphaConfigTLS, r.Name, r.Name)
+	alphaConfig := fmt.Sprintf(alphaConfigTLS, r.Name, r.Name, r.getAlphaConfigTLSProfile())
 	if !r.TLS {
 		alphaConfig = alphaConfigNoTLS
+	} else if r.SidecarTLS {
+		alphaConfig = strings.Replace(alphaConfig, `"uri": "http://localhost:3000"`, `"uri": "https://localhost:3000"`, 1)
 	}
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -5645,6 +6857,21 @@ func (r *TestResources) NewOAuth2ProxyConfigMap() *corev1.ConfigMap {
 	}
 }
 
+func (r *TestResources) getAlphaConfigTLSProfile() string {
+	if r.ModernTLSProfile {
+		return `      "MinVersion": "TLS1.3"`
+	}
+	return `      "MinVersion": "TLS1.2",
+      "CipherSuites": [
+        "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+        "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+        "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+        "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+        "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+        "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"
+      ]`
+}
+
 func (r *TestResources) NewOAuth2ProxyConfigMapOld() *corev1.ConfigMap {
 	cm := r.NewOAuth2ProxyConfigMap()
 	cm.Immutable = &[]bool{true}[0]
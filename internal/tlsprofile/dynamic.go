@@ -0,0 +1,103 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile
+
+import (
+	"context"
+	"crypto/tls"
+	"slices"
+	"sync/atomic"
+
+	configv1 "github.com/openshift/api/config/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// Dynamic holds a TLS security profile for servers that keep running while the profile changes,
+// such as when the TLS security profile of the OpenShift API server is updated
+type Dynamic struct {
+	profile atomic.Pointer[Profile]
+}
+
+// NewDynamic returns a Dynamic holding the provided profile
+func NewDynamic(profile *Profile) *Dynamic {
+	d := &Dynamic{}
+	d.Set(profile)
+	return d
+}
+
+// Get returns the current profile
+func (d *Dynamic) Get() *Profile {
+	return d.profile.Load()
+}
+
+// Set replaces the current profile. Connections established afterwards use the new profile.
+func (d *Dynamic) Set(profile *Profile) {
+	d.profile.Store(profile)
+}
+
+// ApplyToTLSConfig restricts a Go crypto/tls server configuration to the current profile,
+// and has the configuration use the profile that is current when each client connects
+func (d *Dynamic) ApplyToTLSConfig(config *tls.Config) {
+	d.Get().ApplyToTLSConfig(config)
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		// Clone when the client connects, to include any options applied after this one
+		result := config.Clone()
+		result.GetConfigForClient = nil
+		d.Get().ApplyToTLSConfig(result)
+		return result, nil
+	}
+}
+
+// WatchCluster updates the profile whenever the TLS security profile of the OpenShift
+// API server changes, using an informer from the provided cache
+func (d *Dynamic) WatchCluster(ctx context.Context, c cache.Informers, onChange func(*Profile)) error {
+	informer, err := c.GetInformer(ctx, &configv1.APIServer{})
+	if err != nil {
+		return err
+	}
+	set := func(profile *Profile) {
+		current := d.Get()
+		if current != nil && current.MinTLSVersion == profile.MinTLSVersion && slices.Equal(current.Ciphers, profile.Ciphers) {
+			return
+		}
+		d.Set(profile)
+		if onChange != nil {
+			onChange(profile)
+		}
+	}
+	isCluster := func(obj any) (*configv1.APIServer, bool) {
+		apiServer, ok := obj.(*configv1.APIServer)
+		return apiServer, ok && apiServer.Name == apiServerName
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if apiServer, ok := isCluster(obj); ok {
+				set(FromSecurityProfile(apiServer.Spec.TLSSecurityProfile))
+			}
+		},
+		UpdateFunc: func(_, obj any) {
+			if apiServer, ok := isCluster(obj); ok {
+				set(FromSecurityProfile(apiServer.Spec.TLSSecurityProfile))
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if _, ok := isCluster(obj); ok {
+				set(Default())
+			}
+		},
+	})
+	return err
+}
@@ -0,0 +1,119 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile_test
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/cryostatio/cryostat-operator/internal/tlsprofile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+)
+
+var _ = Describe("Dynamic TLS security profile", func() {
+	var dynamic *tlsprofile.Dynamic
+
+	BeforeEach(func() {
+		dynamic = tlsprofile.NewDynamic(tlsprofile.Default())
+	})
+
+	It("should use the current profile for each client", func() {
+		config := &tls.Config{}
+		dynamic.ApplyToTLSConfig(config)
+		Expect(config.MinVersion).To(Equal(uint16(tls.VersionTLS12)))
+
+		dynamic.Set(tlsprofile.FromSecurityProfile(&configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType}))
+		clientConfig, err := config.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clientConfig.MinVersion).To(Equal(uint16(tls.VersionTLS13)))
+		Expect(clientConfig.CipherSuites).To(BeEmpty())
+		Expect(clientConfig.GetConfigForClient).To(BeNil())
+	})
+
+	It("should keep options applied after the profile", func() {
+		config := &tls.Config{}
+		dynamic.ApplyToTLSConfig(config)
+		config.NextProtos = []string{"http/1.1"}
+
+		clientConfig, err := config.GetConfigForClient(&tls.ClientHelloInfo{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clientConfig.NextProtos).To(Equal([]string{"http/1.1"}))
+	})
+
+	Context("watching the API server", func() {
+		var informer *controllertest.FakeInformer
+		var changes []*tlsprofile.Profile
+		var apiServer *configv1.APIServer
+
+		BeforeEach(func() {
+			changes = []*tlsprofile.Profile{}
+			s := runtime.NewScheme()
+			Expect(configv1.AddToScheme(s)).To(Succeed())
+			informers := &informertest.FakeInformers{Scheme: s}
+			err := dynamic.WatchCluster(context.Background(), informers, func(profile *tlsprofile.Profile) {
+				changes = append(changes, profile)
+			})
+			Expect(err).ToNot(HaveOccurred())
+			informer, err = informers.FakeInformerFor(context.Background(), &configv1.APIServer{})
+			Expect(err).ToNot(HaveOccurred())
+
+			apiServer = &configv1.APIServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: configv1.APIServerSpec{
+					TLSSecurityProfile: &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType},
+				},
+			}
+		})
+
+		It("should update the profile when the API server changes", func() {
+			informer.Add(apiServer)
+			Expect(dynamic.Get().MinTLSVersion).To(Equal(configv1.VersionTLS13))
+			Expect(changes).To(HaveLen(1))
+
+			updated := apiServer.DeepCopy()
+			updated.Spec.TLSSecurityProfile = &configv1.TLSSecurityProfile{Type: configv1.TLSProfileOldType}
+			informer.Update(apiServer, updated)
+			Expect(dynamic.Get().MinTLSVersion).To(Equal(configv1.VersionTLS10))
+			Expect(changes).To(HaveLen(2))
+		})
+
+		It("should not report unchanged profiles", func() {
+			apiServer.Spec.TLSSecurityProfile = nil
+			informer.Add(apiServer)
+			Expect(dynamic.Get()).To(Equal(tlsprofile.Default()))
+			Expect(changes).To(BeEmpty())
+		})
+
+		It("should use the default profile when the API server is deleted", func() {
+			informer.Add(apiServer)
+			informer.Delete(apiServer)
+			Expect(dynamic.Get()).To(Equal(tlsprofile.Default()))
+			Expect(changes).To(HaveLen(2))
+		})
+
+		It("should ignore other API servers", func() {
+			apiServer.Name = "other"
+			informer.Add(apiServer)
+			Expect(dynamic.Get()).To(Equal(tlsprofile.Default()))
+			Expect(changes).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,250 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile
+
+import (
+	"context"
+	"crypto/tls"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Profile is a resolved TLS security profile, containing the minimum TLS version
+// and the allowed ciphers using their OpenSSL names
+type Profile struct {
+	MinTLSVersion configv1.TLSProtocolVersion
+	Ciphers       []string
+}
+
+// The canonical name of an APIServer instance
+const apiServerName = "cluster"
+
+// Default returns the profile used when none is configured, which is the "Intermediate" profile
+func Default() *Profile {
+	return fromSpec(configv1.TLSProfiles[configv1.TLSProfileIntermediateType])
+}
+
+// FromSecurityProfile resolves a TLS security profile into its minimum TLS version and ciphers.
+// A missing or unrecognized profile resolves to the default profile.
+func FromSecurityProfile(profile *configv1.TLSSecurityProfile) *Profile {
+	if profile == nil {
+		return Default()
+	}
+	switch profile.Type {
+	case configv1.TLSProfileOldType, configv1.TLSProfileIntermediateType, configv1.TLSProfileModernType:
+		return fromSpec(configv1.TLSProfiles[profile.Type])
+	case configv1.TLSProfileCustomType:
+		if profile.Custom == nil {
+			return Default()
+		}
+		result := fromSpec(&profile.Custom.TLSProfileSpec)
+		if len(result.MinTLSVersion) == 0 {
+			result.MinTLSVersion = configv1.VersionTLS12
+		}
+		return result
+	default:
+		return Default()
+	}
+}
+
+// ForCluster returns the TLS security profile of the OpenShift API server
+func ForCluster(ctx context.Context, reader client.Reader) (*Profile, error) {
+	apiServer := &configv1.APIServer{}
+	err := reader.Get(ctx, types.NamespacedName{Name: apiServerName}, apiServer)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return Default(), nil
+		}
+		return nil, err
+	}
+	return FromSecurityProfile(apiServer.Spec.TLSSecurityProfile), nil
+}
+
+// ForCryostat returns the TLS security profile for a Cryostat instance. A profile specified in
+// the Cryostat spec takes precedence, followed by the profile of the OpenShift API server.
+func ForCryostat(ctx context.Context, reader client.Reader, profile *configv1.TLSSecurityProfile, openShift bool) (*Profile, error) {
+	if profile != nil || !openShift {
+		return FromSecurityProfile(profile), nil
+	}
+	return ForCluster(ctx, reader)
+}
+
+func fromSpec(spec *configv1.TLSProfileSpec) *Profile {
+	return &Profile{
+		MinTLSVersion: spec.MinTLSVersion,
+		Ciphers:       append([]string{}, spec.Ciphers...),
+	}
+}
+
+var tlsVersions = []configv1.TLSProtocolVersion{
+	configv1.VersionTLS10,
+	configv1.VersionTLS11,
+	configv1.VersionTLS12,
+	configv1.VersionTLS13,
+}
+
+var goTLSVersions = map[configv1.TLSProtocolVersion]uint16{
+	configv1.VersionTLS10: tls.VersionTLS10,
+	configv1.VersionTLS11: tls.VersionTLS11,
+	configv1.VersionTLS12: tls.VersionTLS12,
+	configv1.VersionTLS13: tls.VersionTLS13,
+}
+
+// IANA names for the OpenSSL cipher names used by TLS security profiles.
+// TLS 1.3 cipher suites use the same name in both.
+var ianaCiphers = map[string]string{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-RSA-AES128-GCM-SHA256":   "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"ECDHE-ECDSA-AES256-GCM-SHA384": "TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-RSA-AES256-GCM-SHA384":   "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	"ECDHE-ECDSA-CHACHA20-POLY1305": "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256",
+	"ECDHE-RSA-CHACHA20-POLY1305":   "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"DHE-RSA-AES128-GCM-SHA256":     "TLS_DHE_RSA_WITH_AES_128_GCM_SHA256",
+	"DHE-RSA-AES256-GCM-SHA384":     "TLS_DHE_RSA_WITH_AES_256_GCM_SHA384",
+	"DHE-RSA-CHACHA20-POLY1305":     "TLS_DHE_RSA_WITH_CHACHA20_POLY1305_SHA256",
+	"DHE-RSA-AES128-SHA256":         "TLS_DHE_RSA_WITH_AES_128_CBC_SHA256",
+	"DHE-RSA-AES256-SHA256":         "TLS_DHE_RSA_WITH_AES_256_CBC_SHA256",
+	"ECDHE-ECDSA-AES128-SHA256":     "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-RSA-AES128-SHA256":       "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256",
+	"ECDHE-ECDSA-AES128-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-RSA-AES128-SHA":          "TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA",
+	"ECDHE-ECDSA-AES256-SHA384":     "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA384",
+	"ECDHE-RSA-AES256-SHA384":       "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA384",
+	"ECDHE-ECDSA-AES256-SHA":        "TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA",
+	"ECDHE-RSA-AES256-SHA":          "TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA",
+	"AES128-GCM-SHA256":             "TLS_RSA_WITH_AES_128_GCM_SHA256",
+	"AES256-GCM-SHA384":             "TLS_RSA_WITH_AES_256_GCM_SHA384",
+	"AES128-SHA256":                 "TLS_RSA_WITH_AES_128_CBC_SHA256",
+	"AES256-SHA256":                 "TLS_RSA_WITH_AES_256_CBC_SHA256",
+	"AES128-SHA":                    "TLS_RSA_WITH_AES_128_CBC_SHA",
+	"AES256-SHA":                    "TLS_RSA_WITH_AES_256_CBC_SHA",
+	"DES-CBC3-SHA":                  "TLS_RSA_WITH_3DES_EDE_CBC_SHA",
+}
+
+// Versions returns the TLS versions enabled by this profile, from lowest to highest,
+// using the "TLSv1.2" naming convention
+func (p *Profile) Versions() []string {
+	result := []string{}
+	enabled := false
+	for _, version := range tlsVersions {
+		if version == p.MinTLSVersion {
+			enabled = true
+		}
+		if enabled {
+			result = append(result, protocolName(version))
+		}
+	}
+	if len(result) == 0 {
+		// Unrecognized minimum version, only enable the highest
+		result = append(result, protocolName(configv1.VersionTLS13))
+	}
+	return result
+}
+
+// MinVersion returns the minimum TLS version using the "TLSv1.2" naming convention
+func (p *Profile) MinVersion() string {
+	return p.Versions()[0]
+}
+
+// TLS13Only returns whether this profile only permits TLS 1.3
+func (p *Profile) TLS13Only() bool {
+	return p.MinVersion() == protocolName(configv1.VersionTLS13)
+}
+
+// OpenSSLCiphers returns the profile's TLS 1.2 and older ciphers, using their OpenSSL names.
+// TLS 1.3 cipher suites are omitted, since OpenSSL configures these separately.
+func (p *Profile) OpenSSLCiphers() []string {
+	result := []string{}
+	for _, cipher := range p.Ciphers {
+		if !isTLS13Cipher(cipher) {
+			result = append(result, cipher)
+		}
+	}
+	return result
+}
+
+// IANACiphers returns the profile's ciphers using their IANA names. Ciphers
+// without a known IANA name are omitted.
+func (p *Profile) IANACiphers() []string {
+	result := []string{}
+	for _, cipher := range p.Ciphers {
+		if isTLS13Cipher(cipher) {
+			result = append(result, cipher)
+		} else if name, pres := ianaCiphers[cipher]; pres {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// GoMinVersion returns the minimum TLS version for a Go crypto/tls configuration
+func (p *Profile) GoMinVersion() uint16 {
+	version, pres := goTLSVersions[p.MinTLSVersion]
+	if !pres {
+		return tls.VersionTLS13
+	}
+	return version
+}
+
+// GoCipherSuites returns the profile's TLS 1.2 and older ciphers that are supported
+// by Go's crypto/tls. TLS 1.3 cipher suites are not configurable in Go.
+func (p *Profile) GoCipherSuites() []uint16 {
+	ids := map[string]uint16{}
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		ids[suite.Name] = suite.ID
+	}
+	result := []uint16{}
+	for _, cipher := range p.OpenSSLCiphers() {
+		if id, pres := ids[ianaCiphers[cipher]]; pres {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// GoCipherSuiteNames returns the names of the cipher suites from GoCipherSuites
+func (p *Profile) GoCipherSuiteNames() []string {
+	result := []string{}
+	for _, id := range p.GoCipherSuites() {
+		result = append(result, tls.CipherSuiteName(id))
+	}
+	return result
+}
+
+// ApplyToTLSConfig restricts a Go crypto/tls configuration to this profile
+func (p *Profile) ApplyToTLSConfig(config *tls.Config) {
+	config.MinVersion = p.GoMinVersion()
+	config.CipherSuites = p.GoCipherSuites()
+}
+
+func protocolName(version configv1.TLSProtocolVersion) string {
+	// VersionTLS12 -> TLSv1.2, VersionTLS10 -> TLSv1
+	digits := strings.TrimPrefix(string(version), "VersionTLS")
+	if len(digits) != 2 {
+		return string(version)
+	}
+	if digits[1:] == "0" {
+		return "TLSv" + digits[:1]
+	}
+	return "TLSv" + digits[:1] + "." + digits[1:]
+}
+
+func isTLS13Cipher(cipher string) bool {
+	return strings.HasPrefix(cipher, "TLS_")
+}
@@ -0,0 +1,198 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile_test
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/cryostatio/cryostat-operator/internal/tlsprofile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("TLS security profiles", func() {
+	customProfile := func(minVersion configv1.TLSProtocolVersion, ciphers ...string) *configv1.TLSSecurityProfile {
+		return &configv1.TLSSecurityProfile{
+			Type: configv1.TLSProfileCustomType,
+			Custom: &configv1.CustomTLSProfile{
+				TLSProfileSpec: configv1.TLSProfileSpec{
+					MinTLSVersion: minVersion,
+					Ciphers:       ciphers,
+				},
+			},
+		}
+	}
+
+	DescribeTable("resolving a security profile",
+		func(profile *configv1.TLSSecurityProfile, expected *configv1.TLSProfileSpec) {
+			result := tlsprofile.FromSecurityProfile(profile)
+			Expect(result.MinTLSVersion).To(Equal(expected.MinTLSVersion))
+			Expect(result.Ciphers).To(Equal(expected.Ciphers))
+		},
+		Entry("with no profile", nil, configv1.TLSProfiles[configv1.TLSProfileIntermediateType]),
+		Entry("with the Old profile", &configv1.TLSSecurityProfile{Type: configv1.TLSProfileOldType},
+			configv1.TLSProfiles[configv1.TLSProfileOldType]),
+		Entry("with the Intermediate profile", &configv1.TLSSecurityProfile{Type: configv1.TLSProfileIntermediateType},
+			configv1.TLSProfiles[configv1.TLSProfileIntermediateType]),
+		Entry("with the Modern profile", &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType},
+			configv1.TLSProfiles[configv1.TLSProfileModernType]),
+		Entry("with a Custom profile", customProfile(configv1.VersionTLS11, "ECDHE-RSA-AES128-GCM-SHA256"),
+			&configv1.TLSProfileSpec{MinTLSVersion: configv1.VersionTLS11, Ciphers: []string{"ECDHE-RSA-AES128-GCM-SHA256"}}),
+		Entry("with a Custom profile without a minimum version", customProfile("", "TLS_AES_128_GCM_SHA256"),
+			&configv1.TLSProfileSpec{MinTLSVersion: configv1.VersionTLS12, Ciphers: []string{"TLS_AES_128_GCM_SHA256"}}),
+		Entry("with a Custom profile without settings", &configv1.TLSSecurityProfile{Type: configv1.TLSProfileCustomType},
+			configv1.TLSProfiles[configv1.TLSProfileIntermediateType]),
+		Entry("with an unknown profile type", &configv1.TLSSecurityProfile{Type: "Unknown"},
+			configv1.TLSProfiles[configv1.TLSProfileIntermediateType]),
+	)
+
+	DescribeTable("listing TLS versions",
+		func(minVersion configv1.TLSProtocolVersion, expected []string, tls13Only bool) {
+			profile := &tlsprofile.Profile{MinTLSVersion: minVersion}
+			Expect(profile.Versions()).To(Equal(expected))
+			Expect(profile.MinVersion()).To(Equal(expected[0]))
+			Expect(profile.TLS13Only()).To(Equal(tls13Only))
+		},
+		Entry("from TLS 1.0", configv1.VersionTLS10, []string{"TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}, false),
+		Entry("from TLS 1.1", configv1.VersionTLS11, []string{"TLSv1.1", "TLSv1.2", "TLSv1.3"}, false),
+		Entry("from TLS 1.2", configv1.VersionTLS12, []string{"TLSv1.2", "TLSv1.3"}, false),
+		Entry("from TLS 1.3", configv1.VersionTLS13, []string{"TLSv1.3"}, true),
+		Entry("from an unknown version", configv1.TLSProtocolVersion("VersionTLS14"), []string{"TLSv1.3"}, true),
+	)
+
+	DescribeTable("converting ciphers",
+		func(ciphers []string, openSSL []string, iana []string, goSuites []uint16) {
+			profile := &tlsprofile.Profile{MinTLSVersion: configv1.VersionTLS12, Ciphers: ciphers}
+			Expect(profile.OpenSSLCiphers()).To(Equal(openSSL))
+			Expect(profile.IANACiphers()).To(Equal(iana))
+			Expect(profile.GoCipherSuites()).To(Equal(goSuites))
+			names := []string{}
+			for _, id := range goSuites {
+				names = append(names, tls.CipherSuiteName(id))
+			}
+			Expect(profile.GoCipherSuiteNames()).To(Equal(names))
+		},
+		Entry("with TLS 1.3 cipher suites",
+			[]string{"TLS_AES_128_GCM_SHA256", "TLS_CHACHA20_POLY1305_SHA256"},
+			[]string{},
+			[]string{"TLS_AES_128_GCM_SHA256", "TLS_CHACHA20_POLY1305_SHA256"},
+			[]uint16{}),
+		Entry("with ECDHE ciphers",
+			[]string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-CHACHA20-POLY1305"},
+			[]string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-CHACHA20-POLY1305"},
+			[]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"},
+			[]uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256}),
+		Entry("with insecure ciphers supported by Go",
+			[]string{"ECDHE-RSA-AES128-SHA256", "DES-CBC3-SHA"},
+			[]string{"ECDHE-RSA-AES128-SHA256", "DES-CBC3-SHA"},
+			[]string{"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256", "TLS_RSA_WITH_3DES_EDE_CBC_SHA"},
+			[]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256, tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA}),
+		Entry("with DHE ciphers not supported by Go",
+			[]string{"DHE-RSA-AES128-GCM-SHA256"},
+			[]string{"DHE-RSA-AES128-GCM-SHA256"},
+			[]string{"TLS_DHE_RSA_WITH_AES_128_GCM_SHA256"},
+			[]uint16{}),
+		Entry("with unknown ciphers",
+			[]string{"UNKNOWN-CIPHER"},
+			[]string{"UNKNOWN-CIPHER"},
+			[]string{},
+			[]uint16{}),
+	)
+
+	It("should know the IANA name of every cipher in the predefined profiles", func() {
+		for profileType, spec := range configv1.TLSProfiles {
+			if profileType == configv1.TLSProfileCustomType {
+				continue
+			}
+			profile := &tlsprofile.Profile{MinTLSVersion: spec.MinTLSVersion, Ciphers: spec.Ciphers}
+			Expect(profile.IANACiphers()).To(HaveLen(len(spec.Ciphers)), "profile %s", profileType)
+		}
+	})
+
+	DescribeTable("applying to a Go TLS configuration",
+		func(profileType configv1.TLSProfileType, minVersion uint16) {
+			profile := tlsprofile.FromSecurityProfile(&configv1.TLSSecurityProfile{Type: profileType})
+			config := &tls.Config{}
+			profile.ApplyToTLSConfig(config)
+			Expect(config.MinVersion).To(Equal(minVersion))
+			Expect(config.CipherSuites).To(Equal(profile.GoCipherSuites()))
+			for _, id := range config.CipherSuites {
+				Expect(tls.CipherSuiteName(id)).To(BeElementOf(profile.IANACiphers()))
+			}
+		},
+		Entry("with the Old profile", configv1.TLSProfileOldType, uint16(tls.VersionTLS10)),
+		Entry("with the Intermediate profile", configv1.TLSProfileIntermediateType, uint16(tls.VersionTLS12)),
+		Entry("with the Modern profile", configv1.TLSProfileModernType, uint16(tls.VersionTLS13)),
+	)
+
+	Context("resolving the profile for a Cryostat instance", func() {
+		var reader client.Reader
+		var objs []client.Object
+
+		BeforeEach(func() {
+			objs = []client.Object{}
+		})
+
+		JustBeforeEach(func() {
+			s := runtime.NewScheme()
+			Expect(configv1.AddToScheme(s)).To(Succeed())
+			reader = fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+		})
+
+		Context("with an API server profile", func() {
+			BeforeEach(func() {
+				objs = append(objs, &configv1.APIServer{
+					ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+					Spec: configv1.APIServerSpec{
+						TLSSecurityProfile: &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType},
+					},
+				})
+			})
+
+			It("should use the API server profile on OpenShift", func() {
+				profile, err := tlsprofile.ForCryostat(context.Background(), reader, nil, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(profile.MinTLSVersion).To(Equal(configv1.VersionTLS13))
+			})
+
+			It("should prefer the Cryostat profile", func() {
+				profile, err := tlsprofile.ForCryostat(context.Background(), reader,
+					&configv1.TLSSecurityProfile{Type: configv1.TLSProfileOldType}, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(profile.MinTLSVersion).To(Equal(configv1.VersionTLS10))
+			})
+
+			It("should use the default profile on other platforms", func() {
+				profile, err := tlsprofile.ForCryostat(context.Background(), reader, nil, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(profile).To(Equal(tlsprofile.Default()))
+			})
+		})
+
+		Context("without an API server", func() {
+			It("should use the default profile", func() {
+				profile, err := tlsprofile.ForCluster(context.Background(), reader)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(profile).To(Equal(tlsprofile.Default()))
+			})
+		})
+	})
+})
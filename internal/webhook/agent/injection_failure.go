@@ -0,0 +1,183 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/agentconfig"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Reasons that the agent could not be injected into a pod
+const (
+	reasonCryostatNotFound         = "CryostatNotFound"
+	reasonNamespaceNotTargeted     = "NamespaceNotTargeted"
+	reasonInvalidConfiguration     = agentconfig.ReasonInvalidConfiguration
+	reasonJavaOptionsNotExtensible = "JavaOptionsNotExtensible"
+	reasonAgentConfigNotFound      = "AgentConfigNotFound"
+	reasonInternalError            = "InternalError"
+)
+
+// Values for spec.agentOptions.injectionFailurePolicy
+const (
+	injectionFailurePolicyIgnore = "Ignore"
+	injectionFailurePolicyFail   = "Fail"
+)
+
+const eventAgentInjectionFailedType = "AgentInjectionFailed"
+
+// statusReasonAgentInjectionRejected marks a response that should deny the pod,
+// rather than admitting it without the agent.
+const statusReasonAgentInjectionRejected metav1.StatusReason = "AgentInjectionRejected"
+
+// injectionError is an error that prevented the agent from being injected into a pod
+type injectionError struct {
+	reason string
+	err    error
+}
+
+func newInjectionError(reason string, err error) *injectionError {
+	return &injectionError{
+		reason: reason,
+		err:    err,
+	}
+}
+
+func (e *injectionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *injectionError) Unwrap() error {
+	return e.err
+}
+
+func newLookupError(err error) *injectionError {
+	reason := reasonInternalError
+	if kerrors.IsNotFound(err) {
+		reason = reasonCryostatNotFound
+	}
+	return newInjectionError(reason, err)
+}
+
+// injectionFailed reports a failure to inject the agent into a pod, and returns the error
+// to deny the admission request with. The target is nil if no Cryostat could be selected
+// for the pod, and the cr is nil if the Cryostat could not be found.
+func (r *podMutator) injectionFailed(ctx context.Context, pod *corev1.Pod, target *types.NamespacedName,
+	cr *operatorv1beta2.Cryostat, err *injectionError) error {
+	podName := getPodName(pod)
+	r.log.Info("failed to configure Cryostat agent for pod", "name", podName, "namespace", pod.Namespace,
+		"reason", err.reason, "error", err.err.Error())
+
+	if !isDryRun(ctx) {
+		// Failures are counted per Cryostat, so there is nothing to count against without a target
+		if target != nil {
+			metrics.RecordAgentInjectionFailure(target.Namespace, target.Name, pod.Namespace, err.reason)
+		}
+		r.recordFailureEvent(ctx, pod, fmt.Sprintf("Failed to inject the Cryostat agent into pod %s: %s",
+			podName, err.Error()))
+	}
+
+	if getInjectionFailurePolicy(cr) == injectionFailurePolicyFail {
+		return &kerrors.StatusError{
+			ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusForbidden,
+				Reason:  statusReasonAgentInjectionRejected,
+				Message: fmt.Sprintf("failed to inject the Cryostat agent (%s): %s", err.reason, err.Error()),
+			},
+		}
+	}
+	return err
+}
+
+// recordFailureEvent records a warning Event on the workload that owns the pod,
+// since the pod itself does not exist yet
+func (r *podMutator) recordFailureEvent(ctx context.Context, pod *corev1.Pod, msg string) {
+	owner := r.getEventOwner(ctx, pod)
+	if owner == nil {
+		return
+	}
+	r.recorder.Event(owner, corev1.EventTypeWarning, eventAgentInjectionFailedType, msg)
+}
+
+func (r *podMutator) getEventOwner(ctx context.Context, pod *corev1.Pod) runtime.Object {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return nil
+	}
+	owner := objectForReference(ref, pod.Namespace)
+
+	// Report to the Deployment instead of its ReplicaSet, if there is one
+	if ref.APIVersion != appsv1.SchemeGroupVersion.String() || ref.Kind != "ReplicaSet" {
+		return owner
+	}
+	rs := &metav1.PartialObjectMetadata{}
+	rs.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+	err := r.reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: pod.Namespace}, rs)
+	if err != nil {
+		r.log.Error(err, "failed to look up ReplicaSet for pod", "name", ref.Name, "namespace", pod.Namespace)
+		return owner
+	}
+	rsRef := metav1.GetControllerOf(rs)
+	if rsRef != nil && rsRef.APIVersion == appsv1.SchemeGroupVersion.String() && rsRef.Kind == "Deployment" {
+		return objectForReference(rsRef, pod.Namespace)
+	}
+	return owner
+}
+
+func objectForReference(ref *metav1.OwnerReference, namespace string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: namespace,
+			UID:       ref.UID,
+		},
+	}
+}
+
+func getInjectionFailurePolicy(cr *operatorv1beta2.Cryostat) string {
+	if cr != nil && cr.Spec.AgentOptions != nil && cr.Spec.AgentOptions.InjectionFailurePolicy != nil {
+		return *cr.Spec.AgentOptions.InjectionFailurePolicy
+	}
+	return injectionFailurePolicyIgnore
+}
+
+func getPodName(pod *corev1.Pod) string {
+	// Use GenerateName if no explicit Name is given
+	if len(pod.Name) == 0 {
+		return pod.GenerateName
+	}
+	return pod.Name
+}
+
+func isDryRun(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	return err == nil && req.DryRun != nil && *req.DryRun
+}
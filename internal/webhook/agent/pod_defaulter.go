@@ -19,49 +19,51 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/agentconfig"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
+	resources "github.com/cryostatio/cryostat-operator/internal/controller/common/resource_definitions"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/cryostatio/cryostat-operator/internal/controller/metrics"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 type podMutator struct {
-	client client.Client
-	log    *logr.Logger
-	gvk    *schema.GroupVersionKind
-	config *AgentWebhookConfig
+	client   client.Client
+	reader   client.Reader
+	recorder record.EventRecorder
+	log      *logr.Logger
+	gvk      *schema.GroupVersionKind
+	config   *AgentWebhookConfig
 	common.ReconcilerTLS
 }
 
 var _ admission.CustomDefaulter = &podMutator{}
 
 const (
-	agentArg                    = "-javaagent:" + constants.AgentJarPath
-	agentLogLevelProp           = "io.cryostat.agent.shaded.org.slf4j.simpleLogger.defaultLogLevel"
-	podNameEnvVar               = "CRYOSTAT_AGENT_POD_NAME"
-	podIPEnvVar                 = "CRYOSTAT_AGENT_POD_IP"
-	agentMaxSizeBytes           = "50Mi"
-	agentInitCpuRequest         = "10m"
-	agentInitMemoryRequest      = "32Mi"
-	agentInitCpuLimit           = "20m"
-	agentInitMemoryLimit        = "64Mi"
-	defaultLogLevel             = "off"
-	defaultJavaOptsVar          = "JAVA_TOOL_OPTIONS"
-	defaultSmartTriggersMount   = constants.AgentEmptyDirBasePath + "/smart-triggers"
-	defaultHarvesterExitMaxAge  = int32(30000)
-	kib                         = int32(1024)
-	mib                         = 1024 * kib
-	defaultHarvesterExitMaxSize = 20 * mib
+	agentArg                  = "-javaagent:" + constants.AgentJarPath
+	agentLogLevelProp         = "io.cryostat.agent.shaded.org.slf4j.simpleLogger.defaultLogLevel"
+	podNameEnvVar             = "CRYOSTAT_AGENT_POD_NAME"
+	podIPEnvVar               = "CRYOSTAT_AGENT_POD_IP"
+	agentMaxSizeBytes         = "50Mi"
+	agentInitCpuRequest       = "10m"
+	agentInitMemoryRequest    = "32Mi"
+	agentInitCpuLimit         = "20m"
+	agentInitMemoryLimit      = "64Mi"
+	defaultSmartTriggersMount = constants.AgentEmptyDirBasePath + "/smart-triggers"
 )
 
 // Default optionally mutates a pod to inject the Cryostat agent
@@ -71,74 +73,137 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		return fmt.Errorf("expected a Pod, but received a %T", obj)
 	}
 
-	// Check for required labels and return early if missing.
-	// This should not happen because such pods are filtered out by Kubernetes server-side due to our object selector.
-	if !metav1.HasLabel(pod.ObjectMeta, constants.AgentLabelCryostatName) || !metav1.HasLabel(pod.ObjectMeta, constants.AgentLabelCryostatNamespace) {
-		r.log.Info("pod is missing required labels")
+	// Pods may opt out of injection, even within a namespace that opts in
+	if agentconfig.IsInjectionDisabled(pod) {
+		r.log.Info("agent injection disabled for pod", "name", getPodName(pod), "namespace", pod.Namespace)
 		return nil
 	}
 
+	// Without labels selecting a Cryostat, fall back to the selection on the pod's namespace
+	target := getInjectionTarget(pod)
+	if target == nil {
+		selected, injectErr := r.selectCryostatFromNamespace(ctx, pod)
+		if injectErr != nil {
+			return r.injectionFailed(ctx, pod, nil, nil, injectErr)
+		}
+		// This should not happen because such pods are filtered out by Kubernetes server-side due to our selectors.
+		if selected == nil {
+			r.log.Info("pod is missing required labels")
+			return nil
+		}
+		target = selected
+	}
+
 	// Look up Cryostat
 	cr := &operatorv1beta2.Cryostat{}
-	err := r.client.Get(ctx, types.NamespacedName{
-		Name:      pod.Labels[constants.AgentLabelCryostatName],
-		Namespace: pod.Labels[constants.AgentLabelCryostatNamespace],
-	}, cr)
+	err := r.client.Get(ctx, *target, cr)
 	if err != nil {
-		return err
+		return r.injectionFailed(ctx, pod, target, nil, newLookupError(err))
 	}
 
-	// Check if this pod is within a target namespace of the CR
-	if !slices.Contains(cr.Status.TargetNamespaces, pod.Namespace) {
-		return fmt.Errorf("pod's namespace \"%s\" is not a target namespace of Cryostat \"%s\" in \"%s\"",
-			pod.Namespace, cr.Name, cr.Namespace)
+	if injectErr := r.injectAgent(ctx, pod, cr); injectErr != nil {
+		return r.injectionFailed(ctx, pod, target, cr, injectErr)
 	}
 
-	// Check whether TLS is enabled for this CR
-	crModel := model.FromCryostat(cr)
-	tlsEnabled := r.IsCertManagerEnabled(crModel)
+	r.log.Info("configured Cryostat agent for pod", "name", getPodName(pod), "namespace", pod.Namespace)
 
-	// Select target container
-	container, err := getTargetContainer(pod)
-	if err != nil {
-		return err
+	// Count this injection, unless the pod won't actually be created
+	if !isDryRun(ctx) {
+		metrics.RecordAgentInjection(cr.Namespace, cr.Name, pod.Namespace)
 	}
+	return nil
+}
 
-	// Determine the callback port number
-	port, err := getAgentCallbackPort(pod.Labels)
-	if err != nil {
-		return err
+// getInjectionTarget returns the Cryostat instance selected by the pod's labels,
+// or nil if the pod does not have labels selecting one
+func getInjectionTarget(pod *corev1.Pod) *types.NamespacedName {
+	if !metav1.HasLabel(pod.ObjectMeta, constants.AgentLabelCryostatName) || !metav1.HasLabel(pod.ObjectMeta, constants.AgentLabelCryostatNamespace) {
+		return nil
 	}
-
-	// Check whether write access has been disabled
-	write, err := hasWriteAccess(pod.Labels)
-	if err != nil {
-		return err
+	return &types.NamespacedName{
+		Name:      pod.Labels[constants.AgentLabelCryostatName],
+		Namespace: pod.Labels[constants.AgentLabelCryostatNamespace],
 	}
+}
 
-	harvesterTemplate := getHarvesterTemplate(pod.Labels)
-	harvesterPeriod, err := getHarvesterPeriod(pod.Labels)
+// selectCryostatFromNamespace labels the pod with the Cryostat instance selected by
+// its namespace, if any. Returns the selected Cryostat, or nil if the namespace does not select one.
+func (r *podMutator) selectCryostatFromNamespace(ctx context.Context, pod *corev1.Pod) (*types.NamespacedName, *injectionError) {
+	// Namespaces are looked up for every pod created in them, so use the cache
+	ns := &metav1.PartialObjectMetadata{}
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	err := r.client.Get(ctx, types.NamespacedName{Name: pod.Namespace}, ns)
 	if err != nil {
-		return err
+		return nil, newInjectionError(reasonInternalError, fmt.Errorf("failed to look up namespace \"%s\": %w", pod.Namespace, err))
 	}
-	harvesterMaxFiles, err := getHarvesterMaxFiles(pod.Labels)
-	if err != nil {
-		return err
+	value, pres := ns.Labels[constants.AgentLabelInject]
+	if !pres {
+		return nil, nil
 	}
-	harvesterExitMaxAge, err := getHarvesterExitMaxAge(pod.Labels)
+	crNamespace, crName, err := agentconfig.ParseInjectSelection(value)
 	if err != nil {
-		return err
+		return nil, newInjectionError(reasonInvalidConfiguration,
+			fmt.Errorf("namespace \"%s\" has an %w", pod.Namespace, err))
+	}
+
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
 	}
-	harvesterExitMaxSize, err := getHarvesterExitMaxSize(pod.Labels)
+	pod.Labels[constants.AgentLabelCryostatName] = crName
+	pod.Labels[constants.AgentLabelCryostatNamespace] = crNamespace
+	return &types.NamespacedName{Name: crName, Namespace: crNamespace}, nil
+}
+
+// getCABundleConfigMap returns the name of the ConfigMap that trust-manager synchronized the trusted
+// CA certificates into within the namespace, or an empty string if the agent should trust the CA
+// certificate within its certificate secret instead
+func (r *podMutator) getCABundleConfigMap(ctx context.Context, cr *model.CryostatInstance, namespace string) (string, *injectionError) {
+	if !common.IsTrustManagerCADistribution(cr) {
+		return "", nil
+	}
+	name := common.CABundleName(r.gvk, cr)
+	cm := &metav1.PartialObjectMetadata{}
+	cm.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	err := r.reader.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm)
 	if err != nil {
-		return err
+		// Either trust-manager is unavailable, or it has not yet synchronized the Bundle into this namespace
+		if kerrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", newInjectionError(reasonInternalError, fmt.Errorf("failed to look up config map \"%s\" in \"%s\": %w",
+			name, namespace, err))
+	}
+	return name, nil
+}
+
+func (r *podMutator) injectAgent(ctx context.Context, pod *corev1.Pod, cr *operatorv1beta2.Cryostat) *injectionError {
+	// Check if this pod is within a target namespace of the CR
+	if !slices.Contains(cr.Status.TargetNamespaces, pod.Namespace) {
+		return newInjectionError(reasonNamespaceNotTargeted,
+			fmt.Errorf("pod's namespace \"%s\" is not a target namespace of Cryostat \"%s\" in \"%s\"",
+				pod.Namespace, cr.Name, cr.Namespace))
+	}
+
+	// Check whether TLS is enabled for this CR
+	crModel := model.FromCryostat(cr)
+	tlsEnabled := r.IsTLSEnabled(crModel)
+
+	// Read and validate agent configuration from labels and annotations
+	config := agentconfig.Get(&pod.ObjectMeta)
+	labelOptions, parseErr := agentconfig.Parse(pod, config)
+	if parseErr != nil {
+		return newInjectionError(parseErr.Reason, parseErr.Err)
+	}
+	agentProperties, injectErr := r.getAgentProperties(ctx, pod, config, cr)
+	if injectErr != nil {
+		return injectErr
 	}
 
 	// Add init container
 	nonRoot := true
 	imageTag := r.getImageTag()
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
-		Name:            "cryostat-agent-init",
+		Name:            constants.AgentInitContainerName,
 		Image:           imageTag,
 		ImagePullPolicy: common.GetPullPolicy(imageTag),
 		Command:         []string{"cp", "-v", "/cryostat/agent/cryostat-agent-shaded.jar", constants.AgentJarPath},
@@ -170,10 +235,10 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		},
 	})
 
-	if metav1.HasLabel(pod.ObjectMeta, constants.AgentLabelSmartTriggersConfigMaps) {
-		// Mount the Smart Triggers volume
+	smartTriggersConfigMapNames := agentconfig.SmartTriggersConfigMapNames(config)
+	if len(smartTriggersConfigMapNames) > 0 {
+		// Add the Smart Triggers volumes
 		readOnlyMode := int32(0440)
-		smartTriggersConfigMapNames := getSmartTriggersConfigMapNames(pod.Labels)
 		for _, triggerMap := range smartTriggersConfigMapNames {
 			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
 				Name: "trigger-" + triggerMap,
@@ -187,9 +252,116 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 				},
 			})
 		}
+	}
+
+	caConfigMap := ""
+	tls13Only := r.config.FIPSEnabled
+	var cipherSuites []string
+	if tlsEnabled {
+		// Add the certificate volume
+		readOnlyMode := int32(0440)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "cryostat-agent-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  common.AgentCertificateName(r.gvk, crModel, pod.Namespace),
+					DefaultMode: &readOnlyMode,
+				},
+			},
+		})
+
+		// Add the volume for CA certificates distributed by trust-manager, if present
+		caConfigMap, injectErr = r.getCABundleConfigMap(ctx, crModel, pod.Namespace)
+		if injectErr != nil {
+			return injectErr
+		}
+		if len(caConfigMap) > 0 {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+				Name: "cryostat-agent-ca",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: caConfigMap,
+						},
+						DefaultMode: &readOnlyMode,
+					},
+				},
+			})
+		}
 
+		// Restrict the agent's TLS versions and ciphers to the TLS security profile
+		profile, err := common.GetTLSProfile(ctx, r.client, crModel, r.config.IsOpenShift)
+		if err != nil {
+			return newInjectionError(reasonInternalError, fmt.Errorf("failed to determine TLS security profile: %w", err))
+		}
+		tls13Only = tls13Only || profile.TLS13Only()
+		cipherSuites = profile.IANACiphers()
+	}
+
+	options := &agentContainerOptions{
+		cr:                   crModel,
+		namespace:            pod.Namespace,
+		tlsEnabled:           tlsEnabled,
+		caConfigMap:          caConfigMap,
+		tls13Only:            tls13Only,
+		cipherSuites:         cipherSuites,
+		write:                labelOptions.Write,
+		harvesterTemplate:    labelOptions.HarvesterTemplate,
+		harvesterPeriod:      labelOptions.HarvesterPeriod,
+		harvesterMaxFiles:    labelOptions.HarvesterMaxFiles,
+		harvesterExitMaxAge:  labelOptions.HarvesterExitMaxAge,
+		harvesterExitMaxSize: labelOptions.HarvesterExitMaxSize,
+		smartTriggers:        smartTriggersConfigMapNames,
+		javaOptsVar:          agentconfig.JavaOptionsVar(config),
+		logLevel:             agentconfig.LogLevel(config),
+		systemProperties:     labelOptions.SystemProperties,
+		agentProperties:      agentProperties,
+	}
+	for i, container := range labelOptions.Containers {
+		// Keep the previous port and application names when injecting a single container
+		portName := constants.AgentCallbackPortName
+		appName := fmt.Sprintf("$(%s)", podNameEnvVar)
+		if i > 0 {
+			portName = fmt.Sprintf("%s-%d", constants.AgentCallbackPortName, i)
+		}
+		if len(labelOptions.Containers) > 1 {
+			appName = fmt.Sprintf("$(%s)-%s", podNameEnvVar, container.Name)
+		}
+		injectErr := r.configureContainer(container, options, labelOptions.Ports[i], portName, appName)
+		if injectErr != nil {
+			return injectErr
+		}
+	}
+	return nil
+}
+
+// agentContainerOptions contains the agent configuration common to all containers in a pod
+type agentContainerOptions struct {
+	cr                   *model.CryostatInstance
+	namespace            string
+	tlsEnabled           bool
+	caConfigMap          string
+	tls13Only            bool
+	cipherSuites         []string
+	write                bool
+	harvesterTemplate    string
+	harvesterPeriod      *int32
+	harvesterMaxFiles    *int32
+	harvesterExitMaxAge  int32
+	harvesterExitMaxSize int32
+	smartTriggers        []string
+	javaOptsVar          string
+	logLevel             string
+	systemProperties     []string
+	agentProperties      map[string]string
+}
+
+// configureContainer configures a container to load the Cryostat agent
+func (r *podMutator) configureContainer(container *corev1.Container, options *agentContainerOptions,
+	port int32, portName string, appName string) *injectionError {
+	if len(options.smartTriggers) > 0 {
 		// Mount the triggers specified in the pod labels under /tmp/smart-triggers
-		for _, triggerMap := range getSmartTriggersConfigMapNames(pod.Labels) {
+		for _, triggerMap := range options.smartTriggers {
 			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 				Name:      "trigger-" + triggerMap,
 				MountPath: defaultSmartTriggersMount,
@@ -214,7 +386,7 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 	container.Env = append(container.Env,
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_BASEURI",
-			Value: cryostatURL(crModel, tlsEnabled),
+			Value: cryostatURL(options.cr, options.tlsEnabled),
 		},
 		corev1.EnvVar{
 			Name: podNameEnvVar,
@@ -226,7 +398,7 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_APP_NAME",
-			Value: fmt.Sprintf("$(%s)", podNameEnvVar),
+			Value: appName,
 		},
 		corev1.EnvVar{
 			Name: podIPEnvVar,
@@ -238,11 +410,11 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_API_WRITES_ENABLED",
-			Value: strconv.FormatBool(*write),
+			Value: strconv.FormatBool(options.write),
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_WEBSERVER_PORT",
-			Value: strconv.Itoa(int(*port)),
+			Value: strconv.Itoa(int(port)),
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_PUBLISH_FILL_STRATEGY",
@@ -250,7 +422,7 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_PUBLISH_CONTEXT_NAMESPACE",
-			Value: pod.Namespace,
+			Value: options.namespace,
 		},
 		corev1.EnvVar{
 			Name:  "CRYOSTAT_AGENT_PUBLISH_CONTEXT_NODETYPE",
@@ -262,28 +434,28 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		},
 	)
 
-	if len(harvesterTemplate) > 0 {
+	if len(options.harvesterTemplate) > 0 {
 		container.Env = append(container.Env,
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_HARVESTER_TEMPLATE",
-				Value: harvesterTemplate,
+				Value: options.harvesterTemplate,
 			},
 		)
 
-		if harvesterPeriod != nil {
+		if options.harvesterPeriod != nil {
 			container.Env = append(container.Env,
 				corev1.EnvVar{
 					Name:  "CRYOSTAT_AGENT_HARVESTER_PERIOD_MS",
-					Value: strconv.Itoa(int(*harvesterPeriod)),
+					Value: strconv.Itoa(int(*options.harvesterPeriod)),
 				},
 			)
 		}
 
-		if harvesterMaxFiles != nil {
+		if options.harvesterMaxFiles != nil {
 			container.Env = append(container.Env,
 				corev1.EnvVar{
 					Name:  "CRYOSTAT_AGENT_HARVESTER_MAX_FILES",
-					Value: strconv.Itoa(int(*harvesterMaxFiles)),
+					Value: strconv.Itoa(int(*options.harvesterMaxFiles)),
 				},
 			)
 		}
@@ -291,38 +463,26 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		container.Env = append(container.Env,
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_HARVESTER_EXIT_MAX_AGE_MS",
-				Value: strconv.Itoa(int(*harvesterExitMaxAge)),
+				Value: strconv.Itoa(int(options.harvesterExitMaxAge)),
 			},
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_HARVESTER_EXIT_MAX_SIZE_B",
-				Value: strconv.Itoa(int(*harvesterExitMaxSize)),
+				Value: strconv.Itoa(int(options.harvesterExitMaxSize)),
 			},
 		)
 	}
 
 	// Append a port for the callback server
 	container.Ports = append(container.Ports, corev1.ContainerPort{
-		Name:          constants.AgentCallbackPortName,
+		Name:          portName,
 		Protocol:      corev1.ProtocolTCP,
-		ContainerPort: *port,
+		ContainerPort: port,
 	})
 
 	// Append callback environment variables
-	container.Env = append(container.Env, r.callbackEnv(crModel, pod.Namespace, tlsEnabled, *port)...)
-
-	if tlsEnabled {
-		// Mount the certificate volume
-		readOnlyMode := int32(0440)
-		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
-			Name: "cryostat-agent-tls",
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName:  common.AgentCertificateName(r.gvk, crModel, pod.Namespace),
-					DefaultMode: &readOnlyMode,
-				},
-			},
-		})
+	container.Env = append(container.Env, r.callbackEnv(options.cr, options.namespace, options.tlsEnabled, port)...)
 
+	if options.tlsEnabled {
 		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
 			Name:      "cryostat-agent-tls",
 			MountPath: "/var/run/secrets/io.cryostat/cryostat-agent",
@@ -342,10 +502,19 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 		)
 
 		// Configure the Cryostat agent to trust the Cryostat CA
+		caPath := fmt.Sprintf("/var/run/secrets/io.cryostat/cryostat-agent/%s", constants.CAKey)
+		if len(options.caConfigMap) > 0 {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      "cryostat-agent-ca",
+				MountPath: "/var/run/secrets/io.cryostat/cryostat-agent-ca",
+				ReadOnly:  true,
+			})
+			caPath = fmt.Sprintf("/var/run/secrets/io.cryostat/cryostat-agent-ca/%s", constants.CAKey)
+		}
 		container.Env = append(container.Env,
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_TRUSTSTORE_CERT_0__PATH",
-				Value: fmt.Sprintf("/var/run/secrets/io.cryostat/cryostat-agent/%s", constants.CAKey),
+				Value: caPath,
 			},
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_TRUSTSTORE_CERT_0__TYPE",
@@ -377,7 +546,7 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 			},
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_KEY_TYPE",
-				Value: "RSA",
+				Value: getAgentKeyType(options.cr),
 			},
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_KEY_ALIAS",
@@ -393,8 +562,9 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 			})
 	}
 
-	if r.config.FIPSEnabled {
-		// Force usage for TLSv1.3 for FIPS compatibility
+	if options.tls13Only {
+		// Force usage of TLSv1.3 for FIPS compatibility, or if required by the TLS security profile.
+		// Otherwise, the agent's default of TLSv1.2 already satisfies the minimum version of the profile.
 		container.Env = append(container.Env,
 			corev1.EnvVar{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_VERSION",
@@ -406,22 +576,63 @@ func (r *podMutator) Default(ctx context.Context, obj runtime.Object) error {
 			},
 		)
 	}
+	if len(options.cipherSuites) > 0 {
+		// Java uses the IANA names of cipher suites
+		cipherSuites := strings.Join(options.cipherSuites, ",")
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_CIPHER_SUITES",
+				Value: cipherSuites,
+			},
+			corev1.EnvVar{
+				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_CIPHER_SUITES",
+				Value: cipherSuites,
+			},
+		)
+	}
 
 	// Inject agent using JAVA_TOOL_OPTIONS or specified variable, appending to any existing value
-	extended, err := extendJavaOptsVar(container.Env, getJavaOptionsVar(pod.Labels), getLogLevel(pod.Labels))
+	extended, err := extendJavaOptsVar(container.Env, options.javaOptsVar, options.logLevel, options.systemProperties)
 	if err != nil {
-		return err
+		return newInjectionError(reasonJavaOptionsNotExtensible, err)
 	}
 	container.Env = extended
 
-	// Use GenerateName for logging if no explicit Name is given
-	podName := pod.Name
-	if len(podName) == 0 {
-		podName = pod.GenerateName
+	// Add any remaining agent properties not already set by the operator or the container
+	container.Env = appendAgentProperties(container.Env, options.agentProperties)
+	return nil
+}
+
+// getAgentProperties returns the additional agent properties for a pod as environment variables.
+// Properties from the pod's agent ConfigMap take precedence over the Cryostat's default properties.
+func (r *podMutator) getAgentProperties(ctx context.Context, pod *corev1.Pod, config map[string]string,
+	cr *operatorv1beta2.Cryostat) (map[string]string, *injectionError) {
+	result := map[string]string{}
+	if cr.Spec.AgentOptions != nil {
+		err := mergeAgentProperties(result, cr.Spec.AgentOptions.DefaultProperties, "default agent properties")
+		if err != nil {
+			return nil, newInjectionError(reasonInvalidConfiguration, err)
+		}
 	}
-	r.log.Info("configured Cryostat agent for pod", "name", podName, "namespace", pod.Namespace)
 
-	return nil
+	name, pres := config[constants.AgentLabelConfigMap]
+	if pres {
+		// Use an uncached read, since the ConfigMap may be in any namespace
+		cm := &corev1.ConfigMap{}
+		err := r.reader.Get(ctx, types.NamespacedName{Name: name, Namespace: pod.Namespace}, cm)
+		if err != nil {
+			reason := reasonInternalError
+			if kerrors.IsNotFound(err) {
+				reason = reasonAgentConfigNotFound
+			}
+			return nil, newInjectionError(reason, fmt.Errorf("failed to read agent ConfigMap \"%s\": %s", name, err.Error()))
+		}
+		err = mergeAgentProperties(result, cm.Data, fmt.Sprintf("ConfigMap \"%s\"", name))
+		if err != nil {
+			return nil, newInjectionError(reasonInvalidConfiguration, err)
+		}
+	}
+	return result, nil
 }
 
 func (r *podMutator) callbackEnv(cr *model.CryostatInstance, namespace string, tls bool, containerPort int32) []corev1.EnvVar {
@@ -471,13 +682,14 @@ func (r *podMutator) getImageTag() string {
 	return *r.config.InitImageTag
 }
 
-func extendJavaOptsVar(envs []corev1.EnvVar, javaOptsVar string, logLevel string) ([]corev1.EnvVar, error) {
+func extendJavaOptsVar(envs []corev1.EnvVar, javaOptsVar string, logLevel string, systemProperties []string) ([]corev1.EnvVar, error) {
 	existing, err := findJavaOptsVar(envs, javaOptsVar)
 	if err != nil {
 		return nil, err
 	}
 
-	agentArgLine := fmt.Sprintf("%s=%s=%s", agentArg, agentLogLevelProp, logLevel)
+	agentArgLine := strings.Join(append([]string{fmt.Sprintf("%s=%s=%s", agentArg, agentLogLevelProp, logLevel)},
+		systemProperties...), " ")
 	if existing != nil {
 		existing.Value += " " + agentArgLine
 	} else {
@@ -501,3 +713,11 @@ func findJavaOptsVar(envs []corev1.EnvVar, javaOptsVar string) (*corev1.EnvVar,
 	}
 	return nil, nil
 }
+
+// getAgentKeyType returns the Java key algorithm name of the agent certificate's private key
+func getAgentKeyType(cr *model.CryostatInstance) string {
+	if resources.GetCertificateKeyAlgorithm(cr) == "ECDSA" {
+		return "EC"
+	}
+	return "RSA"
+}
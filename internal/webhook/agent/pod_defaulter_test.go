@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -181,6 +182,28 @@ var _ = Describe("PodDefaulter", func() {
 				ExpectPod()
 			})
 
+			Context("with trust-manager CA distribution", func() {
+				Context("with the ConfigMap synchronized", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithTrustManager().Object, t.NewTrustManagerConfigMap())
+						originalPod = t.NewPod()
+						expectedPod = t.NewMutatedPodTrustManager()
+					})
+
+					ExpectPod()
+				})
+
+				Context("without the ConfigMap synchronized", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithTrustManager().Object)
+						originalPod = t.NewPod()
+						expectedPod = t.NewMutatedPod()
+					})
+
+					ExpectPod()
+				})
+			})
+
 			Context("With Smart Triggers", func() {
 				BeforeEach(func() {
 					t.objs = append(t.objs, t.NewCryostat().Object)
@@ -256,6 +279,47 @@ var _ = Describe("PodDefaulter", func() {
 				ExpectPod()
 			})
 
+			Context("in a namespace selecting the Cryostat", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewNamespaceWithInjectLabel(), t.NewOtherNamespace(otherNS), t.NewCryostat().Object,
+					}
+					originalPod = t.NewPodNoCryostatLabels()
+					expectedPod = t.NewMutatedPod()
+				})
+
+				ExpectPod()
+
+				It("should add labels selecting the Cryostat", func() {
+					actual := t.getPod(expectedPod)
+					Expect(actual.Labels).To(HaveKeyWithValue("cryostat.io/name", t.Name))
+					Expect(actual.Labels).To(HaveKeyWithValue("cryostat.io/namespace", t.Namespace))
+				})
+
+				Context("with a pod that opts out", func() {
+					BeforeEach(func() {
+						originalPod = t.NewPodInjectionDisabled()
+						// Should not mutate
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+			})
+
+			Context("in a namespace with an invalid selection", func() {
+				BeforeEach(func() {
+					t.objs = []ctrlclient.Object{
+						t.NewNamespaceWithInvalidInjectLabel(), t.NewOtherNamespace(otherNS), t.NewCryostat().Object,
+					}
+					originalPod = t.NewPodNoCryostatLabels()
+					// Should fail
+					expectedPod = originalPod
+				})
+
+				ExpectPod()
+			})
+
 			Context("with custom image tag", func() {
 				var saveOSUtils common.OSUtils
 
@@ -338,6 +402,94 @@ var _ = Describe("PodDefaulter", func() {
 				ExpectPod()
 			})
 
+			Context("with agent annotations", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+					originalPod = t.NewPodAnnotations()
+					expectedPod = t.NewMutatedPodAnnotations()
+				})
+
+				ExpectPod()
+			})
+
+			Context("with an agent ConfigMap", func() {
+				Context("that exists", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostatWithDefaultAgentProperties().Object, t.NewAgentConfigMap())
+						originalPod = t.NewPodAgentConfigLabel()
+						expectedPod = t.NewMutatedPodAgentConfig()
+					})
+
+					ExpectPod()
+				})
+
+				Context("that sets a non-agent property", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object, t.NewAgentConfigMapInvalid())
+						originalPod = t.NewPodAgentConfigLabel()
+						// Should fail
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+
+				Context("that doesn't exist", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodAgentConfigLabel()
+						// Should fail
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+			})
+
+			Context("with a system properties annotation", func() {
+				Context("that is valid", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodSystemPropertiesAnnotation()
+						expectedPod = t.NewMutatedPodSystemProperties()
+					})
+
+					ExpectPod()
+				})
+
+				Context("that contains whitespace", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodSystemPropertiesAnnotationInvalid()
+						// Should fail
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+			})
+
+			Context("with a harvester template annotation listing multiple templates", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+					originalPod = t.NewPodHarvesterTemplatesAnnotation()
+					// Should fail
+					expectedPod = originalPod
+				})
+
+				ExpectPod()
+			})
+
+			Context("with a container already declaring the callback port", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+					originalPod = t.NewPodCallbackPortDeclared()
+					expectedPod = t.NewMutatedPodCallbackPortDeclared()
+				})
+
+				ExpectPod()
+			})
+
 			Context("with a custom callback port label", func() {
 				Context("that is valid", func() {
 					BeforeEach(func() {
@@ -427,6 +579,50 @@ var _ = Describe("PodDefaulter", func() {
 				})
 			})
 
+			Context("with multiple containers in the container label", func() {
+				Context("with default ports", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodMultiContainerLabel()
+						expectedPod = t.NewMutatedPodMultiContainerLabel()
+					})
+
+					ExpectPod()
+				})
+
+				Context("with a port for each container", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodMultiContainerPorts()
+						expectedPod = t.NewMutatedPodMultiContainerPorts()
+					})
+
+					ExpectPod()
+				})
+
+				Context("with the wrong number of ports", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodMultiContainerPortsMismatch()
+						// Should fail
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+
+				Context("with a port conflict", func() {
+					BeforeEach(func() {
+						t.objs = append(t.objs, t.NewCryostat().Object)
+						originalPod = t.NewPodMultiContainerPortConflict()
+						// Should fail
+						expectedPod = originalPod
+					})
+
+					ExpectPod()
+				})
+			})
+
 			Context("with a custom read-only label", func() {
 				Context("that is valid", func() {
 					BeforeEach(func() {
@@ -518,6 +714,16 @@ var _ = Describe("PodDefaulter", func() {
 				})
 			})
 
+			Context("with ECDSA certificates", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithECDSACertificates().Object)
+					originalPod = t.NewPod()
+					expectedPod = t.NewMutatedPodECDSAKey()
+				})
+
+				ExpectPod()
+			})
+
 			Context("with a custom resource requirements", func() {
 				Context("that are valid", func() {
 					BeforeEach(func() {
@@ -566,6 +772,88 @@ var _ = Describe("PodDefaulter", func() {
 
 				ExpectPod()
 			})
+
+			Context("with a TLS security profile requiring TLSv1.3", func() {
+				BeforeEach(func() {
+					t.ModernTLSProfile = true
+
+					t.objs = append(t.objs, t.NewCryostatWithTLSSecurityProfile().Object)
+					originalPod = t.NewPod()
+					expectedPod = t.NewMutatedPod()
+				})
+
+				ExpectPod()
+			})
+		})
+
+		Context("with an injection failure", func() {
+			var deploy *appsv1.Deployment
+			var createErr error
+
+			BeforeEach(func() {
+				deploy = t.NewOwnerDeployment()
+			})
+
+			JustBeforeEach(func() {
+				cr := t.getCryostatInstance()
+				cr.Status.TargetNamespaces = cr.Spec.TargetNamespaces
+				t.updateCryostatInstanceStatus(cr)
+
+				err := t.client.Create(ctx, deploy)
+				Expect(err).ToNot(HaveOccurred())
+				rs := t.NewOwnerReplicaSet(deploy)
+				err = t.client.Create(ctx, rs)
+				Expect(err).ToNot(HaveOccurred())
+				t.objs = append(t.objs, deploy, rs)
+
+				originalPod = t.NewPodOwnedBy(rs)
+				createErr = t.client.Create(ctx, originalPod)
+			})
+
+			ExpectEvent := func() {
+				It("should record an Event on the Deployment", func() {
+					Eventually(func() []corev1.Event {
+						events := &corev1.EventList{}
+						err := t.client.List(ctx, events, ctrlclient.InNamespace(deploy.Namespace))
+						Expect(err).ToNot(HaveOccurred())
+						result := []corev1.Event{}
+						for _, event := range events.Items {
+							if event.InvolvedObject.Kind == "Deployment" && event.InvolvedObject.UID == deploy.UID &&
+								event.Reason == "AgentInjectionFailed" {
+								result = append(result, event)
+							}
+						}
+						return result
+					}).ShouldNot(BeEmpty())
+				})
+			}
+
+			Context("with the default failure policy", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+				})
+
+				It("should admit the pod unmodified", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+					actual := t.getPod(originalPod)
+					Expect(actual.Spec.InitContainers).To(BeEmpty())
+				})
+
+				ExpectEvent()
+			})
+
+			Context("with the Fail failure policy", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostatWithInjectionFailurePolicy("Fail").Object)
+				})
+
+				It("should reject the pod", func() {
+					Expect(createErr).To(HaveOccurred())
+					Expect(createErr.Error()).To(ContainSubstring("ContainerNotFound"))
+				})
+
+				ExpectEvent()
+			})
 		})
 
 		Context("with a missing Cryostat CR", func() {
@@ -24,9 +24,11 @@ import (
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
 	"github.com/cryostatio/cryostat-operator/internal/test"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type AgentWebhookTestResources struct {
@@ -262,6 +264,36 @@ func (r *AgentWebhookTestResources) NewPodNoNamespaceLabel() *corev1.Pod {
 	return pod
 }
 
+func (r *AgentWebhookTestResources) NewPodNoCryostatLabels() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Labels = nil
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodInjectionDisabled() *corev1.Pod {
+	pod := r.NewPodNoCryostatLabels()
+	pod.Labels = map[string]string{
+		"cryostat.io/inject": "false",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewNamespaceWithInjectLabel() *corev1.Namespace {
+	ns := r.NewNamespace()
+	ns.Labels = map[string]string{
+		"cryostat.io/inject": r.Namespace + "." + r.Name,
+	}
+	return ns
+}
+
+func (r *AgentWebhookTestResources) NewNamespaceWithInvalidInjectLabel() *corev1.Namespace {
+	ns := r.NewNamespace()
+	ns.Labels = map[string]string{
+		"cryostat.io/inject": r.Name,
+	}
+	return ns
+}
+
 func (r *AgentWebhookTestResources) NewPodLogLevelLabel() *corev1.Pod {
 	pod := r.NewPod()
 	pod.Labels["cryostat.io/log-level"] = "trace"
@@ -274,6 +306,86 @@ func (r *AgentWebhookTestResources) NewPodSmartTriggersLabel() *corev1.Pod {
 	return pod
 }
 
+func (r *AgentWebhookTestResources) NewPodAnnotations() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Labels["cryostat.io/callback-port"] = "9998"
+	pod.Labels["cryostat.io/log-level"] = "trace"
+	pod.Annotations = map[string]string{
+		"cryostat.io/callback-port":  "9999",
+		"cryostat.io/smart-triggers": "triggers, more-triggers",
+		// Should be ignored
+		"cryostat.io/name": "other-cryostat",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodSystemPropertiesAnnotation() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Annotations = map[string]string{
+		"cryostat.io/java-system-properties": `
+# Settings for the agent
+cryostat.agent.harvester.max-age-ms=60000
+cryostat.agent.app.name = my-app
+`,
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodSystemPropertiesAnnotationInvalid() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Annotations = map[string]string{
+		"cryostat.io/java-system-properties": "cryostat.agent.app.name=my app",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodHarvesterTemplatesAnnotation() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Annotations = map[string]string{
+		"cryostat.io/harvester-template": "Continuous, Profiling",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodAgentConfigLabel() *corev1.Pod {
+	pod := r.NewPod()
+	pod.Labels["cryostat.io/agent-config"] = "agent-config"
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewAgentConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "agent-config",
+			Namespace: r.Namespace,
+		},
+		Data: map[string]string{
+			"cryostat.agent.webclient.connect.timeout-ms": "2000",
+			"CRYOSTAT_AGENT_REGISTRATION_RETRY_MS":        "10000",
+			// Should be ignored in favour of the value configured by the operator
+			"cryostat.agent.api.writes-enabled": "false",
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewTrustManagerConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.GetTrustManagerBundleName(),
+			Namespace: r.Namespace,
+		},
+		Data: map[string]string{
+			"ca.crt": "trusted CA certificates",
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewAgentConfigMapInvalid() *corev1.ConfigMap {
+	cm := r.NewAgentConfigMap()
+	cm.Data["JAVA_TOOL_OPTIONS"] = "-Xmx1g"
+	return cm
+}
+
 func (r *AgentWebhookTestResources) NewPodPortLabel() *corev1.Pod {
 	pod := r.NewPod()
 	pod.Labels["cryostat.io/callback-port"] = "9998"
@@ -298,6 +410,52 @@ func (r *AgentWebhookTestResources) NewPodContainerLabel() *corev1.Pod {
 	return pod
 }
 
+func (r *AgentWebhookTestResources) NewPodMultiContainerLabel() *corev1.Pod {
+	pod := r.NewPodMultiContainer()
+	pod.Labels["cryostat.io/container"] = "test,other"
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodMultiContainerPorts() *corev1.Pod {
+	pod := r.NewPodMultiContainerLabel()
+	pod.Annotations = map[string]string{
+		"cryostat.io/callback-port": "9000, 9100",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodMultiContainerPortsMismatch() *corev1.Pod {
+	pod := r.NewPodMultiContainerLabel()
+	pod.Annotations = map[string]string{
+		"cryostat.io/callback-port": "9000,9100,9200",
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodMultiContainerPortConflict() *corev1.Pod {
+	pod := r.NewPodMultiContainerLabel()
+	// Conflicts with the second container's default callback port
+	pod.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{
+			Name:          "http",
+			ContainerPort: 9978,
+		},
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewPodCallbackPortDeclared() *corev1.Pod {
+	pod := r.NewPod()
+	// Already declares the default callback port
+	pod.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{
+			Name:          "agent",
+			ContainerPort: 9977,
+		},
+	}
+	return pod
+}
+
 func (r *AgentWebhookTestResources) NewPodContainerBadLabel() *corev1.Pod {
 	pod := r.NewPodMultiContainer()
 	pod.Labels["cryostat.io/container"] = "wrong"
@@ -407,8 +565,16 @@ type mutatedPodOptions struct {
 	harvesterExitAge  int32
 	harvesterExitSize int32
 	smartTriggers     string
-	scheme            string
-	resources         *corev1.ResourceRequirements
+	systemProperties  string
+	extraEnv          []corev1.EnvVar
+	appName           string
+	callbackPortName  string
+	// Callback port of the second container, when injecting multiple containers
+	secondCallbackPort int32
+	scheme             string
+	resources          *corev1.ResourceRequirements
+	keyType            string
+	caConfigMap        string
 	// Function to produce mutated container array
 	containersFunc func(*AgentWebhookTestResources, *mutatedPodOptions) []corev1.Container
 }
@@ -432,6 +598,12 @@ func (r *AgentWebhookTestResources) setDefaultMutatedPodOptions(options *mutated
 	if options.callbackPort == 0 {
 		options.callbackPort = 9977
 	}
+	if len(options.appName) == 0 {
+		options.appName = "$(CRYOSTAT_AGENT_POD_NAME)"
+	}
+	if len(options.callbackPortName) == 0 {
+		options.callbackPortName = "cryostat-cb"
+	}
 	if options.harvesterExitAge == 0 {
 		options.harvesterExitAge = 30000
 	}
@@ -456,6 +628,9 @@ func (r *AgentWebhookTestResources) setDefaultMutatedPodOptions(options *mutated
 			},
 		}
 	}
+	if len(options.keyType) == 0 {
+		options.keyType = "RSA"
+	}
 	options.scheme = "https"
 	if !r.TLS {
 		options.scheme = "http"
@@ -469,6 +644,12 @@ func (r *AgentWebhookTestResources) NewMutatedPod() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{})
 }
 
+func (r *AgentWebhookTestResources) NewMutatedPodTrustManager() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		caConfigMap: r.GetTrustManagerBundleName(),
+	})
+}
+
 func (r *AgentWebhookTestResources) NewMutatedPodJavaToolOptions() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{
 		javaOptionsValue: "-Dexisting=var ",
@@ -513,12 +694,37 @@ func (r *AgentWebhookTestResources) NewMutatedPodCallbackPort() *corev1.Pod {
 	})
 }
 
+func (r *AgentWebhookTestResources) NewMutatedPodCallbackPortDeclared() *corev1.Pod {
+	pod := r.NewMutatedPod()
+	pod.Spec.Containers[0].Ports = append([]corev1.ContainerPort{
+		{
+			Name:          "agent",
+			ContainerPort: 9977,
+		},
+	}, pod.Spec.Containers[0].Ports...)
+	return pod
+}
+
 func (r *AgentWebhookTestResources) NewMutatedPodMultiContainer() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{
 		containersFunc: newMutatedMultiContainers,
 	})
 }
 
+func (r *AgentWebhookTestResources) NewMutatedPodMultiContainerLabel() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		containersFunc: newMutatedMultiContainersBoth,
+	})
+}
+
+func (r *AgentWebhookTestResources) NewMutatedPodMultiContainerPorts() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		callbackPort:       9000,
+		secondCallbackPort: 9100,
+		containersFunc:     newMutatedMultiContainersBoth,
+	})
+}
+
 func (r *AgentWebhookTestResources) NewMutatedPodContainerLabel() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{
 		containersFunc: newMutatedMultiContainersLabel,
@@ -583,12 +789,71 @@ func (r *AgentWebhookTestResources) NewMutatedPodHarvesterTemplateAllSettings()
 	})
 }
 
+func (r *AgentWebhookTestResources) NewMutatedPodAnnotations() *corev1.Pod {
+	pod := r.newMutatedPod(&mutatedPodOptions{
+		callbackPort:  9999,
+		logLevel:      "trace",
+		smartTriggers: "triggers",
+	})
+	// Add the second smart triggers ConfigMap
+	readOnlyMode := int32(0440)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: "trigger-more-triggers",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "more-triggers",
+				},
+				DefaultMode: &readOnlyMode,
+			},
+		},
+	})
+	container := &pod.Spec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      "trigger-more-triggers",
+		MountPath: "/tmp/cryostat-agent/smart-triggers",
+		ReadOnly:  true,
+	})
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewMutatedPodSystemProperties() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		systemProperties: " -Dcryostat.agent.harvester.max-age-ms=60000 -Dcryostat.agent.app.name=my-app",
+	})
+}
+
+func (r *AgentWebhookTestResources) NewMutatedPodAgentConfig() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		extraEnv: []corev1.EnvVar{
+			{
+				Name:  "CRYOSTAT_AGENT_REGISTRATION_RETRY_MS",
+				Value: "10000",
+			},
+			{
+				Name:  "CRYOSTAT_AGENT_WEBCLIENT_CONNECT_TIMEOUT_MS",
+				Value: "2000",
+			},
+			{
+				Name:  "CRYOSTAT_AGENT_WEBCLIENT_RESPONSE_TIMEOUT_MS",
+				Value: "5000",
+			},
+		},
+	})
+}
+
 func (r *AgentWebhookTestResources) NewMutatedPodWithSmartTriggers() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{
 		smartTriggers: "triggers",
 	})
 }
 
+func (r *AgentWebhookTestResources) NewMutatedPodECDSAKey() *corev1.Pod {
+	return r.newMutatedPod(&mutatedPodOptions{
+		keyType: "EC",
+	})
+}
+
 func (r *AgentWebhookTestResources) NewMutatedPodResources() *corev1.Pod {
 	return r.newMutatedPod(&mutatedPodOptions{
 		resources: &corev1.ResourceRequirements{
@@ -693,6 +958,20 @@ func (r *AgentWebhookTestResources) newMutatedPod(options *mutatedPodOptions) *c
 					},
 				},
 			})
+		if len(options.caConfigMap) > 0 {
+			pod.Spec.Volumes = append(pod.Spec.Volumes,
+				corev1.Volume{
+					Name: "cryostat-agent-ca",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: options.caConfigMap,
+							},
+							DefaultMode: &[]int32{0440}[0],
+						},
+					},
+				})
+		}
 	}
 
 	if len(options.smartTriggers) > 0 {
@@ -723,6 +1002,20 @@ func newMutatedMultiContainers(r *AgentWebhookTestResources, options *mutatedPod
 	return []corev1.Container{*r.newMutatedContainer(&containers[0], options), containers[1]}
 }
 
+func newMutatedMultiContainersBoth(r *AgentWebhookTestResources, options *mutatedPodOptions) []corev1.Container {
+	containers := r.NewPodMultiContainer().Spec.Containers
+	first := *options
+	first.appName = "$(CRYOSTAT_AGENT_POD_NAME)-test"
+	second := *options
+	second.appName = "$(CRYOSTAT_AGENT_POD_NAME)-other"
+	second.callbackPortName = "cryostat-cb-1"
+	second.callbackPort = options.callbackPort + 1
+	if options.secondCallbackPort != 0 {
+		second.callbackPort = options.secondCallbackPort
+	}
+	return []corev1.Container{*r.newMutatedContainer(&containers[0], &first), *r.newMutatedContainer(&containers[1], &second)}
+}
+
 func newMutatedMultiContainersLabel(r *AgentWebhookTestResources, options *mutatedPodOptions) []corev1.Container {
 	containers := r.NewPodMultiContainer().Spec.Containers
 	return []corev1.Container{containers[0], *r.newMutatedContainer(&containers[1], options)}
@@ -748,7 +1041,7 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 			},
 			{
 				Name:  "CRYOSTAT_AGENT_APP_NAME",
-				Value: "$(CRYOSTAT_AGENT_POD_NAME)",
+				Value: options.appName,
 			},
 			{
 				Name: "CRYOSTAT_AGENT_POD_IP",
@@ -785,12 +1078,12 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 			},
 			{
 				Name:  options.javaOptionsName,
-				Value: options.javaOptionsValue + fmt.Sprintf("-javaagent:"+constants.AgentJarPath+"=io.cryostat.agent.shaded.org.slf4j.simpleLogger.defaultLogLevel=%s", options.logLevel),
+				Value: options.javaOptionsValue + fmt.Sprintf("-javaagent:"+constants.AgentJarPath+"=io.cryostat.agent.shaded.org.slf4j.simpleLogger.defaultLogLevel=%s", options.logLevel) + options.systemProperties,
 			},
 		}...),
 		Ports: []corev1.ContainerPort{
 			{
-				Name:          "cryostat-cb",
+				Name:          options.callbackPortName,
 				Protocol:      corev1.ProtocolTCP,
 				ContainerPort: options.callbackPort,
 			},
@@ -819,6 +1112,16 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 	}
 
 	if r.TLS {
+		caPath := "/var/run/secrets/io.cryostat/cryostat-agent/ca.crt"
+		if len(options.caConfigMap) > 0 {
+			caPath = "/var/run/secrets/io.cryostat/cryostat-agent-ca/ca.crt"
+			container.VolumeMounts = append(container.VolumeMounts,
+				corev1.VolumeMount{
+					Name:      "cryostat-agent-ca",
+					MountPath: "/var/run/secrets/io.cryostat/cryostat-agent-ca",
+					ReadOnly:  true,
+				})
+		}
 		tlsEnvs := []corev1.EnvVar{
 			{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_CLIENT_AUTH_CERT_PATH",
@@ -830,7 +1133,7 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 			},
 			{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_TRUSTSTORE_CERT_0__PATH",
-				Value: "/var/run/secrets/io.cryostat/cryostat-agent/ca.crt",
+				Value: caPath,
 			},
 			{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_TRUSTSTORE_CERT_0__TYPE",
@@ -858,7 +1161,7 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 			},
 			{
 				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_KEY_TYPE",
-				Value: "RSA",
+				Value: options.keyType,
 			},
 			{
 				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_KEY_ALIAS",
@@ -896,7 +1199,7 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 			})
 	}
 
-	if r.IsFIPS {
+	if r.IsFIPS || (r.TLS && r.ModernTLSProfile) {
 		fipsEnvs := []corev1.EnvVar{
 			{
 				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_VERSION",
@@ -909,6 +1212,19 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 		}
 		container.Env = append(container.Env, fipsEnvs...)
 	}
+	if r.TLS {
+		ciphers := r.GetJavaTLSCiphers()
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  "CRYOSTAT_AGENT_WEBCLIENT_TLS_CIPHER_SUITES",
+				Value: ciphers,
+			},
+			corev1.EnvVar{
+				Name:  "CRYOSTAT_AGENT_WEBSERVER_TLS_CIPHER_SUITES",
+				Value: ciphers,
+			},
+		)
+	}
 
 	var callbackEnvs []corev1.EnvVar
 	if r.DisableAgentHostnameVerify {
@@ -978,9 +1294,23 @@ func (r *AgentWebhookTestResources) newMutatedContainer(original *corev1.Contain
 		)
 	}
 
+	container.Env = append(container.Env, options.extraEnv...)
 	return container
 }
 
+func (r *AgentWebhookTestResources) NewCryostatWithECDSACertificates() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.TLSOptions = &operatorv1beta2.TLSOptions{
+		Certificates: &operatorv1beta2.CertificateOptions{
+			PrivateKey: &operatorv1beta2.PrivateKeyOptions{
+				Algorithm: "ECDSA",
+				Size:      384,
+			},
+		},
+	}
+	return cr
+}
+
 func (r *AgentWebhookTestResources) NewCryostatWithAgentInitResources() *model.CryostatInstance {
 	cr := r.NewCryostat()
 	cr.Spec.AgentOptions = &operatorv1beta2.AgentOptions{
@@ -1023,3 +1353,222 @@ func (r *AgentWebhookTestResources) NewCryostatWithAgentInitResourcesNoLimit() *
 	}
 	return cr
 }
+
+func (r *AgentWebhookTestResources) NewCryostatWithInjectionFailurePolicy(policy string) *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.AgentOptions = &operatorv1beta2.AgentOptions{
+		InjectionFailurePolicy: &policy,
+	}
+	return cr
+}
+
+func (r *AgentWebhookTestResources) NewPodOwnedBy(owner *appsv1.ReplicaSet) *corev1.Pod {
+	pod := r.NewPodContainerBadLabel()
+	pod.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("ReplicaSet")),
+	}
+	return pod
+}
+
+func (r *AgentWebhookTestResources) NewDeploymentAnnotations() *appsv1.Deployment {
+	deploy := r.NewDeployment()
+	deploy.Annotations["cryostat.io/smart-triggers"] = "someConfigMap,otherConfigMap"
+	deploy.Annotations["cryostat.io/java-system-properties"] = "cryostat.agent.app.name=my-app"
+	return deploy
+}
+
+func (r *AgentWebhookTestResources) NewStatefulSet() *appsv1.StatefulSet {
+	deploy := r.NewDeployment()
+	return &appsv1.StatefulSet{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: appsv1.StatefulSetSpec{
+			Template: deploy.Spec.Template,
+			Selector: deploy.Spec.Selector,
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewMutatedStatefulSet() *appsv1.StatefulSet {
+	deploy := r.NewMutatedDeployment()
+	return &appsv1.StatefulSet{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: appsv1.StatefulSetSpec{
+			Template: deploy.Spec.Template,
+			Selector: deploy.Spec.Selector,
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewDaemonSet() *appsv1.DaemonSet {
+	deploy := r.NewDeployment()
+	return &appsv1.DaemonSet{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: appsv1.DaemonSetSpec{
+			Template: deploy.Spec.Template,
+			Selector: deploy.Spec.Selector,
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewMutatedDaemonSet() *appsv1.DaemonSet {
+	deploy := r.NewMutatedDeployment()
+	return &appsv1.DaemonSet{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: appsv1.DaemonSetSpec{
+			Template: deploy.Spec.Template,
+			Selector: deploy.Spec.Selector,
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewJob() *batchv1.Job {
+	deploy := r.NewDeployment()
+	return &batchv1.Job{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: batchv1.JobSpec{
+			Template: newJobPodTemplate(deploy.Spec.Template),
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewMutatedJob() *batchv1.Job {
+	deploy := r.NewMutatedDeployment()
+	return &batchv1.Job{
+		ObjectMeta: deploy.ObjectMeta,
+		Spec: batchv1.JobSpec{
+			Template: newJobPodTemplate(deploy.Spec.Template),
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewCronJob() *batchv1.CronJob {
+	job := r.NewJob()
+	return &batchv1.CronJob{
+		ObjectMeta: job.ObjectMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: job.Spec,
+			},
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewMutatedCronJob() *batchv1.CronJob {
+	job := r.NewMutatedJob()
+	return &batchv1.CronJob{
+		ObjectMeta: job.ObjectMeta,
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 * * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: job.Spec,
+			},
+		},
+	}
+}
+
+func newJobPodTemplate(template corev1.PodTemplateSpec) corev1.PodTemplateSpec {
+	// Jobs may not restart pods always
+	template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	return template
+}
+
+func (r *AgentWebhookTestResources) NewMutatedDeploymentAnnotations() *appsv1.Deployment {
+	deploy := r.NewMutatedDeployment()
+	deploy.Annotations["cryostat.io/smart-triggers"] = "someConfigMap,otherConfigMap"
+	deploy.Annotations["cryostat.io/java-system-properties"] = "cryostat.agent.app.name=my-app"
+	deploy.Spec.Template.Annotations = map[string]string{
+		"cryostat.io/smart-triggers":         "someConfigMap,otherConfigMap",
+		"cryostat.io/java-system-properties": "cryostat.agent.app.name=my-app",
+	}
+	return deploy
+}
+
+func (r *AgentWebhookTestResources) NewOwnerDeployment() *appsv1.Deployment {
+	deploy := r.NewDeployment()
+	deploy.Labels = map[string]string{
+		"app": r.Name,
+	}
+	return deploy
+}
+
+func (r *AgentWebhookTestResources) NewOwnerReplicaSet(owner *appsv1.Deployment) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      owner.Name + "-5d8f9c7b6",
+			Namespace: owner.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owner, appsv1.SchemeGroupVersion.WithKind("Deployment")),
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: owner.Spec.Selector,
+			Template: owner.Spec.Template,
+		},
+	}
+}
+
+func (r *AgentWebhookTestResources) NewCryostatWithDefaultAgentProperties() *model.CryostatInstance {
+	cr := r.NewCryostat()
+	cr.Spec.AgentOptions = &operatorv1beta2.AgentOptions{
+		DefaultProperties: map[string]string{
+			"cryostat.agent.webclient.connect.timeout-ms":  "1000",
+			"cryostat.agent.webclient.response.timeout-ms": "5000",
+		},
+	}
+	return cr
+}
+
+func (r *AgentWebhookTestResources) NewRollout() *unstructured.Unstructured {
+	rollout := r.newRollout()
+	rollout.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				"app": "someApplication",
+			},
+		},
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"app": "someApplication",
+				},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{
+						"name":  "other-container",
+						"image": "incorrect/image:latest",
+					},
+				},
+			},
+		},
+	}
+	return rollout
+}
+
+func (r *AgentWebhookTestResources) NewRolloutWithWorkloadRef() *unstructured.Unstructured {
+	rollout := r.newRollout()
+	rollout.Object["spec"] = map[string]interface{}{
+		"workloadRef": map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"name":       r.Name,
+		},
+	}
+	return rollout
+}
+
+func (r *AgentWebhookTestResources) newRollout() *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetAPIVersion("argoproj.io/v1alpha1")
+	rollout.SetKind("Rollout")
+	rollout.SetName(r.Name)
+	rollout.SetNamespace(r.Namespace)
+	rollout.SetLabels(map[string]string{
+		"app":                       r.Name,
+		"cryostat.io/namespace":     r.Namespace,
+		"cryostat.io/name":          "cryostat",
+		"cryostat.io/callback-port": "123",
+	})
+	return rollout
+}
@@ -15,20 +15,22 @@
 package agent
 
 import (
-	"errors"
 	"fmt"
-	"strconv"
+	"regexp"
+	"slices"
 	"strings"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
 	"github.com/cryostatio/cryostat-operator/internal/controller/model"
-	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+var envNameReplaceRegexp = regexp.MustCompile(`[^A-Z0-9_]`)
+
+const agentEnvPrefix = "CRYOSTAT_AGENT_"
+
 func cryostatURL(cr *model.CryostatInstance, tls bool) string {
 	// Build the URL to the agent proxy service
 	scheme := "https"
@@ -48,129 +50,6 @@ func getAgentGatewayHTTPPort(cr *model.CryostatInstance) int32 {
 	return port
 }
 
-func getAgentCallbackPort(labels map[string]string) (*int32, error) {
-	result := constants.AgentCallbackContainerPort
-	port, pres := labels[constants.AgentLabelCallbackPort]
-	if pres {
-		// Parse the label value into an int32 and return an error if invalid
-		parsed, err := strconv.ParseInt(port, 10, 32)
-		if err != nil {
-			return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelCallbackPort, err.Error())
-		}
-		result = int32(parsed)
-	}
-	return &result, nil
-}
-
-func hasWriteAccess(labels map[string]string) (*bool, error) {
-	// Default to true
-	result := true
-	value, pres := labels[constants.AgentLabelReadOnly]
-	if pres {
-		// Parse the label value into a bool and return an error if invalid
-		parsed, err := strconv.ParseBool(value)
-		if err != nil {
-			return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelReadOnly, err.Error())
-		}
-		result = !parsed
-	}
-	return &result, nil
-}
-
-func getLogLevel(labels map[string]string) string {
-	result := defaultLogLevel
-	value, pres := labels[constants.AgentLabelLogLevel]
-	if pres {
-		result = value
-	}
-	return result
-}
-
-func getJavaOptionsVar(labels map[string]string) string {
-	result := defaultJavaOptsVar
-	value, pres := labels[constants.AgentLabelJavaOptionsVar]
-	if pres {
-		result = value
-	}
-	return result
-}
-
-func getHarvesterTemplate(labels map[string]string) string {
-	result := ""
-	value, pres := labels[constants.AgentLabelHarvesterTemplate]
-	if pres {
-		result = value
-	}
-	return result
-}
-
-func getSmartTriggersConfigMapNames(labels map[string]string) []string {
-	result := ""
-	value, pres := labels[constants.AgentLabelSmartTriggersConfigMaps]
-	if pres {
-		result = value
-	}
-	return strings.Split(result, ",")
-}
-
-func getHarvesterExitMaxAge(labels map[string]string) (*int32, error) {
-	value := defaultHarvesterExitMaxAge
-	age, pres := labels[constants.AgentLabelHarvesterExitMaxAge]
-	if pres {
-		// Parse the label value into an int32 and return an error if invalid
-		parsed, err := time.ParseDuration(age)
-		if err != nil {
-			return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelHarvesterExitMaxAge, err.Error())
-		}
-		value = int32(parsed.Milliseconds())
-	}
-	return &value, nil
-}
-
-func getHarvesterExitMaxSize(labels map[string]string) (*int32, error) {
-	value := defaultHarvesterExitMaxSize
-	size, pres := labels[constants.AgentLabelHarvesterExitMaxSize]
-	if pres {
-		parsed, err := resource.ParseQuantity(size)
-		if err != nil {
-			return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelHarvesterExitMaxSize, err.Error())
-		}
-		value = int32(parsed.Value())
-	}
-	return &value, nil
-}
-
-func getHarvesterPeriod(labels map[string]string) (*int32, error) {
-	period, pres := labels[constants.AgentLabelHarvesterPeriod]
-	if !pres {
-		return nil, nil
-	}
-
-	parsed, err := time.ParseDuration(period)
-	if err != nil {
-		return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelHarvesterPeriod, err.Error())
-	}
-	value := int32(parsed.Milliseconds())
-	return &value, nil
-}
-
-func getHarvesterMaxFiles(labels map[string]string) (*int32, error) {
-	maxFiles, pres := labels[constants.AgentLabelHarvesterMaxFiles]
-	if !pres {
-		return nil, nil
-	}
-
-	parsed, err := strconv.ParseInt(maxFiles, 10, 32)
-	if err != nil {
-		return nil, fmt.Errorf("invalid label value for \"%s\": %s", constants.AgentLabelHarvesterMaxFiles, err.Error())
-	}
-	if parsed <= 0 {
-		return nil, fmt.Errorf("invalid label value for \"%s\": must be positive", constants.AgentLabelHarvesterMaxFiles)
-	}
-	value := int32(parsed)
-	return &value, nil
-}
-
 func getResourceRequirements(cr *model.CryostatInstance) *corev1.ResourceRequirements {
 	resources := &corev1.ResourceRequirements{}
 	if cr.Spec.AgentOptions != nil {
@@ -181,25 +60,46 @@ func getResourceRequirements(cr *model.CryostatInstance) *corev1.ResourceRequire
 	return resources
 }
 
-func getTargetContainer(pod *corev1.Pod) (*corev1.Container, error) {
-	if len(pod.Spec.Containers) == 0 {
-		// Should never happen, Kubernetes doesn't allow this
-		return nil, errors.New("pod has no containers")
+// agentPropertyEnvName converts an agent property name, such as "cryostat.agent.webclient.connect.timeout-ms",
+// into the environment variable the agent reads it from, such as "CRYOSTAT_AGENT_WEBCLIENT_CONNECT_TIMEOUT_MS".
+// Environment variable names are returned unchanged.
+func agentPropertyEnvName(key string) (string, error) {
+	name := envNameReplaceRegexp.ReplaceAllString(strings.ToUpper(key), "_")
+	if !strings.HasPrefix(name, agentEnvPrefix) {
+		return "", fmt.Errorf("\"%s\" is not a Cryostat agent property", key)
 	}
-	label, pres := pod.Labels[constants.AgentLabelContainer]
-	if !pres {
-		// Use the first container by default
-		return &pod.Spec.Containers[0], nil
+	return name, nil
+}
+
+// mergeAgentProperties adds properties to the agent environment variables, where properties
+// in later sources take precedence over earlier ones
+func mergeAgentProperties(result map[string]string, properties map[string]string, source string) error {
+	for key, value := range properties {
+		name, err := agentPropertyEnvName(key)
+		if err != nil {
+			return fmt.Errorf("invalid property in %s: %s", source, err.Error())
+		}
+		result[name] = value
 	}
-	// Find the container matching the label
-	return findNamedContainer(pod.Spec.Containers, label)
+	return nil
 }
 
-func findNamedContainer(containers []corev1.Container, name string) (*corev1.Container, error) {
-	for i, container := range containers {
-		if container.Name == name {
-			return &containers[i], nil
+// appendAgentProperties adds agent properties as environment variables, unless the
+// container already sets them
+func appendAgentProperties(envs []corev1.EnvVar, properties map[string]string) []corev1.EnvVar {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		if !slices.ContainsFunc(envs, func(env corev1.EnvVar) bool { return env.Name == name }) {
+			names = append(names, name)
 		}
 	}
-	return nil, fmt.Errorf("no container found with name \"%s\"", name)
+	// Sort for a stable ordering
+	slices.Sort(names)
+	for _, name := range names {
+		envs = append(envs, corev1.EnvVar{
+			Name:  name,
+			Value: properties[name],
+		})
+	}
+	return envs
 }
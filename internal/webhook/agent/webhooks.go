@@ -16,11 +16,16 @@ package agent
 
 import (
 	"context"
+	"fmt"
 
 	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
 	"github.com/cryostatio/cryostat-operator/internal/controller/common"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,13 +34,38 @@ import (
 
 // podWebhookLog is for logging in this package.
 var podWebhookLog = logf.Log.WithName("pod-webhook")
-var deploymentWebhookLog = logf.Log.WithName("deployment-webhook")
+var workloadWebhookLog = logf.Log.WithName("workload-webhook")
 
 // Environment variable to override the agent init container image
 const agentInitImageTagEnv = "RELATED_IMAGE_AGENT_INIT"
 
+// Source of Events recorded by the agent webhooks
+const agentWebhookEventSource = "cryostat-agent-webhook"
+
 // +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod-namespace.cryostat.io,admissionReviewVersions=v1
 // +kubebuilder:webhook:path=/mutate--v1-deployment,mutating=true,failurePolicy=ignore,sideEffects=None,groups="apps",resources=deployments,verbs=create;update,versions=v1,name=mdeployment.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1-statefulset,mutating=true,failurePolicy=ignore,sideEffects=None,groups="apps",resources=statefulsets,verbs=create;update,versions=v1,name=mstatefulset.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1-daemonset,mutating=true,failurePolicy=ignore,sideEffects=None,groups="apps",resources=daemonsets,verbs=create;update,versions=v1,name=mdaemonset.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1-job,mutating=true,failurePolicy=ignore,sideEffects=None,groups="batch",resources=jobs,verbs=create,versions=v1,name=mjob.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1-cronjob,mutating=true,failurePolicy=ignore,sideEffects=None,groups="batch",resources=cronjobs,verbs=create;update,versions=v1,name=mcronjob.cryostat.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate--v1alpha1-rollout,mutating=true,failurePolicy=ignore,sideEffects=None,groups="argoproj.io",resources=rollouts,verbs=create;update,versions=v1alpha1,name=mrollout.cryostat.io,admissionReviewVersions=v1
+
+// Workloads whose pod templates receive the agent autoconfig labels and annotations,
+// by webhook path. Jobs are only mutated on creation, since their pod template is immutable.
+var agentWorkloads = map[string]func() runtime.Object{
+	"/mutate--v1-deployment":  func() runtime.Object { return &appsv1.Deployment{} },
+	"/mutate--v1-statefulset": func() runtime.Object { return &appsv1.StatefulSet{} },
+	"/mutate--v1-daemonset":   func() runtime.Object { return &appsv1.DaemonSet{} },
+	"/mutate--v1-job":         func() runtime.Object { return &batchv1.Job{} },
+	"/mutate--v1-cronjob":     func() runtime.Object { return &batchv1.CronJob{} },
+	// Argo Rollouts has no Go types we depend on, so decode these as unstructured
+	"/mutate--v1alpha1-rollout": func() runtime.Object {
+		rollout := &unstructured.Unstructured{}
+		rollout.SetGroupVersionKind(schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"})
+		return rollout
+	},
+}
 
 type AgentWebhook interface {
 	SetupWebhookWithManager(mgr ctrl.Manager) error
@@ -44,6 +74,7 @@ type AgentWebhook interface {
 type AgentWebhookConfig struct {
 	InitImageTag *string
 	FIPSEnabled  bool
+	IsOpenShift  bool
 	common.OSUtils
 }
 
@@ -68,41 +99,46 @@ func (r *agentWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	deploymentWebhook := admission.WithCustomDefaulter(mgr.GetScheme(), &appsv1.Deployment{}, &deploymentMutator{
+	workloadMutator := &workloadMutator{
 		client: mgr.GetClient(),
 		config: r.AgentWebhookConfig,
-		log:    &deploymentWebhookLog,
+		log:    &workloadWebhookLog,
 		gvk:    &gvk,
 		ReconcilerTLS: common.NewReconcilerTLS(&common.ReconcilerTLSConfig{
 			Client: mgr.GetClient(),
 			OS:     r.OSUtils,
 		}),
-	}).WithRecoverPanic(true)
+	}
+	for path, newWorkload := range agentWorkloads {
+		workloadWebhook := admission.WithCustomDefaulter(mgr.GetScheme(), newWorkload(), workloadMutator).WithRecoverPanic(true)
+		workloadWebhook.Handler = admitOnFailure(workloadWebhook.Handler)
+		mgr.GetWebhookServer().Register(path, workloadWebhook)
+	}
 
 	webhook := admission.WithCustomDefaulter(mgr.GetScheme(), &corev1.Pod{}, &podMutator{
 		client: mgr.GetClient(),
-		config: r.AgentWebhookConfig,
-		log:    &podWebhookLog,
-		gvk:    &gvk,
+		// Use an uncached reader to look up pod owners, to avoid caching every ReplicaSet in the cluster
+		reader:   mgr.GetAPIReader(),
+		recorder: mgr.GetEventRecorderFor(agentWebhookEventSource),
+		config:   r.AgentWebhookConfig,
+		log:      &podWebhookLog,
+		gvk:      &gvk,
 		ReconcilerTLS: common.NewReconcilerTLS(&common.ReconcilerTLSConfig{
 			Client: mgr.GetClient(),
 			OS:     r.OSUtils,
 		}),
 	}).WithRecoverPanic(true)
-	// Modify the webhook to never deny the pod from being admitted
-	webhook.Handler = allowAllRequests(webhook.Handler)
+	// Modify the webhook to only deny the pod from being admitted when its Cryostat requires it
+	webhook.Handler = admitOnFailure(webhook.Handler)
 	mgr.GetWebhookServer().Register("/mutate--v1-pod", webhook)
-
-	deploymentWebhook.Handler = allowAllRequests(deploymentWebhook.Handler)
-	mgr.GetWebhookServer().Register("/mutate--v1-deployment", deploymentWebhook)
 	return nil
 }
 
-type allowAllHandlerWrapper struct {
+type admitOnFailureHandlerWrapper struct {
 	impl admission.Handler
 }
 
-func (r *allowAllHandlerWrapper) Handle(ctx context.Context, req admission.Request) admission.Response {
+func (r *admitOnFailureHandlerWrapper) Handle(ctx context.Context, req admission.Request) admission.Response {
 	// Call the handler implementation
 	result := r.impl.Handle(ctx, req)
 	if !result.Allowed {
@@ -110,17 +146,22 @@ func (r *allowAllHandlerWrapper) Handle(ctx context.Context, req admission.Reque
 		if result.Result != nil {
 			msg = result.Result.Message
 		}
+		if result.Result != nil && result.Result.Reason == statusReasonAgentInjectionRejected {
+			// The failure policy requires rejecting the request
+			return result
+		}
 		podWebhookLog.Info("pod mutation failed", "result", msg)
+		result = result.WithWarnings(fmt.Sprintf("Cryostat agent was not configured: %s", msg))
 	}
-	// Modify the result to always permit the request
+	// Modify the result to permit the request
 	result.Allowed = true
 	return result
 }
 
-var _ admission.Handler = &allowAllHandlerWrapper{}
+var _ admission.Handler = &admitOnFailureHandlerWrapper{}
 
-func allowAllRequests(handler admission.Handler) admission.Handler {
-	return &allowAllHandlerWrapper{
+func admitOnFailure(handler admission.Handler) admission.Handler {
+	return &admitOnFailureHandlerWrapper{
 		impl: handler,
 	}
 }
@@ -0,0 +1,226 @@
+// Copyright The Cryostat Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	operatorv1beta2 "github.com/cryostatio/cryostat-operator/api/v1beta2"
+	"github.com/cryostatio/cryostat-operator/internal/controller/agentconfig"
+	"github.com/cryostatio/cryostat-operator/internal/controller/common"
+	"github.com/cryostatio/cryostat-operator/internal/controller/constants"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// workloadMutator propagates the agent autoconfig labels and annotations of a workload,
+// such as a Deployment, to the template of the pods it creates
+type workloadMutator struct {
+	client client.Client
+	log    *logr.Logger
+	gvk    *schema.GroupVersionKind
+	config *AgentWebhookConfig
+	common.ReconcilerTLS
+}
+
+var _ admission.CustomDefaulter = &workloadMutator{}
+
+// Default optionally mutates a workload to propagate the agent autoconfig labels
+// to pods within. The Pod mutator webhook will take care of the rest.
+func (r *workloadMutator) Default(ctx context.Context, obj runtime.Object) error {
+	workload, ok := obj.(client.Object)
+	if !ok {
+		return fmt.Errorf("expected a workload, but received a %T", obj)
+	}
+	kind := workloadKind(obj)
+
+	template, save, err := getPodTemplateMeta(obj)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		// The pods are created from a template in another workload, which is configured by its own webhook
+		r.log.V(1).Info("Workload has no pod template, skipping", "kind", kind, "name", workload.GetName(),
+			"namespace", workload.GetNamespace())
+		return nil
+	}
+
+	// Look up Cryostat
+	cr := &operatorv1beta2.Cryostat{}
+	err = r.client.Get(ctx, types.NamespacedName{
+		Name:      workload.GetLabels()[constants.AgentLabelCryostatName],
+		Namespace: workload.GetLabels()[constants.AgentLabelCryostatNamespace],
+	}, cr)
+	if err != nil {
+		return err
+	}
+
+	// Check if this workload is within a target namespace of the CR
+	if !slices.Contains(cr.Status.TargetNamespaces, workload.GetNamespace()) {
+		return fmt.Errorf("%s's namespace \"%s\" is not a target namespace of Cryostat \"%s\" in \"%s\"",
+			strings.ToLower(kind), workload.GetNamespace(), cr.Name, cr.Namespace)
+	}
+
+	config := agentconfig.Get(&metav1.ObjectMeta{
+		Labels:      workload.GetLabels(),
+		Annotations: workload.GetAnnotations(),
+	})
+
+	// Sanity check the non-string values
+	// Callback Port
+	_, err = agentconfig.ParseCallbackPorts(config)
+	if err != nil {
+		return err
+	}
+
+	// Write access
+	_, err = agentconfig.HasWriteAccess(config)
+	if err != nil {
+		return err
+	}
+
+	// Harvester settings
+	_, err = agentconfig.HarvesterTemplate(config)
+	if err != nil {
+		return err
+	}
+	_, err = agentconfig.HarvesterExitMaxAge(config)
+	if err != nil {
+		return err
+	}
+	_, err = agentconfig.HarvesterExitMaxSize(config)
+	if err != nil {
+		return err
+	}
+
+	// Additional system properties
+	_, err = agentconfig.SystemProperties(config)
+	if err != nil {
+		return err
+	}
+
+	// Propagate labels and annotations that exist. If they don't the pod defaulter will
+	// set default values itself.
+	for label, value := range workload.GetLabels() {
+		if strings.HasPrefix(label, constants.AgentLabelPrefix) {
+			if template.Labels == nil {
+				template.Labels = map[string]string{}
+			}
+			template.Labels[label] = value
+		}
+	}
+	for annotation, value := range workload.GetAnnotations() {
+		if strings.HasPrefix(annotation, constants.AgentLabelPrefix) {
+			if template.Annotations == nil {
+				template.Annotations = map[string]string{}
+			}
+			template.Annotations[annotation] = value
+		}
+	}
+	err = save()
+	if err != nil {
+		return err
+	}
+
+	// Use GenerateName for logging if no explicit Name is given
+	workloadName := workload.GetName()
+	if len(workloadName) == 0 {
+		workloadName = workload.GetGenerateName()
+	}
+	r.log.Info("Configured workload", "kind", kind, "name", workloadName, "namespace", workload.GetNamespace())
+	return nil
+}
+
+// getPodTemplateMeta returns the metadata of a workload's pod template,
+// along with a function to store changes made to it
+func getPodTemplateMeta(obj runtime.Object) (*metav1.ObjectMeta, func() error, error) {
+	unchanged := func() error { return nil }
+	switch workload := obj.(type) {
+	case *appsv1.Deployment:
+		return &workload.Spec.Template.ObjectMeta, unchanged, nil
+	case *appsv1.StatefulSet:
+		return &workload.Spec.Template.ObjectMeta, unchanged, nil
+	case *appsv1.DaemonSet:
+		return &workload.Spec.Template.ObjectMeta, unchanged, nil
+	case *batchv1.Job:
+		return &workload.Spec.Template.ObjectMeta, unchanged, nil
+	case *batchv1.CronJob:
+		return &workload.Spec.JobTemplate.Spec.Template.ObjectMeta, unchanged, nil
+	case *unstructured.Unstructured:
+		// Workloads without Go types, such as Argo Rollouts, with the pod template in spec.template
+		return getUnstructuredPodTemplateMeta(workload)
+	default:
+		return nil, nil, fmt.Errorf("unsupported workload type %T", obj)
+	}
+}
+
+// getUnstructuredPodTemplateMeta returns the metadata of the pod template in spec.template, or nil if there
+// is none. A Rollout may instead reference a Deployment's pod template using spec.workloadRef, and
+// Argo Rollouts rejects a Rollout that sets both.
+func getUnstructuredPodTemplateMeta(workload *unstructured.Unstructured) (*metav1.ObjectMeta, func() error, error) {
+	_, found, err := unstructured.NestedMap(workload.Object, "spec", "template")
+	if err != nil || !found {
+		return nil, nil, err
+	}
+	labelsPath := []string{"spec", "template", "metadata", "labels"}
+	annotationsPath := []string{"spec", "template", "metadata", "annotations"}
+	labels, _, err := unstructured.NestedStringMap(workload.Object, labelsPath...)
+	if err != nil {
+		return nil, nil, err
+	}
+	annotations, _, err := unstructured.NestedStringMap(workload.Object, annotationsPath...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := &metav1.ObjectMeta{
+		Labels:      labels,
+		Annotations: annotations,
+	}
+	save := func() error {
+		if meta.Labels != nil {
+			err := unstructured.SetNestedStringMap(workload.Object, meta.Labels, labelsPath...)
+			if err != nil {
+				return err
+			}
+		}
+		if meta.Annotations != nil {
+			return unstructured.SetNestedStringMap(workload.Object, meta.Annotations, annotationsPath...)
+		}
+		return nil
+	}
+	return meta, save, nil
+}
+
+func workloadKind(obj runtime.Object) string {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	if len(kind) == 0 {
+		// Typed objects decoded by the webhook may not have their kind set
+		kind = fmt.Sprintf("%T", obj)
+		kind = kind[strings.LastIndex(kind, ".")+1:]
+	}
+	return kind
+}
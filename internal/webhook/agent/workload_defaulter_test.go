@@ -26,20 +26,23 @@ import (
 	. "github.com/onsi/gomega"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
-type deploymentDefaulterTestInput struct {
+type workloadDefaulterTestInput struct {
 	client ctrlclient.Client
 	objs   []ctrlclient.Object
 	*webhooktests.AgentWebhookTestResources
 }
 
-var _ = Describe("PodDefaulter", func() {
-	var t *deploymentDefaulterTestInput
+var _ = Describe("WorkloadDefaulter", func() {
+	var t *workloadDefaulterTestInput
 	var otherNS string
 	count := 0
 
@@ -50,7 +53,7 @@ var _ = Describe("PodDefaulter", func() {
 	BeforeEach(func() {
 		ns := namespaceWithSuffix("test")
 		otherNS = namespaceWithSuffix("other")
-		t = &deploymentDefaulterTestInput{
+		t = &workloadDefaulterTestInput{
 			AgentWebhookTestResources: &webhooktests.AgentWebhookTestResources{
 				TestResources: &test.TestResources{
 					Name:             "cryostat",
@@ -87,6 +90,119 @@ var _ = Describe("PodDefaulter", func() {
 		count++
 	})
 
+	updateCryostatStatus := func() {
+		cr := t.getCryostatInstance()
+		cr.Status.TargetNamespaces = cr.Spec.TargetNamespaces
+		t.updateCryostatInstanceStatus(cr)
+	}
+
+	Context("Configuring a StatefulSet", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostat().Object)
+		})
+
+		It("Should propagate autoconfig labels to pod template", func() {
+			updateCryostatStatus()
+			original := t.NewStatefulSet()
+			expected := t.NewMutatedStatefulSet()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &appsv1.StatefulSet{}
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			expectTemplatePropagated(&actual.Spec.Template, &expected.Spec.Template)
+		})
+	})
+
+	Context("Configuring a DaemonSet", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostat().Object)
+		})
+
+		It("Should propagate autoconfig labels to pod template", func() {
+			updateCryostatStatus()
+			original := t.NewDaemonSet()
+			expected := t.NewMutatedDaemonSet()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &appsv1.DaemonSet{}
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			expectTemplatePropagated(&actual.Spec.Template, &expected.Spec.Template)
+		})
+	})
+
+	Context("Configuring a Job", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostat().Object)
+		})
+
+		It("Should propagate autoconfig labels to pod template", func() {
+			updateCryostatStatus()
+			original := t.NewJob()
+			expected := t.NewMutatedJob()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &batchv1.Job{}
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			// The API server adds its own labels to Job pod templates
+			for key, value := range expected.Spec.Template.Labels {
+				Expect(actual.Spec.Template.Labels).To(HaveKeyWithValue(key, value))
+			}
+		})
+	})
+
+	Context("Configuring a CronJob", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostat().Object)
+		})
+
+		It("Should propagate autoconfig labels to pod template", func() {
+			updateCryostatStatus()
+			original := t.NewCronJob()
+			expected := t.NewMutatedCronJob()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &batchv1.CronJob{}
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			expectTemplatePropagated(&actual.Spec.JobTemplate.Spec.Template, &expected.Spec.JobTemplate.Spec.Template)
+		})
+	})
+
+	Context("Configuring an Argo Rollout", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostat().Object)
+		})
+
+		It("Should propagate autoconfig labels to pod template", func() {
+			updateCryostatStatus()
+			original := t.NewRollout()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &unstructured.Unstructured{}
+			actual.SetGroupVersionKind(original.GroupVersionKind())
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			labels, _, err := unstructured.NestedStringMap(actual.Object, "spec", "template", "metadata", "labels")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(labels).To(Equal(map[string]string{
+				"app":                       "someApplication",
+				"cryostat.io/namespace":     t.Namespace,
+				"cryostat.io/name":          "cryostat",
+				"cryostat.io/callback-port": "123",
+			}))
+		})
+
+		It("Should not add a pod template to a Rollout referencing a workload", func() {
+			updateCryostatStatus()
+			original := t.NewRolloutWithWorkloadRef()
+			Expect(t.client.Create(ctx, original)).To(Succeed())
+
+			actual := &unstructured.Unstructured{}
+			actual.SetGroupVersionKind(original.GroupVersionKind())
+			Expect(t.client.Get(ctx, ctrlclient.ObjectKeyFromObject(original), actual)).To(Succeed())
+			Expect(actual.Object["spec"]).ToNot(HaveKey("template"))
+			Expect(actual.Object["spec"]).To(HaveKey("workloadRef"))
+		})
+	})
+
 	Context("Configuring a Deployment", func() {
 
 		var expectedDeployment *appsv1.Deployment
@@ -132,25 +248,45 @@ var _ = Describe("PodDefaulter", func() {
 
 				ExpectDeployment()
 			})
+
+			Context("with agent annotations", func() {
+				BeforeEach(func() {
+					t.objs = append(t.objs, t.NewCryostat().Object)
+					originalDeployment = t.NewDeploymentAnnotations()
+					expectedDeployment = t.NewMutatedDeploymentAnnotations()
+				})
+
+				ExpectDeployment()
+
+				It("Should propagate autoconfig annotations to pod template", func() {
+					actual := t.getDeployment(expectedDeployment)
+					Expect(actual.Spec.Template.Annotations).To(Equal(expectedDeployment.Spec.Template.Annotations))
+				})
+			})
 		})
 	})
 })
 
-func (t *deploymentDefaulterTestInput) getCryostatInstance() *model.CryostatInstance {
+func expectTemplatePropagated(actual *corev1.PodTemplateSpec, expected *corev1.PodTemplateSpec) {
+	Expect(actual.Labels).To(Equal(expected.Labels))
+	Expect(actual.Annotations).To(Equal(expected.Annotations))
+}
+
+func (t *workloadDefaulterTestInput) getCryostatInstance() *model.CryostatInstance {
 	cr := &operatorv1beta2.Cryostat{}
 	err := t.client.Get(context.Background(), types.NamespacedName{Name: t.Name, Namespace: t.Namespace}, cr)
 	Expect(err).ToNot(HaveOccurred())
 	return t.ConvertNamespacedToModel(cr)
 }
 
-func (t *deploymentDefaulterTestInput) getDeployment(expected *appsv1.Deployment) *appsv1.Deployment {
+func (t *workloadDefaulterTestInput) getDeployment(expected *appsv1.Deployment) *appsv1.Deployment {
 	deployment := &appsv1.Deployment{}
 	err := t.client.Get(context.Background(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, deployment)
 	Expect(err).ToNot(HaveOccurred())
 	return deployment
 }
 
-func (t *deploymentDefaulterTestInput) updateCryostatInstanceStatus(cr *model.CryostatInstance) {
+func (t *workloadDefaulterTestInput) updateCryostatInstanceStatus(cr *model.CryostatInstance) {
 	err := t.client.Status().Update(context.Background(), cr.Object)
 	Expect(err).ToNot(HaveOccurred())
 }
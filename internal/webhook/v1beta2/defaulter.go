@@ -38,7 +38,7 @@ func (r *cryostatDefaulter) Default(ctx context.Context, obj runtime.Object) err
 	}
 	r.log.Info("defaulting Cryostat", "name", cr.Name, "namespace", cr.Namespace)
 
-	if cr.Spec.TargetNamespaces == nil {
+	if cr.Spec.TargetNamespaces == nil && cr.Spec.TargetNamespaceSelector == nil {
 		r.log.Info("defaulting target namespaces", "name", cr.Name, "namespace", cr.Namespace)
 		cr.Spec.TargetNamespaces = []string{cr.Namespace}
 	}
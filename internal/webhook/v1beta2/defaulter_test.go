@@ -107,6 +107,17 @@ var _ = Describe("CryostatDefaulter", func() {
 		})
 	})
 
+	Context("with target namespace selector", func() {
+		BeforeEach(func() {
+			t.objs = append(t.objs, t.NewCryostatWithTargetNamespaceSelector().Object)
+		})
+
+		It("should not set default target namespace", func() {
+			result := t.getCryostatInstance()
+			Expect(result.TargetNamespaces).To(BeEmpty())
+		})
+	})
+
 	Context("without audit setting", func() {
 		BeforeEach(func() {
 			t.objs = append(t.objs, t.NewCryostat().Object)
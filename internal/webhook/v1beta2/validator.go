@@ -22,6 +22,7 @@ import (
 	"github.com/go-logr/logr"
 	authnv1 "k8s.io/api/authentication/v1"
 	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -63,6 +64,10 @@ func NewErrNotPermitted(operation string, namespace string) *ErrNotPermitted {
 }
 
 func (e *ErrNotPermitted) Error() string {
+	if e.namespace == metav1.NamespaceAll {
+		return fmt.Sprintf("unable to %s Cryostat: user is not permitted to create a Cryostat in all namespaces, "+
+			"which is required to use a target namespace selector", e.operation)
+	}
 	return fmt.Sprintf("unable to %s Cryostat: user is not permitted to create a Cryostat in namespace %s", e.operation, e.namespace)
 }
 
@@ -85,35 +90,59 @@ func (r *cryostatValidator) validate(ctx context.Context, obj runtime.Object, op
 	// Check that for each target namespace, the user has permissions
 	// to create a Cryostat CR in that namespace
 	for _, namespace := range cr.Spec.TargetNamespaces {
-		sar := &authzv1.SubjectAccessReview{
-			Spec: authzv1.SubjectAccessReviewSpec{
-				User:   userInfo.Username,
-				Groups: userInfo.Groups,
-				UID:    userInfo.UID,
-				Extra:  translateExtra(userInfo.Extra),
-				ResourceAttributes: &authzv1.ResourceAttributes{
-					Namespace: namespace,
-					Verb:      "create",
-					Group:     operatorv1beta2.GroupVersion.Group,
-					Version:   operatorv1beta2.GroupVersion.Version,
-					Resource:  "cryostats",
-				},
-			},
+		allowed, err := r.canCreateCryostat(ctx, userInfo, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, NewErrNotPermitted(op, namespace)
 		}
+	}
 
-		err := r.client.Create(ctx, sar)
+	// The set of namespaces matching a selector can change at any time without
+	// any changes to the Cryostat CR, so require that the user has permissions
+	// to create a Cryostat CR in all namespaces
+	if cr.Spec.TargetNamespaceSelector != nil {
+		_, err := metav1.LabelSelectorAsSelector(cr.Spec.TargetNamespaceSelector)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check permissions: %w", err)
+			return nil, fmt.Errorf("invalid target namespace selector: %w", err)
 		}
-
-		if !sar.Status.Allowed {
-			return nil, NewErrNotPermitted(op, namespace)
+		allowed, err := r.canCreateCryostat(ctx, userInfo, metav1.NamespaceAll)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, NewErrNotPermitted(op, metav1.NamespaceAll)
 		}
 	}
 
 	return nil, nil
 }
 
+func (r *cryostatValidator) canCreateCryostat(ctx context.Context, userInfo authnv1.UserInfo, namespace string) (bool, error) {
+	sar := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			Groups: userInfo.Groups,
+			UID:    userInfo.UID,
+			Extra:  translateExtra(userInfo.Extra),
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     operatorv1beta2.GroupVersion.Group,
+				Version:   operatorv1beta2.GroupVersion.Version,
+				Resource:  "cryostats",
+			},
+		},
+	}
+
+	err := r.client.Create(ctx, sar)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permissions: %w", err)
+	}
+	return sar.Status.Allowed, nil
+}
+
 func translateExtra(extra map[string]authnv1.ExtraValue) map[string]authzv1.ExtraValue {
 	var result map[string]authzv1.ExtraValue
 	if extra == nil {
@@ -28,6 +28,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -126,6 +127,17 @@ var _ = Describe("CryostatValidator", func() {
 			})
 		})
 
+		Context("creates a Cryostat with a target namespace selector", func() {
+			BeforeEach(func() {
+				cr = t.NewCryostatWithTargetNamespaceSelector()
+			})
+
+			It("should allow the request", func() {
+				err := t.client.Create(ctx, cr.Object)
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("creates a Cryostat with invalid trusted certificate entries", func() {
 			BeforeEach(func() {
 				cr.Spec.TrustedCertSecrets = []operatorv1beta2.CertificateSecret{
@@ -201,6 +213,19 @@ var _ = Describe("CryostatValidator", func() {
 			})
 		})
 
+		Context("creates a Cryostat with a target namespace selector", func() {
+			BeforeEach(func() {
+				cr = t.NewCryostatWithTargetNamespaceSelector()
+				cr.Spec.TargetNamespaces = []string{t.Namespace}
+			})
+
+			It("should deny the request", func() {
+				err := saClient.Create(ctx, cr.Object)
+				Expect(err).To((HaveOccurred()))
+				expectErrNotPermitted(err, "create", metav1.NamespaceAll)
+			})
+		})
+
 		Context("deletes a Cryostat", func() {
 			BeforeEach(func() {
 				t.objs = append(t.objs, cr.Object)